@@ -226,7 +226,14 @@ func genBinOp(w *bytes.Buffer, t ops.Type, op ops.Op) {
 		// Short-circuit if right is concrete.
 		fmt.Fprintf(w, "if y.IsConcrete() {\n")
 		fmt.Fprintf(w, "	if y.C >= %d {\n", t.Bits)
-		fmt.Fprintf(w, "		return %s{C: 0}\n", resType)
+		if symop == "SRsh" {
+			// Shifting a signed value right by at
+			// least its width fills it with its sign
+			// bit.
+			fmt.Fprintf(w, "		return %s{S: x.sym(cache).SRsh(cache.z3.FromInt(%d, cache.sort%s).(z3.BV))}\n", resType, t.Bits-1, t.StName)
+		} else {
+			fmt.Fprintf(w, "		return %s{C: 0}\n", resType)
+		}
 		fmt.Fprintf(w, "	}\n")
 		if t.Bits == 64 {
 			fmt.Fprintf(w, "}\n")
@@ -31,6 +31,16 @@ func TestEquivUint32(t *testing.T) {
 		uint32(0), uint32(1), uint32(31), uint32(32), uint32(1<<32-1))
 }
 
+func TestEquivInt8(t *testing.T) {
+	testEquiv(t, reflect.TypeOf(Int8{}), Int8.sym,
+		int8(0), int8(1), int8(-1), int8(7), int8(math.MaxInt8), int8(math.MinInt8))
+}
+
+func TestEquivUint64(t *testing.T) {
+	testEquiv(t, reflect.TypeOf(Uint64{}), Uint64.sym,
+		uint64(0), uint64(1), uint64(63), uint64(1<<63), uint64(math.MaxUint64))
+}
+
 func TestEquivInteger(t *testing.T) {
 	var huge big.Int
 	huge.SetString("123456789012345678901234567890", 10)
@@ -59,7 +69,9 @@ func testEquiv(t *testing.T, typ reflect.Type, symMethod interface{}, vals ...in
 		case "IsConcrete", "Eval", "String":
 			continue
 		case "Lsh", "Rsh":
-			// TODO: Test these
+			t.Run(m.Name, func(t *testing.T) {
+				testShift(t, ctx, typ, symMethod, m, rvals)
+			})
 			continue
 		}
 		t.Run(m.Name, func(t *testing.T) {
@@ -91,6 +103,28 @@ func testEquiv(t *testing.T, typ reflect.Type, symMethod interface{}, vals ...in
 	}
 }
 
+// testShift checks that concrete and symbolic shift method m agree
+// for every combination of concrete and symbolic operands.
+func testShift(t *testing.T, ctx *z3.Context, typ reflect.Type, symMethod interface{}, m reflect.Method, rvals []reflect.Value) {
+	counts := []uint64{0, 1, 7, 8, 31, 32, 33, 63, 64, 65, 1 << 63}
+	for _, x := range rvals {
+		xc, xs := wrap(ctx, typ, symMethod, []reflect.Value{x})
+		for _, count := range counts {
+			yc, ys := wrap(ctx, reflect.TypeOf(Uint64{}), Uint64.sym, []reflect.Value{reflect.ValueOf(count)})
+			want := m.Func.Call([]reflect.Value{xc[0], yc[0]})[0]
+			for _, args := range [][]reflect.Value{
+				{xs[0], yc[0]}, {xc[0], ys[0]}, {xs[0], ys[0]},
+			} {
+				got := m.Func.Call(args)[0]
+				eq := want.MethodByName("Eq").Call([]reflect.Value{got})[0]
+				if !toBool(ctx, eq.Interface().(Bool)) {
+					t.Errorf("%s(%v, %v) = %v, want %v", m.Name, x, count, got, want.FieldByName("C").Interface())
+				}
+			}
+		}
+	}
+}
+
 // genArgs returns the Cartesian product vals^n.
 func genArgs(vals []reflect.Value, n int) [][]reflect.Value {
 	if n == 0 {
@@ -131,6 +165,9 @@ func sliceInterface(rs []reflect.Value) []interface{} {
 }
 
 func toBool(ctx *z3.Context, b Bool) bool {
+	if b.IsConcrete() {
+		return b.C
+	}
 	// Since everything is literals, the simplifier should have no
 	// trouble getting the answer and is dramatically faster than
 	// the solver.
@@ -0,0 +1,583 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package stssa translates Go functions in SSA form into Z3 terms.
+//
+// Translate takes a function built by golang.org/x/tools/go/ssa and
+// arguments as values from package st, and returns the function's
+// results as st values. Arguments may be concrete or symbolic, so a
+// single translation can answer questions about a function over all
+// of its inputs, which is the starting point for symbolic execution
+// and test generation tools.
+//
+// Only a pure fragment of Go is supported: the function's
+// parameters and results must have boolean or machine integer types,
+// its body may use arithmetic, bitwise, comparison, and conversion
+// operators on those types, and its control flow may branch but not
+// loop. Calls, memory operations, and other instructions are
+// reported as errors.
+//
+// Operations follow the semantics of the corresponding st methods.
+// In particular, division by a symbolic zero does not panic, and
+// shifts by a negative signed amount are treated as shifts by a
+// large unsigned amount.
+package stssa
+
+import (
+	"fmt"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"math/big"
+	"reflect"
+
+	"github.com/aclements/go-z3/internal/ops"
+	"github.com/aclements/go-z3/st"
+	"github.com/aclements/go-z3/z3"
+	"golang.org/x/tools/go/ssa"
+)
+
+// stTypes maps Go basic type names to the corresponding st types.
+var stTypes = map[string]reflect.Type{
+	"bool":    reflect.TypeOf(st.Bool{}),
+	"int":     reflect.TypeOf(st.Int{}),
+	"int8":    reflect.TypeOf(st.Int8{}),
+	"int16":   reflect.TypeOf(st.Int16{}),
+	"int32":   reflect.TypeOf(st.Int32{}),
+	"int64":   reflect.TypeOf(st.Int64{}),
+	"uint":    reflect.TypeOf(st.Uint{}),
+	"uint8":   reflect.TypeOf(st.Uint8{}),
+	"uint16":  reflect.TypeOf(st.Uint16{}),
+	"uint32":  reflect.TypeOf(st.Uint32{}),
+	"uint64":  reflect.TypeOf(st.Uint64{}),
+	"uintptr": reflect.TypeOf(st.Uintptr{}),
+}
+
+// A basic describes a supported Go type.
+type basic struct {
+	ops.Type
+	st reflect.Type
+}
+
+func (b *basic) isBool() bool {
+	return b.Flags&ops.IsBool != 0
+}
+
+func (b *basic) signed() bool {
+	return b.Flags&ops.IsUnsigned == 0
+}
+
+// basicOf returns the description of typ, or nil if typ is not
+// supported.
+func basicOf(typ types.Type) *basic {
+	t, ok := typ.Underlying().(*types.Basic)
+	if !ok {
+		return nil
+	}
+	name := types.Typ[t.Kind()].Name()
+	for _, ot := range ops.Types {
+		if ot.ConType == name && stTypes[name] != nil {
+			return &basic{ot, stTypes[name]}
+		}
+	}
+	return nil
+}
+
+// Translate translates fn applied to args into Z3 terms in ctx.
+//
+// Each element of args must be the st type corresponding to the type
+// of fn's parameter, such as st.Int32 for an int32 parameter, or nil.
+// A nil argument is replaced by a fresh symbolic value named after
+// the parameter. If args is shorter than fn's parameter list, the
+// remaining arguments are nil.
+//
+// Translate returns fn's results as st values, in order. If fn uses
+// a feature that is not supported, Translate returns an error
+// describing it.
+func Translate(ctx *z3.Context, fn *ssa.Function, args ...interface{}) (results []interface{}, err error) {
+	if len(fn.FreeVars) != 0 {
+		return nil, fmt.Errorf("stssa: %s: closures are not supported", fn)
+	}
+	if len(args) > len(fn.Params) {
+		return nil, fmt.Errorf("stssa: %s: %d arguments for %d parameters", fn, len(args), len(fn.Params))
+	}
+	if fn.Blocks == nil {
+		return nil, fmt.Errorf("stssa: %s: function has no body", fn)
+	}
+
+	tr := &translator{
+		ctx:    ctx,
+		fn:     fn,
+		vals:   make(map[ssa.Value]z3.Value),
+		conds:  make(map[*ssa.BasicBlock]z3.Bool),
+		blocks: make(map[*ssa.BasicBlock]bool),
+	}
+	if err := z3.Try(func() {
+		for i, p := range fn.Params {
+			var arg interface{}
+			if i < len(args) {
+				arg = args[i]
+			}
+			if err = tr.param(p, arg); err != nil {
+				return
+			}
+		}
+		results, err = tr.translate()
+	}); err != nil {
+		return nil, fmt.Errorf("stssa: %s: %v", fn, err)
+	}
+	return results, err
+}
+
+type translator struct {
+	ctx *z3.Context
+	fn  *ssa.Function
+
+	// vals maps SSA values to their translations.
+	vals map[ssa.Value]z3.Value
+
+	// conds maps each block to the condition under which it
+	// executes.
+	conds map[*ssa.BasicBlock]z3.Bool
+
+	// blocks is the set of blocks that have been translated.
+	blocks map[*ssa.BasicBlock]bool
+
+	// rets are the Return instructions of the function and the
+	// translations of their results.
+	rets []*ssa.Return
+}
+
+func (tr *translator) errorf(pos token.Pos, format string, args ...interface{}) error {
+	msg := fmt.Sprintf(format, args...)
+	if tr.fn.Prog != nil && pos.IsValid() {
+		return fmt.Errorf("stssa: %s: %s", tr.fn.Prog.Fset.Position(pos), msg)
+	}
+	return fmt.Errorf("stssa: %s: %s", tr.fn, msg)
+}
+
+func (tr *translator) sort(b *basic) z3.Sort {
+	if b.isBool() {
+		return tr.ctx.BoolSort()
+	}
+	return tr.ctx.BVSort(b.Bits)
+}
+
+// param binds SSA parameter p to arg.
+func (tr *translator) param(p *ssa.Parameter, arg interface{}) error {
+	b := basicOf(p.Type())
+	if b == nil {
+		return tr.errorf(p.Pos(), "parameter %s has unsupported type %s", p.Name(), p.Type())
+	}
+	if arg == nil {
+		tr.vals[p] = tr.ctx.FreshConst(p.Name(), tr.sort(b))
+		return nil
+	}
+	rv := reflect.ValueOf(arg)
+	if rv.Type() != b.st {
+		return tr.errorf(p.Pos(), "argument for %s has type %T, want %s", p.Name(), arg, b.st)
+	}
+	if !rv.MethodByName("IsConcrete").Call(nil)[0].Bool() {
+		sym := rv.FieldByName("S").Interface().(z3.Value)
+		if sym.Context() != tr.ctx {
+			return tr.errorf(p.Pos(), "argument for %s belongs to a different Context", p.Name())
+		}
+		tr.vals[p] = sym
+		return nil
+	}
+	c := rv.FieldByName("C")
+	switch {
+	case b.isBool():
+		tr.vals[p] = tr.ctx.FromBool(c.Bool())
+	case b.signed():
+		tr.vals[p] = tr.ctx.FromInt(c.Int(), tr.sort(b))
+	default:
+		tr.vals[p] = tr.ctx.FromBigInt(new(big.Int).SetUint64(c.Uint()), tr.sort(b))
+	}
+	return nil
+}
+
+// translate translates the body of tr.fn and returns its results.
+func (tr *translator) translate() ([]interface{}, error) {
+	order, err := tr.topoSort()
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range order {
+		if err := tr.block(b); err != nil {
+			return nil, err
+		}
+	}
+	if len(tr.rets) == 0 {
+		return nil, tr.errorf(tr.fn.Pos(), "function never returns")
+	}
+
+	// Merge the results of each return. At most one return
+	// executes, so their order doesn't matter.
+	sig := tr.fn.Signature.Results()
+	results := make([]interface{}, sig.Len())
+	for i := range results {
+		b := basicOf(sig.At(i).Type())
+		if b == nil {
+			return nil, tr.errorf(tr.fn.Pos(), "result %d has unsupported type %s", i, sig.At(i).Type())
+		}
+		last := tr.rets[len(tr.rets)-1]
+		res, err := tr.value(last.Results[i])
+		if err != nil {
+			return nil, err
+		}
+		for j := len(tr.rets) - 2; j >= 0; j-- {
+			ret := tr.rets[j]
+			v, err := tr.value(ret.Results[i])
+			if err != nil {
+				return nil, err
+			}
+			res = tr.conds[ret.Block()].IfThenElse(v, res)
+		}
+		out := reflect.New(b.st).Elem()
+		out.FieldByName("S").Set(reflect.ValueOf(res))
+		results[i] = out.Interface()
+	}
+	return results, nil
+}
+
+// topoSort returns the reachable blocks of tr.fn in topological
+// order, or an error if the control flow graph has a cycle.
+func (tr *translator) topoSort() ([]*ssa.BasicBlock, error) {
+	const (
+		unvisited = iota
+		active
+		done
+	)
+	state := make(map[*ssa.BasicBlock]int)
+	var post []*ssa.BasicBlock
+	var visit func(b *ssa.BasicBlock) error
+	visit = func(b *ssa.BasicBlock) error {
+		state[b] = active
+		for _, s := range b.Succs {
+			switch state[s] {
+			case active:
+				var pos token.Pos
+				if len(s.Instrs) > 0 {
+					pos = s.Instrs[0].Pos()
+				}
+				return tr.errorf(pos, "loops are not supported")
+			case unvisited:
+				if err := visit(s); err != nil {
+					return err
+				}
+			}
+		}
+		state[b] = done
+		post = append(post, b)
+		return nil
+	}
+	if err := visit(tr.fn.Blocks[0]); err != nil {
+		return nil, err
+	}
+	for i, j := 0, len(post)-1; i < j; i, j = i+1, j-1 {
+		post[i], post[j] = post[j], post[i]
+	}
+	return post, nil
+}
+
+// edgeCond returns the condition under which control flows from pred
+// to b.
+func (tr *translator) edgeCond(pred, b *ssa.BasicBlock) (z3.Bool, error) {
+	cond := tr.conds[pred]
+	if len(pred.Instrs) == 0 {
+		return cond, nil
+	}
+	ifInstr, ok := pred.Instrs[len(pred.Instrs)-1].(*ssa.If)
+	if !ok || pred.Succs[0] == pred.Succs[1] {
+		return cond, nil
+	}
+	v, err := tr.value(ifInstr.Cond)
+	if err != nil {
+		return z3.Bool{}, err
+	}
+	c := v.(z3.Bool)
+	if pred.Succs[1] == b {
+		c = c.Not()
+	}
+	return cond.And(c), nil
+}
+
+// block translates the instructions of b.
+func (tr *translator) block(b *ssa.BasicBlock) error {
+	if b.Index == 0 {
+		tr.conds[b] = tr.ctx.FromBool(true)
+	} else {
+		var edges []z3.Bool
+		for _, pred := range b.Preds {
+			if !tr.blocks[pred] {
+				// Unreachable predecessor.
+				continue
+			}
+			c, err := tr.edgeCond(pred, b)
+			if err != nil {
+				return err
+			}
+			edges = append(edges, c)
+		}
+		tr.conds[b] = tr.ctx.OrN(edges...)
+	}
+	tr.blocks[b] = true
+
+	for _, instr := range b.Instrs {
+		if err := tr.instr(instr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (tr *translator) instr(instr ssa.Instruction) error {
+	switch instr := instr.(type) {
+	case *ssa.DebugRef:
+		return nil
+
+	case *ssa.Jump, *ssa.If:
+		// Handled by edgeCond.
+		return nil
+
+	case *ssa.Return:
+		tr.rets = append(tr.rets, instr)
+		return nil
+
+	case *ssa.Phi:
+		return tr.phi(instr)
+
+	case *ssa.BinOp:
+		return tr.binOp(instr)
+
+	case *ssa.UnOp:
+		return tr.unOp(instr)
+
+	case *ssa.Convert:
+		return tr.convert(instr, instr.X)
+
+	case *ssa.ChangeType:
+		return tr.convert(instr, instr.X)
+	}
+	return tr.errorf(instr.Pos(), "unsupported instruction %s", instr)
+}
+
+// value returns the translation of v.
+func (tr *translator) value(v ssa.Value) (z3.Value, error) {
+	if x, ok := tr.vals[v]; ok {
+		return x, nil
+	}
+	c, ok := v.(*ssa.Const)
+	if !ok {
+		return nil, tr.errorf(v.Pos(), "unsupported value %s", v)
+	}
+	b := basicOf(c.Type())
+	if b == nil {
+		return nil, tr.errorf(c.Pos(), "constant %s has unsupported type %s", c, c.Type())
+	}
+	var x z3.Value
+	switch {
+	case b.isBool():
+		x = tr.ctx.FromBool(c.Value != nil && constant.BoolVal(c.Value))
+	case b.signed():
+		x = tr.ctx.FromInt(c.Int64(), tr.sort(b))
+	default:
+		x = tr.ctx.FromBigInt(new(big.Int).SetUint64(c.Uint64()), tr.sort(b))
+	}
+	tr.vals[v] = x
+	return x, nil
+}
+
+func (tr *translator) phi(phi *ssa.Phi) error {
+	b := phi.Block()
+	var res z3.Value
+	for i := len(phi.Edges) - 1; i >= 0; i-- {
+		pred := b.Preds[i]
+		if !tr.blocks[pred] {
+			continue
+		}
+		v, err := tr.value(phi.Edges[i])
+		if err != nil {
+			return err
+		}
+		if res == nil {
+			res = v
+			continue
+		}
+		c, err := tr.edgeCond(pred, b)
+		if err != nil {
+			return err
+		}
+		res = c.IfThenElse(v, res)
+	}
+	if res == nil {
+		return tr.errorf(phi.Pos(), "phi %s has no reachable edges", phi.Name())
+	}
+	tr.vals[phi] = res
+	return nil
+}
+
+func (tr *translator) binOp(instr *ssa.BinOp) error {
+	b := basicOf(instr.X.Type())
+	if b == nil {
+		return tr.errorf(instr.Pos(), "operand of %s has unsupported type %s", instr, instr.X.Type())
+	}
+	x, err := tr.value(instr.X)
+	if err != nil {
+		return err
+	}
+	y, err := tr.value(instr.Y)
+	if err != nil {
+		return err
+	}
+
+	if b.isBool() {
+		l, r := x.(z3.Bool), y.(z3.Bool)
+		switch instr.Op {
+		case token.EQL:
+			tr.vals[instr] = l.Eq(r)
+		case token.NEQ:
+			tr.vals[instr] = l.NE(r)
+		default:
+			return tr.errorf(instr.Pos(), "unsupported operator %s on bool", instr.Op)
+		}
+		return nil
+	}
+
+	l, r := x.(z3.BV), y.(z3.BV)
+	var res z3.Value
+	switch instr.Op {
+	case token.ADD:
+		res = l.Add(r)
+	case token.SUB:
+		res = l.Sub(r)
+	case token.MUL:
+		res = l.Mul(r)
+	case token.QUO:
+		if b.signed() {
+			res = l.SDiv(r)
+		} else {
+			res = l.UDiv(r)
+		}
+	case token.REM:
+		if b.signed() {
+			res = l.SRem(r)
+		} else {
+			res = l.URem(r)
+		}
+	case token.AND:
+		res = l.And(r)
+	case token.OR:
+		res = l.Or(r)
+	case token.XOR:
+		res = l.Xor(r)
+	case token.AND_NOT:
+		res = l.And(r.Not())
+	case token.SHL:
+		res = tr.shift(b, l, r, l.Lsh)
+	case token.SHR:
+		if b.signed() {
+			res = tr.shift(b, l, r, l.SRsh)
+		} else {
+			res = tr.shift(b, l, r, l.URsh)
+		}
+	case token.EQL:
+		res = l.Eq(r)
+	case token.NEQ:
+		res = l.NE(r)
+	case token.LSS:
+		if b.signed() {
+			res = l.SLT(r)
+		} else {
+			res = l.ULT(r)
+		}
+	case token.LEQ:
+		if b.signed() {
+			res = l.SLE(r)
+		} else {
+			res = l.ULE(r)
+		}
+	case token.GTR:
+		if b.signed() {
+			res = l.SGT(r)
+		} else {
+			res = l.UGT(r)
+		}
+	case token.GEQ:
+		if b.signed() {
+			res = l.SGE(r)
+		} else {
+			res = l.UGE(r)
+		}
+	default:
+		return tr.errorf(instr.Pos(), "unsupported operator %s", instr.Op)
+	}
+	tr.vals[instr] = res
+	return nil
+}
+
+// shift applies op to l and the shift count r, which may have a
+// different width than l.
+func (tr *translator) shift(b *basic, l, r z3.BV, op func(z3.BV) z3.BV) z3.BV {
+	rbits := r.Sort().BVSize()
+	switch {
+	case rbits < b.Bits:
+		return op(r.ZeroExtend(b.Bits - rbits))
+	case rbits == b.Bits:
+		return op(r)
+	}
+	// r is wider than l. A count of at least b.Bits shifts out
+	// every bit, as does a count of all 1s in l's width.
+	over := r.UGE(tr.ctx.FromInt(int64(b.Bits), r.Sort()).(z3.BV))
+	return op(tr.ctx.FromInt(-1, l.Sort()).(z3.BV)).If(over, op(r.Extract(b.Bits-1, 0)))
+}
+
+func (tr *translator) unOp(instr *ssa.UnOp) error {
+	b := basicOf(instr.X.Type())
+	if b == nil {
+		return tr.errorf(instr.Pos(), "operand of %s has unsupported type %s", instr, instr.X.Type())
+	}
+	x, err := tr.value(instr.X)
+	if err != nil {
+		return err
+	}
+	switch {
+	case instr.Op == token.NOT && b.isBool():
+		tr.vals[instr] = x.(z3.Bool).Not()
+	case instr.Op == token.SUB && !b.isBool():
+		tr.vals[instr] = x.(z3.BV).Neg()
+	case instr.Op == token.XOR && !b.isBool():
+		tr.vals[instr] = x.(z3.BV).Not()
+	default:
+		return tr.errorf(instr.Pos(), "unsupported operator %s", instr.Op)
+	}
+	return nil
+}
+
+// convert translates a conversion of x to the type of instr.
+func (tr *translator) convert(instr ssa.Value, x ssa.Value) error {
+	from, to := basicOf(x.Type()), basicOf(instr.Type())
+	if from == nil || to == nil || from.isBool() != to.isBool() {
+		return tr.errorf(instr.Pos(), "unsupported conversion %s", instr)
+	}
+	v, err := tr.value(x)
+	if err != nil {
+		return err
+	}
+	if from.isBool() || from.Bits == to.Bits {
+		tr.vals[instr] = v
+		return nil
+	}
+	bv := v.(z3.BV)
+	switch {
+	case to.Bits < from.Bits:
+		tr.vals[instr] = bv.Extract(to.Bits-1, 0)
+	case from.signed():
+		tr.vals[instr] = bv.SignExtend(to.Bits - from.Bits)
+	default:
+		tr.vals[instr] = bv.ZeroExtend(to.Bits - from.Bits)
+	}
+	return nil
+}
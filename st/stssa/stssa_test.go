@@ -0,0 +1,152 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stssa
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/aclements/go-z3/st"
+	"github.com/aclements/go-z3/z3"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+const src = `package p
+
+func abs(x int32) int32 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func clamp(x, lo, hi uint8) uint8 {
+	if x < lo {
+		x = lo
+	} else if x > hi {
+		x = hi
+	}
+	return x
+}
+
+func widen(a int8, s uint64) (int64, bool) {
+	w := int64(a) << s
+	return w, w > 100
+}
+
+func loop(n int) int {
+	s := 0
+	for i := 0; i < n; i++ {
+		s += i
+	}
+	return s
+}
+
+func call(x int) int {
+	return int(abs(int32(x)))
+}
+`
+
+func buildPkg(t *testing.T) *ssa.Package {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg, _, err := ssautil.BuildPackage(&types.Config{Importer: importer.Default()}, fset, types.NewPackage("p", ""), []*ast.File{f}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkg
+}
+
+func TestTranslateSymbolic(t *testing.T) {
+	pkg := buildPkg(t)
+	ctx := z3.NewContext(nil)
+
+	// abs(x) is negative only for the minimum int32.
+	x := st.AnyInt32(ctx, "x")
+	res, err := Translate(ctx, pkg.Func("abs"), x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := z3.NewSolver(ctx)
+	s.Assert(res[0].(st.Int32).LT(st.Int32{C: 0}).S)
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("want sat, got %v, %v", sat, err)
+	}
+	if got := x.Eval(s.Model()); got != -1<<31 {
+		t.Errorf("abs(x) < 0 for x = %d, want %d", got, -1<<31)
+	}
+
+	// widen's second result agrees with its first.
+	a := st.AnyInt8(ctx, "a")
+	res, err = Translate(ctx, pkg.Func("widen"), a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, big := res[0].(st.Int64), res[1].(st.Bool)
+	s = z3.NewSolver(ctx)
+	s.Assert(big.Eq(w.GT(st.Int64{C: 100})).Not().S)
+	if sat, err := s.Check(); sat || err != nil {
+		t.Fatalf("want unsat, got %v, %v", sat, err)
+	}
+}
+
+func TestTranslateConcrete(t *testing.T) {
+	pkg := buildPkg(t)
+	ctx := z3.NewContext(nil)
+	s := z3.NewSolver(ctx)
+	if sat, _ := s.Check(); !sat {
+		t.Fatal("empty solver is unsat")
+	}
+	m := s.Model()
+
+	for _, test := range []struct{ x, want uint8 }{{5, 10}, {15, 15}, {250, 200}} {
+		res, err := Translate(ctx, pkg.Func("clamp"), st.Uint8{C: test.x}, st.Uint8{C: 10}, st.Uint8{C: 200})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := res[0].(st.Uint8).Eval(m); got != test.want {
+			t.Errorf("clamp(%d, 10, 200) = %d, want %d", test.x, got, test.want)
+		}
+	}
+
+	for _, test := range []struct {
+		a    int8
+		s    uint64
+		want int64
+	}{{-3, 2, -12}, {1, 62, 1 << 62}, {1, 64, 0}, {-1, 1000, 0}} {
+		res, err := Translate(ctx, pkg.Func("widen"), st.Int8{C: test.a}, st.Uint64{C: test.s})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := res[0].(st.Int64).Eval(m); got != test.want {
+			t.Errorf("widen(%d, %d) = %d, want %d", test.a, test.s, got, test.want)
+		}
+	}
+}
+
+func TestTranslateUnsupported(t *testing.T) {
+	pkg := buildPkg(t)
+	ctx := z3.NewContext(nil)
+	for name, want := range map[string]string{
+		"loop": "loops are not supported",
+		"call": "unsupported instruction",
+	} {
+		if _, err := Translate(ctx, pkg.Func(name)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Translate(%s): want error containing %q, got %v", name, want, err)
+		}
+	}
+	if _, err := Translate(ctx, pkg.Func("abs"), st.Int{C: 1}); err == nil || !strings.Contains(err.Error(), "want st.Int32") {
+		t.Errorf("want argument type error, got %v", err)
+	}
+}
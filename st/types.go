@@ -320,7 +320,7 @@ func (x Int) Rsh(y Uint64) Int {
 	var rs z3.BV
 	if y.IsConcrete() {
 		if y.C >= 64 {
-			return Int{C: 0}
+			return Int{S: x.sym(cache).SRsh(cache.z3.FromInt(63, cache.sortInt).(z3.BV))}
 		}
 	}
 	rs = y.sym(cache)
@@ -680,7 +680,7 @@ func (x Int8) Rsh(y Uint64) Int8 {
 	var rs z3.BV
 	if y.IsConcrete() {
 		if y.C >= 8 {
-			return Int8{C: 0}
+			return Int8{S: x.sym(cache).SRsh(cache.z3.FromInt(7, cache.sortInt8).(z3.BV))}
 		}
 		rs = Uint8{C: uint8(y.C)}.sym(cache)
 	} else {
@@ -1043,7 +1043,7 @@ func (x Int16) Rsh(y Uint64) Int16 {
 	var rs z3.BV
 	if y.IsConcrete() {
 		if y.C >= 16 {
-			return Int16{C: 0}
+			return Int16{S: x.sym(cache).SRsh(cache.z3.FromInt(15, cache.sortInt16).(z3.BV))}
 		}
 		rs = Uint16{C: uint16(y.C)}.sym(cache)
 	} else {
@@ -1406,7 +1406,7 @@ func (x Int32) Rsh(y Uint64) Int32 {
 	var rs z3.BV
 	if y.IsConcrete() {
 		if y.C >= 32 {
-			return Int32{C: 0}
+			return Int32{S: x.sym(cache).SRsh(cache.z3.FromInt(31, cache.sortInt32).(z3.BV))}
 		}
 		rs = Uint32{C: uint32(y.C)}.sym(cache)
 	} else {
@@ -1766,7 +1766,7 @@ func (x Int64) Rsh(y Uint64) Int64 {
 	var rs z3.BV
 	if y.IsConcrete() {
 		if y.C >= 64 {
-			return Int64{C: 0}
+			return Int64{S: x.sym(cache).SRsh(cache.z3.FromInt(63, cache.sortInt64).(z3.BV))}
 		}
 	}
 	rs = y.sym(cache)
@@ -0,0 +1,83 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "runtime"
+
+// An Arena owns a set of ASTs (including Values) and releases them
+// all at once when it is closed.
+//
+// Normally, every AST has a finalizer that releases it after it
+// becomes unreachable. For computations that create many
+// short-lived intermediate expressions, this puts pressure on the
+// garbage collector and delays releasing native memory. ASTs owned
+// by an Arena have no finalizers.
+//
+// An Arena owns exactly the ASTs created through its Context (see
+// Arena.Context). This includes the results of operations on Values
+// it owns, since an operation creates its result in the Context of
+// its receiver. ASTs created through any other
+// Context, including the one the Arena was opened in, are
+// unaffected, so other goroutines can keep using that Context while
+// the Arena is open. ASTs that must outlive the Arena must be passed
+// to Keep. All other ASTs the Arena owns must not be used after it is
+// closed.
+type Arena struct {
+	ctx    *Context
+	impls  []*astImpl
+	closed bool
+}
+
+// NewArena opens a new Arena in ctx. Use the Arena's Context to
+// create ASTs it owns.
+func (ctx *Context) NewArena() *Arena {
+	a := &Arena{}
+	ctx.do(func() {
+		a.ctx = ctx.derive()
+		a.ctx.arena = a
+	})
+	return a
+}
+
+// Context returns a Context that shares the Z3 context of the
+// Context a was opened in, but whose ASTs a owns. It can be mixed
+// freely with that Context.
+func (a *Arena) Context() *Context {
+	return a.ctx
+}
+
+// Keep transfers ownership of vals from a to the garbage collector,
+// so they remain valid after a is closed. Values that a does not own
+// are unaffected.
+func (a *Arena) Keep(vals ...Value) {
+	a.ctx.do(func() {
+		for _, v := range vals {
+			impl := (*astImpl)(v.impl())
+			if impl.arena == a {
+				impl.arena = nil
+				runtime.SetFinalizer(impl, (*astImpl).release)
+			}
+		}
+	})
+}
+
+// Close releases all ASTs owned by a, except those passed to Keep.
+// Closing a again does nothing. After Close, ASTs created through a's
+// Context, such as results of operations on kept Values, are
+// released by the garbage collector as usual.
+func (a *Arena) Close() {
+	a.ctx.do(func() {
+		if a.closed {
+			return
+		}
+		a.closed = true
+		for _, impl := range a.impls {
+			if impl.arena == a {
+				impl.decRef()
+			}
+		}
+		a.impls = nil
+	})
+}
@@ -0,0 +1,55 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestArena(t *testing.T) {
+	ctx := NewContext(nil)
+	ctx.TrackASTs(true)
+	live := func() int {
+		n := 0
+		for _, site := range ctx.LeakSites(0) {
+			n += site.Count
+		}
+		return n
+	}
+	x := ctx.IntConst("x")
+
+	a := ctx.NewArena()
+	actx := a.Context()
+	sum := x
+	for i := 0; i < 100; i++ {
+		sum = actx.IntConst(fmt.Sprint("y", i)).Add(sum)
+	}
+	inner := actx.NewArena()
+	tmp := inner.Context().IntConst("t").Mul(sum)
+	// ASTs created through ctx are not owned by the Arenas, even
+	// while they are open.
+	other := ctx.IntConst("z")
+	inner.Close()
+	a.Keep(sum, tmp)
+	a.Close()
+	a.Close()
+
+	// Only x, other, and sum remain. tmp belonged to inner.
+	if n := live(); n != 3 {
+		t.Errorf("%d ASTs live after closing arena, want 3", n)
+	}
+	s := NewSolver(ctx)
+	s.Assert(sum.Eq(x))
+	s.Assert(other.Eq(x))
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Errorf("Check() = %v, %v, want sat", sat, err)
+	}
+	// New ASTs in a closed Arena's Context belong to the garbage
+	// collector.
+	if w := actx.IntConst("w"); (*astImpl)(w.impl()).arena != nil {
+		t.Errorf("AST created after Close is owned by the Arena")
+	}
+}
@@ -53,6 +53,30 @@ func (ctx *Context) ConstArray(domain Sort, value Value) Array {
 	return res
 }
 
+// Lambda returns an Array value representing the function that maps
+// vars to body.
+//
+// Each of vars must be a constant, such as those returned by
+// Context.Const. These constants are bound in body. The result has an
+// array sort whose domain is the sorts of vars and whose range is the
+// sort of body.
+func (ctx *Context) Lambda(vars []Value, body Value) Array {
+	cvars := make([]C.Z3_app, len(vars))
+	res := Array(wrapValue(ctx, func() C.Z3_ast {
+		for i, v := range vars {
+			cvars[i] = C.Z3_to_app(ctx.c, v.impl().c)
+		}
+		var cvp *C.Z3_app
+		if len(cvars) > 0 {
+			cvp = &cvars[0]
+		}
+		return C.Z3_mk_lambda_const(ctx.c, C.uint(len(cvars)), cvp, body.impl().c)
+	}))
+	runtime.KeepAlive(vars)
+	runtime.KeepAlive(body)
+	return res
+}
+
 //go:generate go run genwrap.go -t Array $GOFILE
 
 // Select returns the value of array x at index i.
@@ -15,7 +15,7 @@ import "C"
 func (l Array) Eq(r Array) Bool {
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_eq(ctx.c, l.c, r.c)
+		return C.Z3_mk_eq(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -27,15 +27,29 @@ func (l Array) NE(r Array) Bool {
 	return l.ctx.Distinct(l, r)
 }
 
+// If returns a Value equal to l if cond is true, otherwise r.
+//
+// This is a typed form of Bool.IfThenElse.
+func (l Array) If(cond Bool, r Array) Array {
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_ite(ctx.c, cond.cref(), l.cref(), r.cref())
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(cond)
+	runtime.KeepAlive(r)
+	return Array(val)
+}
+
 // Select returns the value of array x at index i.
 //
 // i's sort must match x's domain. The result has the sort of x's
 // range.
 func (x Array) Select(i Value) Value {
-	// Generated from array.go:63.
+	// Generated from array.go:87.
 	ctx := x.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_select(ctx.c, x.c, i.impl().c)
+		return C.Z3_mk_select(ctx.c, x.cref(), i.impl().cref())
 	})
 	runtime.KeepAlive(x)
 	runtime.KeepAlive(i)
@@ -48,10 +62,10 @@ func (x Array) Select(i Value) Value {
 // i's sort must match x's domain and v's sort must match x's range.
 // The result has the same sort as x.
 func (x Array) Store(i Value, v Value) Array {
-	// Generated from array.go:71.
+	// Generated from array.go:95.
 	ctx := x.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_store(ctx.c, x.c, i.impl().c, v.impl().c)
+		return C.Z3_mk_store(ctx.c, x.cref(), i.impl().cref(), v.impl().cref())
 	})
 	runtime.KeepAlive(x)
 	runtime.KeepAlive(i)
@@ -64,10 +78,10 @@ func (x Array) Store(i Value, v Value) Array {
 //
 // This is useful for extracting array values interpreted by models.
 func (x Array) Default() Value {
-	// Generated from array.go:78.
+	// Generated from array.go:102.
 	ctx := x.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_array_default(ctx.c, x.c)
+		return C.Z3_mk_array_default(ctx.c, x.cref())
 	})
 	runtime.KeepAlive(x)
 	return val.lift(KindUnknown)
@@ -24,12 +24,24 @@ type AST struct {
 type astImpl struct {
 	ctx *Context
 	c   C.Z3_ast
+
+	// pinned indicates that this AST belongs to ctx's cache, so
+	// it must not be released. See ctxCache.
+	pinned bool
+
+	// arena is the Arena that will release this AST, or nil if
+	// its finalizer will.
+	arena *Arena
 }
 
 // wrapAST wraps a C Z3_ast as a Go AST. This must be called with the
 // ctx.lock held.
 func wrapAST(ctx *Context, c C.Z3_ast) AST {
-	impl := &astImpl{ctx, c}
+	impl := &astImpl{ctx, c, false, ctx.arena}
+	if impl.arena != nil && impl.arena.closed {
+		// Kept Values still refer to the Arena's Context.
+		impl.arena = nil
+	}
 	// Note that, even if c was just returned by an allocation
 	// function, we're still responsible for incrementing its
 	// reference count. This is weird, but also nice because we
@@ -42,14 +54,65 @@ func wrapAST(ctx *Context, c C.Z3_ast) AST {
 	// If we allocate two objects without incrementing the
 	// refcount on the first, Z3 will reclaim the first object!
 	C.Z3_inc_ref(ctx.c, c)
-	runtime.SetFinalizer(impl, func(impl *astImpl) {
-		impl.ctx.do(func() {
-			C.Z3_dec_ref(impl.ctx.c, impl.c)
-		})
-	})
+	if ctx.leaks != nil {
+		ctx.leaks.add(impl)
+	}
+	if impl.arena != nil {
+		impl.arena.impls = append(impl.arena.impls, impl)
+	} else {
+		runtime.SetFinalizer(impl, (*astImpl).release)
+	}
 	return AST{impl, noEq{}}
 }
 
+func (impl *astImpl) release() {
+	impl.ctx.releaseAST(impl.decRef)
+}
+
+// decRef drops impl's reference. It must be called with the ctx.lock
+// held, or as a releaseAST function.
+func (impl *astImpl) decRef() {
+	if impl.c != nil {
+		C.Z3_dec_ref(impl.ctx.c, impl.c)
+		impl.c = nil
+		if impl.ctx.leaks != nil {
+			impl.ctx.leaks.remove(impl)
+		}
+	}
+}
+
+// cref returns impl's Z3 AST, or panics if impl has been released.
+// It must be called with the ctx.lock held.
+func (impl *astImpl) cref() C.Z3_ast {
+	if impl.c == nil {
+		panic("z3: use of released AST")
+	}
+	return impl.c
+}
+
+// do is like impl.ctx.do, but panics if impl has been released.
+func (impl *astImpl) do(f func()) {
+	impl.ctx.do(func() {
+		impl.cref()
+		f()
+	})
+}
+
+// Release drops ast's reference to the underlying Z3 object now,
+// rather than when ast is garbage collected. This bounds native
+// memory use in programs that create many short-lived expressions.
+//
+// ast, and the Value it came from if it was obtained with AsAST, must
+// not be used after Release; doing so panics. Releasing it again does
+// nothing.
+func (ast AST) Release() {
+	if ast.pinned {
+		return
+	}
+	runtime.SetFinalizer(ast.astImpl, nil)
+	ast.release()
+}
+
 // Context returns the Context that created ast.
 func (ast AST) Context() *Context {
 	if ast.astImpl == nil {
@@ -66,8 +129,8 @@ func (ast AST) Equal(o AST) bool {
 	// finalizer to. We can't make *that* pointer 1:1 with the C
 	// pointer without making the object permanently live.
 	var out bool
-	ast.ctx.do(func() {
-		out = z3ToBool(C.Z3_is_eq_ast(ast.ctx.c, ast.c, o.c))
+	ast.do(func() {
+		out = z3ToBool(C.Z3_is_eq_ast(ast.ctx.c, ast.c, o.cref()))
 	})
 	runtime.KeepAlive(ast)
 	runtime.KeepAlive(o)
@@ -77,7 +140,7 @@ func (ast AST) Equal(o AST) bool {
 // String returns ast as an S-expression.
 func (ast AST) String() string {
 	var res string
-	ast.ctx.do(func() {
+	ast.do(func() {
 		res = C.GoString(C.Z3_ast_to_string(ast.ctx.c, ast.c))
 	})
 	runtime.KeepAlive(ast)
@@ -88,7 +151,7 @@ func (ast AST) String() string {
 // the same hash code.
 func (ast AST) Hash() uint64 {
 	var res uint64
-	ast.ctx.do(func() {
+	ast.do(func() {
 		res = uint64(C.Z3_get_ast_hash(ast.ctx.c, ast.c))
 	})
 	runtime.KeepAlive(ast)
@@ -97,9 +160,14 @@ func (ast AST) Hash() uint64 {
 
 // ID returns the unique identifier for ast. Within a Context, two
 // ASTs have the same ID if and only if they are Equal.
+//
+// Since AST and Value cannot be compared with ==, ID is the usual way
+// to use them as map keys. Z3 may reuse an ID once its AST is no
+// longer referenced, so the map must keep the AST (or Value) alive,
+// for example by storing it in the map value.
 func (ast AST) ID() uint64 {
 	var res uint64
-	ast.ctx.do(func() {
+	ast.do(func() {
 		res = uint64(C.Z3_get_ast_id(ast.ctx.c, ast.c))
 	})
 	runtime.KeepAlive(ast)
@@ -107,10 +175,14 @@ func (ast AST) ID() uint64 {
 }
 
 // Translate copies ast into the target Context.
+//
+// To translate a Value, use x.AsAST().Translate(target).AsValue().
+// This is the only way to share expressions between Contexts, for
+// example to hand work to solvers running in parallel.
 func (ast AST) Translate(target *Context) AST {
 	var res AST
-	target.do(func() {
-		res = wrapAST(target, C.Z3_translate(ast.ctx.c, ast.c, target.c))
+	ast.ctx.doWith(target, func() {
+		res = wrapAST(target, C.Z3_translate(ast.ctx.c, ast.cref(), target.c))
 	})
 	runtime.KeepAlive(ast)
 	return res
@@ -119,7 +191,7 @@ func (ast AST) Translate(target *Context) AST {
 // Kind returns ast's kind.
 func (ast AST) Kind() ASTKind {
 	var res ASTKind
-	ast.ctx.do(func() {
+	ast.do(func() {
 		res = ASTKind(C.Z3_get_ast_kind(ast.ctx.c, ast.c))
 	})
 	runtime.KeepAlive(ast)
@@ -150,7 +222,7 @@ func (ast AST) AsSort() Sort {
 	// Weirdly, Z3 doesn't provide an API for this. But these are
 	// all just casts.
 	var sort Sort
-	ast.ctx.do(func() {
+	ast.do(func() {
 		csort := C.Z3_sort(unsafe.Pointer(ast.c))
 		sort = wrapSort(ast.ctx, csort, KindUnknown)
 	})
@@ -167,7 +239,7 @@ func (ast AST) AsFuncDecl() FuncDecl {
 		panic("AST has kind " + kind.String() + ", not ASTKindFuncDecl")
 	}
 	var funcdecl FuncDecl
-	ast.ctx.do(func() {
+	ast.do(func() {
 		funcdecl = wrapFuncDecl(ast.ctx, C.Z3_to_func_decl(ast.ctx.c, ast.c))
 	})
 	runtime.KeepAlive(ast)
@@ -4,7 +4,10 @@
 
 package z3
 
-import "testing"
+import (
+	"sync"
+	"testing"
+)
 
 func TestASTEquality(t *testing.T) {
 	ctx := NewContext(nil)
@@ -62,3 +65,102 @@ func TestASTTranslate(t *testing.T) {
 	x := ctx1.BoolConst("x")
 	x.AsAST().Translate(ctx2).AsValue().(Bool).Eq(ctx2.FromBool(true))
 }
+
+func TestASTTranslateConcurrent(t *testing.T) {
+	// Translating in both directions at once must not race or
+	// deadlock.
+	ctx1, ctx2 := NewContext(nil), NewContext(nil)
+	x1, x2 := ctx1.IntConst("x"), ctx2.IntConst("x")
+	e1, e2 := x1.Add(x1), x2.Mul(x2)
+	var wg sync.WaitGroup
+	for _, dir := range []struct {
+		e   Int
+		dst *Context
+	}{{e1, ctx2}, {e2, ctx1}} {
+		wg.Add(1)
+		go func(e Int, dst *Context) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				y := e.AsAST().Translate(dst).AsValue().(Int)
+				if y.Context() != dst || y.String() != e.String() {
+					t.Errorf("Translate(%v) = %v", e, y)
+					return
+				}
+			}
+		}(dir.e, dir.dst)
+	}
+	wg.Wait()
+}
+
+func TestValueApp(t *testing.T) {
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	e := x.Add(y.Mul(ctx.FromInt(2, ints).(Int)))
+
+	if !e.IsApp() {
+		t.Fatalf("%v is not an application", e)
+	}
+	if got, want := e.Decl(), y.Add(x).Decl(); !got.AsAST().Equal(want.AsAST()) {
+		t.Errorf("%v has decl %v, want %v", e, got, want)
+	}
+	if n := e.NumArgs(); n != 2 {
+		t.Fatalf("%v has %d args, want 2", e, n)
+	}
+	if a := e.Arg(0); !a.AsAST().Equal(x.AsAST()) {
+		t.Errorf("%v arg 0 is %v, want %v", e, a, x)
+	}
+	m := e.Arg(1).(Int)
+	if got, want := m.Decl(), x.Mul(y).Decl(); !got.AsAST().Equal(want.AsAST()) {
+		t.Errorf("%v has decl %v, want %v", m, got, want)
+	}
+	if n := x.NumArgs(); n != 0 || !x.IsApp() {
+		t.Errorf("constant %v has IsApp %v, NumArgs %d", x, x.IsApp(), n)
+	}
+	if !x.Decl().Apply().AsAST().Equal(x.AsAST()) {
+		t.Errorf("re-applying decl of %v failed", x)
+	}
+
+	wantPanic(t, "out of range", func() { e.Arg(2) })
+
+	u := e.UpdateArgs(y, x)
+	if want := y.Add(x); !u.AsAST().Equal(want.AsAST()) {
+		t.Errorf("UpdateArgs = %v, want %v", u, want)
+	}
+	wantPanic(t, "wrong number", func() { e.UpdateArgs(x) })
+}
+
+func TestASTMapKey(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+
+	// Deduplicate structurally identical values built separately.
+	vals := []Value{x.Add(y), y, x.Add(y), ctx.IntConst("y"), x}
+	set := make(map[uint64]Value)
+	for _, v := range vals {
+		set[v.AsAST().ID()] = v
+	}
+	if len(set) != 3 {
+		t.Errorf("got %d distinct values, want 3", len(set))
+	}
+	for id, v := range set {
+		if o := set[v.AsAST().ID()]; !o.AsAST().Equal(v.AsAST()) || o.AsAST().Hash() != v.AsAST().Hash() {
+			t.Errorf("value %v with ID %d does not round-trip", v, id)
+		}
+	}
+}
+
+func TestASTReleased(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	x.Release()
+	wantPanic(t, "use of released AST", func() { _ = x.String() })
+	wantPanic(t, "use of released AST", func() { x.AsAST().Hash() })
+	wantPanic(t, "use of released AST", func() { x.Add(y) })
+	wantPanic(t, "use of released AST", func() { y.Add(x) })
+	wantPanic(t, "use of released AST", func() { ctx.Distinct(y, x) })
+	wantPanic(t, "use of released AST", func() { y.AsAST().Equal(x.AsAST()) })
+	if got := y.Add(y).String(); got != "(+ y y)" {
+		t.Errorf("y+y = %s, want (+ y y)", got)
+	}
+}
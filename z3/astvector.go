@@ -0,0 +1,240 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "runtime"
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+*/
+import "C"
+
+// An ASTVector is a growable vector of ASTs stored in Z3.
+//
+// Several Z3 APIs produce ASTVectors. Accessing elements in place
+// avoids converting large results to Go slices.
+type ASTVector struct {
+	*astVectorImpl
+	noEq
+}
+
+type astVectorImpl struct {
+	ctx *Context
+	c   C.Z3_ast_vector
+}
+
+// NewASTVector returns a new, empty ASTVector.
+func NewASTVector(ctx *Context) *ASTVector {
+	var v *ASTVector
+	ctx.do(func() {
+		v = wrapASTVector(ctx, C.Z3_mk_ast_vector(ctx.c))
+	})
+	return v
+}
+
+// wrapASTVector wraps a C Z3_ast_vector as a Go ASTVector. This must
+// be called with the ctx.lock held.
+func wrapASTVector(ctx *Context, c C.Z3_ast_vector) *ASTVector {
+	impl := &astVectorImpl{ctx, c}
+	C.Z3_ast_vector_inc_ref(ctx.c, c)
+	runtime.SetFinalizer(impl, func(impl *astVectorImpl) {
+		impl.ctx.release(func() {
+			C.Z3_ast_vector_dec_ref(impl.ctx.c, impl.c)
+		})
+	})
+	return &ASTVector{impl, noEq{}}
+}
+
+// Len returns the number of elements in v.
+func (v *ASTVector) Len() int {
+	var n int
+	v.ctx.do(func() {
+		n = int(C.Z3_ast_vector_size(v.ctx.c, v.c))
+	})
+	runtime.KeepAlive(v)
+	return n
+}
+
+// Get returns the i'th element of v. It panics if i is out of range.
+func (v *ASTVector) Get(i int) AST {
+	v.checkIndex(i)
+	var ast AST
+	v.ctx.do(func() {
+		ast = wrapAST(v.ctx, C.Z3_ast_vector_get(v.ctx.c, v.c, C.uint(i)))
+	})
+	runtime.KeepAlive(v)
+	return ast
+}
+
+// Set sets the i'th element of v to ast. It panics if i is out of
+// range.
+func (v *ASTVector) Set(i int, ast AST) {
+	v.checkIndex(i)
+	v.ctx.do(func() {
+		C.Z3_ast_vector_set(v.ctx.c, v.c, C.uint(i), ast.c)
+	})
+	runtime.KeepAlive(v)
+	runtime.KeepAlive(ast)
+}
+
+func (v *ASTVector) checkIndex(i int) {
+	if i < 0 || i >= v.Len() {
+		panic("ASTVector index out of range")
+	}
+}
+
+// Append adds asts to the end of v.
+func (v *ASTVector) Append(asts ...AST) {
+	v.ctx.do(func() {
+		for _, ast := range asts {
+			C.Z3_ast_vector_push(v.ctx.c, v.c, ast.c)
+		}
+	})
+	runtime.KeepAlive(v)
+	runtime.KeepAlive(asts)
+}
+
+// Resize changes the length of v to n. If this grows v, the new
+// elements are nil and must be Set before they are used.
+func (v *ASTVector) Resize(n int) {
+	v.ctx.do(func() {
+		C.Z3_ast_vector_resize(v.ctx.c, v.c, C.uint(n))
+	})
+	runtime.KeepAlive(v)
+}
+
+// Translate copies v and its elements into the target Context.
+func (v *ASTVector) Translate(target *Context) *ASTVector {
+	var res *ASTVector
+	v.ctx.doWith(target, func() {
+		res = wrapASTVector(target, C.Z3_ast_vector_translate(v.ctx.c, v.c, target.c))
+	})
+	runtime.KeepAlive(v)
+	return res
+}
+
+// Slice returns the elements of v as a Go slice.
+func (v *ASTVector) Slice() []AST {
+	var res []AST
+	v.ctx.do(func() {
+		n := C.Z3_ast_vector_size(v.ctx.c, v.c)
+		res = make([]AST, n)
+		for i := C.uint(0); i < n; i++ {
+			res[i] = wrapAST(v.ctx, C.Z3_ast_vector_get(v.ctx.c, v.c, i))
+		}
+	})
+	runtime.KeepAlive(v)
+	return res
+}
+
+// String returns a string representation of v.
+func (v *ASTVector) String() string {
+	var res string
+	v.ctx.do(func() {
+		res = C.GoString(C.Z3_ast_vector_to_string(v.ctx.c, v.c))
+	})
+	runtime.KeepAlive(v)
+	return res
+}
+
+// An ASTMap is a map from ASTs to ASTs stored in Z3. Keys are
+// compared structurally, like AST.Equal.
+type ASTMap struct {
+	*astMapImpl
+	noEq
+}
+
+type astMapImpl struct {
+	ctx *Context
+	c   C.Z3_ast_map
+}
+
+// NewASTMap returns a new, empty ASTMap.
+func NewASTMap(ctx *Context) *ASTMap {
+	var impl *astMapImpl
+	ctx.do(func() {
+		impl = &astMapImpl{ctx, C.Z3_mk_ast_map(ctx.c)}
+		C.Z3_ast_map_inc_ref(ctx.c, impl.c)
+	})
+	runtime.SetFinalizer(impl, func(impl *astMapImpl) {
+		impl.ctx.release(func() {
+			C.Z3_ast_map_dec_ref(impl.ctx.c, impl.c)
+		})
+	})
+	return &ASTMap{impl, noEq{}}
+}
+
+// Len returns the number of entries in m.
+func (m *ASTMap) Len() int {
+	var n int
+	m.ctx.do(func() {
+		n = int(C.Z3_ast_map_size(m.ctx.c, m.c))
+	})
+	runtime.KeepAlive(m)
+	return n
+}
+
+// Get returns the value m maps key to, and whether key is in m.
+func (m *ASTMap) Get(key AST) (AST, bool) {
+	var val AST
+	var ok bool
+	m.ctx.do(func() {
+		ok = z3ToBool(C.Z3_ast_map_contains(m.ctx.c, m.c, key.c))
+		if ok {
+			val = wrapAST(m.ctx, C.Z3_ast_map_find(m.ctx.c, m.c, key.c))
+		}
+	})
+	runtime.KeepAlive(m)
+	runtime.KeepAlive(key)
+	return val, ok
+}
+
+// Set maps key to val in m, replacing any existing mapping for key.
+func (m *ASTMap) Set(key, val AST) {
+	m.ctx.do(func() {
+		C.Z3_ast_map_insert(m.ctx.c, m.c, key.c, val.c)
+	})
+	runtime.KeepAlive(m)
+	runtime.KeepAlive(key)
+	runtime.KeepAlive(val)
+}
+
+// Delete removes key from m, if present.
+func (m *ASTMap) Delete(key AST) {
+	m.ctx.do(func() {
+		C.Z3_ast_map_erase(m.ctx.c, m.c, key.c)
+	})
+	runtime.KeepAlive(m)
+	runtime.KeepAlive(key)
+}
+
+// Reset removes all entries from m.
+func (m *ASTMap) Reset() {
+	m.ctx.do(func() {
+		C.Z3_ast_map_reset(m.ctx.c, m.c)
+	})
+	runtime.KeepAlive(m)
+}
+
+// Keys returns the keys of m.
+func (m *ASTMap) Keys() *ASTVector {
+	var res *ASTVector
+	m.ctx.do(func() {
+		res = wrapASTVector(m.ctx, C.Z3_ast_map_keys(m.ctx.c, m.c))
+	})
+	runtime.KeepAlive(m)
+	return res
+}
+
+// String returns a string representation of m.
+func (m *ASTMap) String() string {
+	var res string
+	m.ctx.do(func() {
+		res = C.GoString(C.Z3_ast_map_to_string(m.ctx.c, m.c))
+	})
+	runtime.KeepAlive(m)
+	return res
+}
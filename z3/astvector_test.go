@@ -0,0 +1,72 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestASTVector(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y, z := ctx.IntConst("x"), ctx.IntConst("y"), ctx.IntConst("z")
+
+	v := NewASTVector(ctx)
+	if n := v.Len(); n != 0 {
+		t.Fatalf("new vector has length %d", n)
+	}
+	v.Append(x.AsAST(), y.AsAST())
+	v.Append(x.Add(y).AsAST())
+	if n := v.Len(); n != 3 {
+		t.Fatalf("vector has length %d, want 3", n)
+	}
+	if !v.Get(1).Equal(y.AsAST()) {
+		t.Errorf("v[1] = %v, want %v", v.Get(1), y)
+	}
+	v.Set(1, z.AsAST())
+	if !v.Get(1).Equal(z.AsAST()) {
+		t.Errorf("after Set, v[1] = %v, want %v", v.Get(1), z)
+	}
+	v.Resize(2)
+	s := v.Slice()
+	if len(s) != 2 || !s[0].Equal(x.AsAST()) || !s[1].Equal(z.AsAST()) {
+		t.Errorf("Slice() = %v, want [x z]", s)
+	}
+	wantPanic(t, "out of range", func() { v.Get(2) })
+
+	ctx2 := NewContext(nil)
+	v2 := v.Translate(ctx2)
+	if v2.Len() != 2 || v2.Get(0).Context() != ctx2 || v2.Get(0).String() != "x" {
+		t.Errorf("bad translated vector %v", v2)
+	}
+}
+
+func TestASTMap(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	sum := x.Add(y)
+
+	m := NewASTMap(ctx)
+	m.Set(x.AsAST(), y.AsAST())
+	m.Set(sum.AsAST(), x.AsAST())
+	if n := m.Len(); n != 2 {
+		t.Fatalf("map has length %d, want 2", n)
+	}
+	// Keys are compared structurally.
+	if v, ok := m.Get(x.Add(y).AsAST()); !ok || !v.Equal(x.AsAST()) {
+		t.Errorf("m[x+y] = %v, %v, want x, true", v, ok)
+	}
+	if _, ok := m.Get(y.AsAST()); ok {
+		t.Errorf("m[y] unexpectedly present")
+	}
+	if n := m.Keys().Len(); n != 2 {
+		t.Errorf("Keys() has length %d, want 2", n)
+	}
+	m.Delete(x.AsAST())
+	if _, ok := m.Get(x.AsAST()); ok || m.Len() != 1 {
+		t.Errorf("Delete failed: %v", m)
+	}
+	m.Reset()
+	if n := m.Len(); n != 0 {
+		t.Errorf("after Reset, map has length %d", n)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"math/big"
+	"runtime"
+)
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+*/
+import "C"
+
+// Bounds returns the tightest lower and upper bounds on x implied by
+// the predicates in s. x must be an Int, Real, or BV. Bit-vectors are
+// treated as unsigned.
+//
+// If x is unbounded in some direction, the corresponding bound is
+// nil. Bounds on Real values may be strict, in which case x can
+// approach the returned bound, but not reach it.
+//
+// If the predicates in s are unsatisfiable, Bounds returns false for
+// sat. Like Check, if Z3 is unable to determine the bounds, it
+// returns an *ErrSatUnknown error.
+func (s *Solver) Bounds(x Value) (lo, hi *big.Rat, sat bool, err error) {
+	switch k := x.Sort().Kind(); k {
+	case KindInt, KindReal, KindBV:
+	default:
+		panic("cannot compute bounds of sort " + k.String())
+	}
+	lo, sat, err = s.bound(x, false)
+	if !sat || err != nil {
+		return nil, nil, sat, err
+	}
+	hi, sat, err = s.bound(x, true)
+	if !sat || err != nil {
+		return nil, nil, sat, err
+	}
+	return lo, hi, true, nil
+}
+
+// bound returns the lower or upper bound of x implied by the
+// predicates in s using a fresh optimization context.
+func (s *Solver) bound(x Value, upper bool) (val *big.Rat, sat bool, err error) {
+	ctx := s.ctx
+	var coeffs [3]string
+	s.do(func() {
+		opt := C.Z3_mk_optimize(ctx.c)
+		C.Z3_optimize_inc_ref(ctx.c, opt)
+		defer C.Z3_optimize_dec_ref(ctx.c, opt)
+
+		asserts := C.Z3_solver_get_assertions(ctx.c, s.c)
+		C.Z3_ast_vector_inc_ref(ctx.c, asserts)
+		defer C.Z3_ast_vector_dec_ref(ctx.c, asserts)
+		n := C.Z3_ast_vector_size(ctx.c, asserts)
+		for i := C.uint(0); i < n; i++ {
+			C.Z3_optimize_assert(ctx.c, opt, C.Z3_ast_vector_get(ctx.c, asserts, i))
+		}
+
+		var idx C.unsigned
+		if upper {
+			idx = C.Z3_optimize_maximize(ctx.c, opt, x.impl().c)
+		} else {
+			idx = C.Z3_optimize_minimize(ctx.c, opt, x.impl().c)
+		}
+		switch C.Z3_optimize_check(ctx.c, opt, 0, nil) {
+		case C.Z3_L_UNDEF:
+			cerr := C.Z3_optimize_get_reason_unknown(ctx.c, opt)
+			err = &ErrSatUnknown{C.GoString(cerr)}
+			return
+		case C.Z3_L_FALSE:
+			return
+		}
+		sat = true
+
+		// The bound is encoded as a*∞ + b + c*ε.
+		var cvec C.Z3_ast_vector
+		if upper {
+			cvec = C.Z3_optimize_get_upper_as_vector(ctx.c, opt, idx)
+		} else {
+			cvec = C.Z3_optimize_get_lower_as_vector(ctx.c, opt, idx)
+		}
+		C.Z3_ast_vector_inc_ref(ctx.c, cvec)
+		defer C.Z3_ast_vector_dec_ref(ctx.c, cvec)
+		for i := range coeffs {
+			coeff := C.Z3_ast_vector_get(ctx.c, cvec, C.uint(i))
+			coeffs[i] = C.GoString(C.Z3_get_numeral_string(ctx.c, coeff))
+		}
+	})
+	runtime.KeepAlive(s)
+	runtime.KeepAlive(x)
+	if !sat || err != nil {
+		return nil, sat, err
+	}
+	if coeffs[0] != "0" {
+		// Unbounded.
+		return nil, true, nil
+	}
+	val, ok := new(big.Rat).SetString(coeffs[1])
+	if !ok {
+		panic("failed to parse numeral string " + coeffs[1])
+	}
+	return val, true, nil
+}
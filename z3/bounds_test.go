@@ -0,0 +1,59 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestSolverBounds(t *testing.T) {
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	b := ctx.BVConst("b", 8)
+	s := NewSolver(ctx)
+	s.Assert(x.GE(ctx.FromInt(-3, ints).(Int)))
+	s.Assert(x.Mul(ctx.FromInt(2, ints).(Int)).LE(ctx.FromInt(9, ints).(Int)))
+	s.Assert(y.GT(x))
+	s.Assert(b.UGT(ctx.FromInt(7, b.Sort()).(BV)))
+
+	rat := func(x int64) *big.Rat { return big.NewRat(x, 1) }
+	for _, test := range []struct {
+		x      Value
+		lo, hi *big.Rat
+	}{
+		{x, rat(-3), rat(4)},
+		{y, rat(-2), nil},
+		{x.Add(y), rat(-5), nil},
+		{y.Sub(x), rat(1), nil},
+		{b, rat(8), rat(255)},
+	} {
+		lo, hi, sat, err := s.Bounds(test.x)
+		if !sat || err != nil {
+			t.Fatalf("Bounds(%s) = _, _, %v, %v", test.x, sat, err)
+		}
+		if !ratEq(lo, test.lo) || !ratEq(hi, test.hi) {
+			t.Errorf("Bounds(%s) = [%v, %v], want [%v, %v]", test.x, lo, hi, test.lo, test.hi)
+		}
+	}
+
+	// Bounds must not modify s.
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("Check() = %v, %v after Bounds", sat, err)
+	}
+
+	s.Assert(x.GT(ctx.FromInt(10, ints).(Int)))
+	if _, _, sat, err := s.Bounds(x); sat || err != nil {
+		t.Errorf("Bounds on unsatisfiable predicates = _, _, %v, %v", sat, err)
+	}
+}
+
+func ratEq(a, b *big.Rat) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Cmp(b) == 0
+}
@@ -5,14 +5,35 @@
 package z3
 
 import (
+	"fmt"
 	"math"
 	"math/big"
+	"math/bits"
+	"runtime"
+	"strconv"
+	"strings"
 )
 
 /*
 #cgo LDFLAGS: -lz3
 #include <z3.h>
 #include <stdlib.h>
+#include <stdint.h>
+#include <string.h>
+
+// z3goNumeralWords stores the value of BV numeral a into n
+// little-endian 64-bit words.
+static inline void z3goNumeralWords(Z3_context c, Z3_ast a, uint64_t *words, unsigned n) {
+	Z3_string s = Z3_get_numeral_binary_string(c, a);
+	size_t len = strlen(s), i, bit;
+	memset(words, 0, n * sizeof *words);
+	for (i = 0; i < len; i++) {
+		bit = len - 1 - i;
+		if (s[i] == '1' && bit / 64 < n) {
+			words[bit / 64] |= (uint64_t)1 << (bit % 64);
+		}
+	}
+}
 */
 import "C"
 
@@ -35,7 +56,7 @@ func init() {
 func (ctx *Context) BVSort(bits int) Sort {
 	var sort Sort
 	ctx.do(func() {
-		sort = wrapSort(ctx, C.Z3_mk_bv_sort(ctx.c, C.unsigned(bits)), KindBV)
+		sort = ctx.bvSort(bits)
 	})
 	return sort
 }
@@ -46,6 +67,76 @@ func (ctx *Context) BVConst(name string, bits int) BV {
 	return ctx.Const(name, ctx.BVSort(bits)).(BV)
 }
 
+// Endianness is a byte order used to convert between bit-vectors and
+// byte slices.
+type Endianness int
+
+const (
+	// BigEndian stores the most significant byte first.
+	BigEndian Endianness = iota
+	// LittleEndian stores the least significant byte first.
+	LittleEndian
+)
+
+// BVFromBytes returns a bit-vector literal of width 8*len(b) whose
+// value is b interpreted in byte order endian.
+//
+// b must not be empty.
+func (ctx *Context) BVFromBytes(b []byte, endian Endianness) BV {
+	if len(b) == 0 {
+		panic("bit-vector must have at least one byte")
+	}
+	if endian == LittleEndian {
+		b = reverseBytes(b)
+	}
+	return ctx.FromBigIntBV(new(big.Int).SetBytes(b), 8*len(b))
+}
+
+// BVFromBitString returns a bit-vector literal of width len(bits)
+// whose value is bits, a string of '0' and '1' characters with the
+// most significant bit first. For example, BVFromBitString("0101")
+// returns the 4-bit value 5.
+func (ctx *Context) BVFromBitString(bits string) BV {
+	if len(bits) == 0 {
+		panic("bit-vector must have at least one bit")
+	}
+	val, ok := new(big.Int).SetString(bits, 2)
+	if !ok || strings.ContainsAny(bits, "+-_") {
+		panic("malformed bit string " + strconv.Quote(bits))
+	}
+	return ctx.FromBigIntBV(val, len(bits))
+}
+
+// FromBigIntBV returns a bit-vector literal of width bits whose value
+// is val truncated to bits bits, like a Go integer conversion.
+// Negative values are represented in two's complement.
+//
+// Unlike FromBigInt, FromBigIntBV constructs the literal directly
+// from the bits of val, which is much faster for wide bit-vectors.
+func (ctx *Context) FromBigIntBV(val *big.Int, bits int) BV {
+	if bits <= 0 {
+		panic("bit-vector must have at least one bit")
+	}
+	cbits := make([]C.bool, bits)
+	for i := range cbits {
+		// big.Int.Bit uses two's complement for negative
+		// values.
+		cbits[i] = val.Bit(i) != 0
+	}
+	return BV(wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_bv_numeral(ctx.c, C.unsigned(bits), &cbits[0])
+	}))
+}
+
+// reverseBytes returns a reversed copy of b.
+func reverseBytes(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i, x := range b {
+		r[len(b)-1-i] = x
+	}
+	return r
+}
+
 // AsBigSigned returns the value of lit as a math/big.Int,
 // interpreting lit as a signed two's complement number. If lit is not
 // a literal, it returns nil, false.
@@ -65,7 +156,49 @@ func (lit BV) AsBigSigned() (val *big.Int, isLiteral bool) {
 
 // AsBigUnsigned is like AsBigSigned, but interprets lit as unsigned.
 func (lit BV) AsBigUnsigned() (val *big.Int, isLiteral bool) {
-	return lit.asBigInt()
+	words, isLiteral := lit.AppendWords(nil)
+	if !isLiteral {
+		return nil, false
+	}
+	var nat []big.Word
+	for _, w := range words {
+		if bits.UintSize == 64 {
+			nat = append(nat, big.Word(w))
+		} else {
+			nat = append(nat, big.Word(w), big.Word(w>>32))
+		}
+	}
+	return new(big.Int).SetBits(nat), true
+}
+
+// AppendWords appends the value of lit, interpreted as unsigned, to
+// dst as little-endian 64-bit words and returns the extended slice.
+// That is, it appends bits 0 through 63 of lit, then bits 64 through
+// 127, and so on, for a total of one word per 64 bits of lit, rounded
+// up. If lit is not a literal, it returns dst, false.
+//
+// Unlike the other methods that extract the value of lit,
+// AppendWords does not convert lit through a decimal string and, if
+// dst has enough capacity, does not allocate. This makes it well
+// suited to extracting many wide values from a model.
+func (lit BV) AppendWords(dst []uint64) (words []uint64, isLiteral bool) {
+	lit.ctx.do(func() {
+		if C.Z3_get_ast_kind(lit.ctx.c, lit.c) != C.Z3_NUMERAL_AST {
+			return
+		}
+		isLiteral = true
+		width := int(C.Z3_get_bv_sort_size(lit.ctx.c, C.Z3_get_sort(lit.ctx.c, lit.c)))
+		n := (width + 63) / 64
+		start := len(dst)
+		for i := 0; i < n; i++ {
+			dst = append(dst, 0)
+		}
+		if n > 0 {
+			C.z3goNumeralWords(lit.ctx.c, lit.c, (*C.uint64_t)(&dst[start]), C.unsigned(n))
+		}
+	})
+	runtime.KeepAlive(lit)
+	return dst, isLiteral
 }
 
 // AsInt64 returns the value of lit as an int64, interpreting lit as a
@@ -110,6 +243,232 @@ func (lit BV) AsUint64() (val uint64, isLiteral, ok bool) {
 	return lit.asUint64()
 }
 
+// AsBinaryString returns the value of lit as a string of '0' and '1'
+// characters, most significant bit first. The result has exactly one
+// character per bit of lit. If lit is not a literal, it returns "",
+// false.
+func (lit BV) AsBinaryString() (val string, isLiteral bool) {
+	return lit.asPaddedString(2, lit.Sort().BVSize())
+}
+
+// AsHexString returns the value of lit as a string of lower-case
+// hexadecimal digits, most significant digit first. The result is
+// zero-padded to one digit per four bits of lit, rounded up. If lit
+// is not a literal, it returns "", false.
+func (lit BV) AsHexString() (val string, isLiteral bool) {
+	return lit.asPaddedString(16, (lit.Sort().BVSize()+3)/4)
+}
+
+func (lit BV) asPaddedString(base, digits int) (string, bool) {
+	v, isLiteral := lit.AsBigUnsigned()
+	if !isLiteral {
+		return "", false
+	}
+	str := v.Text(base)
+	if len(str) < digits {
+		str = strings.Repeat("0", digits-len(str)) + str
+	}
+	return str, true
+}
+
+// AsBytes returns the value of lit as a byte slice in byte order
+// endian. The result has one byte per eight bits of lit, rounded up;
+// if lit's width is not a multiple of 8, the unused bits of the most
+// significant byte are 0. If lit is not a literal, it returns nil,
+// false.
+func (lit BV) AsBytes(endian Endianness) (val []byte, isLiteral bool) {
+	v, isLiteral := lit.AsBigUnsigned()
+	if !isLiteral {
+		return nil, false
+	}
+	val = v.FillBytes(make([]byte, (lit.Sort().BVSize()+7)/8))
+	if endian == LittleEndian {
+		val = reverseBytes(val)
+	}
+	return val, true
+}
+
+// AllBitsSet returns a Bool that is true if every bit of l is 1. It
+// is the Bool equivalent of AllBits.
+func (l BV) AllBitsSet() Bool {
+	return l.AllBits().Eq(l.ctx.bvOne())
+}
+
+// AnyBitSet returns a Bool that is true if any bit of l is 1. It is
+// the Bool equivalent of AnyBits.
+func (l BV) AnyBitSet() Bool {
+	return l.AnyBits().Eq(l.ctx.bvOne())
+}
+
+// Bit returns a Bool that is true if bit i of l is 1, where bit 0 is
+// the least significant bit.
+func (l BV) Bit(i int) Bool {
+	return l.Extract(i, i).Eq(l.ctx.bvOne())
+}
+
+// Bits returns the bits of l as Bools, starting with the least
+// significant bit. That is, Bits()[i] is equivalent to Bit(i).
+func (l BV) Bits() []Bool {
+	res := make([]Bool, l.Sort().BVSize())
+	for i := range res {
+		res[i] = l.Bit(i)
+	}
+	return res
+}
+
+// ToBool returns a Bool that is true if the 1-bit bit-vector l is 1.
+// It panics if l is not 1 bit wide. See also Bool.ToBV.
+func (l BV) ToBool() Bool {
+	if w := l.Sort().BVSize(); w != 1 {
+		panic(fmt.Sprintf("z3: ToBool of %d-bit bit-vector", w))
+	}
+	return l.Eq(l.ctx.bvOne())
+}
+
+// Popcount returns the number of 1 bits in l, as a bit-vector of the
+// same width as l.
+func (l BV) Popcount() BV {
+	// Sum adjacent fields of doubling width in parallel: first
+	// pairs of bits, then nibbles, and so on. The count in each
+	// field never exceeds the field width, so fields never carry
+	// into each other.
+	sort := l.Sort()
+	width := sort.BVSize()
+	x := l
+	for shift := 1; shift < width; shift *= 2 {
+		// mask has the low shift bits of every 2*shift-bit field
+		// set.
+		mask := new(big.Int)
+		for i := 0; i < width; i++ {
+			if i%(2*shift) < shift {
+				mask.SetBit(mask, i, 1)
+			}
+		}
+		m := l.ctx.FromBigInt(mask, sort).(BV)
+		sh := l.ctx.FromInt(int64(shift), sort).(BV)
+		x = x.And(m).Add(x.URsh(sh).And(m))
+	}
+	return x
+}
+
+// Clz returns the number of leading (most significant) 0 bits in l,
+// as a bit-vector of the same width as l. If l is 0, the result is
+// the width of l.
+func (l BV) Clz() BV {
+	// Smear the highest 1 bit into all lower bits, then count
+	// the 0 bits that remain.
+	sort := l.Sort()
+	width := sort.BVSize()
+	x := l
+	for shift := 1; shift < width; shift *= 2 {
+		x = x.Or(x.URsh(l.ctx.FromInt(int64(shift), sort).(BV)))
+	}
+	return x.Not().Popcount()
+}
+
+// Ctz returns the number of trailing (least significant) 0 bits in l,
+// as a bit-vector of the same width as l. If l is 0, the result is
+// the width of l.
+func (l BV) Ctz() BV {
+	// l & -l isolates the lowest 1 bit. Subtracting 1 gives a
+	// mask of exactly the trailing 0 bits (or all 1s if l is 0).
+	one := l.ctx.FromInt(1, l.Sort()).(BV)
+	return l.And(l.Neg()).Sub(one).Popcount()
+}
+
+// UAddSat returns l + r, treating l and r as unsigned and saturating
+// at the maximum unsigned value instead of wrapping around.
+func (l BV) UAddSat(r BV) BV {
+	return l.UAddNoOverflow(r).IfThenElse(l.Add(r), l.umax()).(BV)
+}
+
+// SAddSat returns l + r, treating l and r as two's complement signed
+// numbers and saturating at the minimum and maximum signed values
+// instead of wrapping around.
+func (l BV) SAddSat(r BV) BV {
+	sat := l.SAddNoUnderflow(r).IfThenElse(l.Add(r), l.smin())
+	return l.SAddNoOverflow(r).IfThenElse(sat, l.smax()).(BV)
+}
+
+// USubSat returns l - r, treating l and r as unsigned and saturating
+// at 0 instead of wrapping around.
+func (l BV) USubSat(r BV) BV {
+	zero := l.ctx.FromInt(0, l.Sort())
+	return l.USubNoUnderflow(r).IfThenElse(l.Sub(r), zero).(BV)
+}
+
+// SSubSat is like SAddSat, but returns l - r.
+func (l BV) SSubSat(r BV) BV {
+	sat := l.SSubNoUnderflow(r).IfThenElse(l.Sub(r), l.smin())
+	return l.SSubNoOverflow(r).IfThenElse(sat, l.smax()).(BV)
+}
+
+// UMulSat is like UAddSat, but returns l * r.
+func (l BV) UMulSat(r BV) BV {
+	return l.UMulNoOverflow(r).IfThenElse(l.Mul(r), l.umax()).(BV)
+}
+
+// SMulSat is like SAddSat, but returns l * r.
+func (l BV) SMulSat(r BV) BV {
+	sat := l.SMulNoUnderflow(r).IfThenElse(l.Mul(r), l.smin())
+	return l.SMulNoOverflow(r).IfThenElse(sat, l.smax()).(BV)
+}
+
+// SMulNoOverflow returns a Bool that is true if l * r does not
+// overflow, treating l and r as two's complement signed numbers.
+func (l BV) SMulNoOverflow(r BV) Bool {
+	return l.smulWide(r).SLE(l.smax().SignExtend(l.Sort().BVSize()))
+}
+
+// SMulNoUnderflow returns a Bool that is true if l * r does not
+// underflow, treating l and r as two's complement signed numbers.
+func (l BV) SMulNoUnderflow(r BV) Bool {
+	return l.smulWide(r).SGE(l.smin().SignExtend(l.Sort().BVSize()))
+}
+
+// smulWide returns the exact signed product of l and r at double
+// width. Z3_mk_bvmul_no_overflow gets negative operands wrong in some
+// versions of Z3, so the signed overflow checks compare this against
+// the bounds of l's sort instead.
+func (l BV) smulWide(r BV) BV {
+	w := l.Sort().BVSize()
+	return l.SignExtend(w).Mul(r.SignExtend(w))
+}
+
+// SAbs returns the absolute value of l, treating l as a two's
+// complement signed number.
+//
+// The absolute value of the minimum signed value cannot be
+// represented as a signed number, so SAbs returns the minimum signed
+// value unchanged, just as Neg does. Interpreted as unsigned, the
+// result is the correct magnitude for every l.
+func (l BV) SAbs() BV {
+	zero := l.ctx.FromInt(0, l.Sort()).(BV)
+	return l.If(l.SGE(zero), l.Neg())
+}
+
+// umax returns the maximum unsigned value of l's sort.
+func (l BV) umax() BV {
+	return l.ctx.FromInt(-1, l.Sort()).(BV)
+}
+
+// smax returns the maximum signed value of l's sort.
+func (l BV) smax() BV {
+	w := l.Sort().BVSize()
+	return l.ctx.FromBigIntBV(new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(w-1)), big.NewInt(1)), w)
+}
+
+// smin returns the minimum signed value of l's sort.
+func (l BV) smin() BV {
+	w := l.Sort().BVSize()
+	return l.ctx.FromBigIntBV(new(big.Int).Lsh(big.NewInt(1), uint(w-1)), w)
+}
+
+// bvOne returns the 1-bit bit-vector 1.
+func (ctx *Context) bvOne() BV {
+	return ctx.FromInt(1, ctx.BVSort(1)).(BV)
+}
+
 //go:generate go run genwrap.go -t BV $GOFILE
 
 // Not returns the bit-wise negation of l.
@@ -333,13 +692,21 @@ func (lit BV) AsUint64() (val uint64, isLiteral, ok bool) {
 //
 //wrap:expr RotateRight Z3_mk_ext_rotate_right l i
 
+// RotateLeftConst returns l rotated left by the constant i bits.
+//
+//wrap:expr RotateLeftConst l i:int : Z3_mk_rotate_left i:unsigned l
+
+// RotateRightConst returns l rotated right by the constant i bits.
+//
+//wrap:expr RotateRightConst l i:int : Z3_mk_rotate_right i:unsigned l
+
 // SToInt converts signed bit-vector l to an integer.
 //
-//wrap:expr SToInt:Int l : Z3_mk_bv2int l "C.Z3_TRUE"
+//wrap:expr SToInt:Int l : Z3_mk_bv2int l "boolToZ3(true)"
 
 // UToInt converts unsigned bit-vector l to an integer.
 //
-//wrap:expr UToInt:Int l : Z3_mk_bv2int l "C.Z3_FALSE"
+//wrap:expr UToInt:Int l : Z3_mk_bv2int l "boolToZ3(false)"
 
 // IEEEToFloat converts l into a floating-point number, interpreting l
 // in IEEE 754-2008 format.
@@ -362,4 +729,140 @@ func (lit BV) AsUint64() (val uint64, isLiteral, ok bool) {
 //
 //wrap:expr UToFloat:Float l s:Sort : Z3_mk_fpa_to_fp_unsigned @rm l s
 
-// TODO: Z3_mk_bv*_no_{over,under}flow
+// ToChar converts l into the character whose code point is l.
+//
+//wrap:expr ToChar:Char Z3_mk_char_from_bv l
+
+// UAddNoOverflow returns a Bool that is true if l + r does not
+// overflow, treating l and r as unsigned.
+//
+//wrap:expr UAddNoOverflow:Bool l r : Z3_mk_bvadd_no_overflow l r "boolToZ3(false)"
+
+// SAddNoOverflow returns a Bool that is true if l + r does not
+// overflow, treating l and r as two's complement signed numbers.
+//
+//wrap:expr SAddNoOverflow:Bool l r : Z3_mk_bvadd_no_overflow l r "boolToZ3(true)"
+
+// SAddNoUnderflow returns a Bool that is true if l + r does not
+// underflow, treating l and r as two's complement signed numbers.
+//
+//wrap:expr SAddNoUnderflow:Bool Z3_mk_bvadd_no_underflow l r
+
+// SSubNoOverflow returns a Bool that is true if l - r does not
+// overflow, treating l and r as two's complement signed numbers.
+//
+//wrap:expr SSubNoOverflow:Bool Z3_mk_bvsub_no_overflow l r
+
+// USubNoUnderflow returns a Bool that is true if l - r does not
+// underflow, treating l and r as unsigned. That is, it is true if
+// l >= r.
+//
+//wrap:expr USubNoUnderflow:Bool l r : Z3_mk_bvsub_no_underflow l r "boolToZ3(false)"
+
+// SSubNoUnderflow returns a Bool that is true if l - r does not
+// underflow, treating l and r as two's complement signed numbers.
+//
+//wrap:expr SSubNoUnderflow:Bool l r : Z3_mk_bvsub_no_underflow l r "boolToZ3(true)"
+
+// UMulNoOverflow returns a Bool that is true if l * r does not
+// overflow, treating l and r as unsigned.
+//
+//wrap:expr UMulNoOverflow:Bool l r : Z3_mk_bvmul_no_overflow l r "boolToZ3(false)"
+
+// SDivNoOverflow returns a Bool that is true if l / r does not
+// overflow, treating l and r as two's complement signed numbers.
+// The only overflowing case is the minimum signed value divided by
+// -1.
+//
+//wrap:expr SDivNoOverflow:Bool Z3_mk_bvsdiv_no_overflow l r
+
+// NegNoOverflow returns a Bool that is true if -l does not overflow,
+// treating l as a two's complement signed number. The only
+// overflowing case is the minimum signed value.
+//
+//wrap:expr NegNoOverflow:Bool Z3_mk_bvneg_no_overflow l
+
+// The following functions destructure BV Values built by the methods
+// above. Each returns ok == false if x is not an application of the
+// corresponding operator. Z3 treats some of these operators as
+// n-ary; the matchers for those only match the binary form.
+
+// MatchBVNot returns l if x is l.Not().
+//
+//wrap:match MatchBVNot Z3_OP_BNOT l
+
+// MatchBVAnd returns l and r if x is l.And(r).
+//
+//wrap:match MatchBVAnd Z3_OP_BAND l r
+
+// MatchBVOr returns l and r if x is l.Or(r).
+//
+//wrap:match MatchBVOr Z3_OP_BOR l r
+
+// MatchBVXor returns l and r if x is l.Xor(r).
+//
+//wrap:match MatchBVXor Z3_OP_BXOR l r
+
+// MatchBVNeg returns l if x is l.Neg().
+//
+//wrap:match MatchBVNeg Z3_OP_BNEG l
+
+// MatchBVAdd returns l and r if x is l.Add(r).
+//
+//wrap:match MatchBVAdd Z3_OP_BADD l r
+
+// MatchBVSub returns l and r if x is l.Sub(r).
+//
+//wrap:match MatchBVSub Z3_OP_BSUB l r
+
+// MatchBVMul returns l and r if x is l.Mul(r).
+//
+//wrap:match MatchBVMul Z3_OP_BMUL l r
+
+// MatchBVUDiv returns l and r if x is l.UDiv(r).
+//
+//wrap:match MatchBVUDiv Z3_OP_BUDIV l r
+
+// MatchBVSDiv returns l and r if x is l.SDiv(r).
+//
+//wrap:match MatchBVSDiv Z3_OP_BSDIV l r
+
+// MatchBVURem returns l and r if x is l.URem(r).
+//
+//wrap:match MatchBVURem Z3_OP_BUREM l r
+
+// MatchBVSRem returns l and r if x is l.SRem(r).
+//
+//wrap:match MatchBVSRem Z3_OP_BSREM l r
+
+// MatchBVULT returns l and r if x is l.ULT(r).
+//
+//wrap:match MatchBVULT Z3_OP_ULT l r
+
+// MatchBVSLT returns l and r if x is l.SLT(r).
+//
+//wrap:match MatchBVSLT Z3_OP_SLT l r
+
+// MatchBVULE returns l and r if x is l.ULE(r).
+//
+//wrap:match MatchBVULE Z3_OP_ULEQ l r
+
+// MatchBVSLE returns l and r if x is l.SLE(r).
+//
+//wrap:match MatchBVSLE Z3_OP_SLEQ l r
+
+// MatchBVConcat returns l and r if x is l.Concat(r).
+//
+//wrap:match MatchBVConcat Z3_OP_CONCAT l r
+
+// MatchBVLsh returns l and i if x is l.Lsh(i).
+//
+//wrap:match MatchBVLsh Z3_OP_BSHL l i
+
+// MatchBVURsh returns l and i if x is l.URsh(i).
+//
+//wrap:match MatchBVURsh Z3_OP_BLSHR l i
+
+// MatchBVSRsh returns l and i if x is l.SRsh(i).
+//
+//wrap:match MatchBVSRsh Z3_OP_BASHR l i
@@ -15,7 +15,7 @@ import "C"
 func (l BV) Eq(r BV) Bool {
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_eq(ctx.c, l.c, r.c)
+		return C.Z3_mk_eq(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -27,12 +27,26 @@ func (l BV) NE(r BV) Bool {
 	return l.ctx.Distinct(l, r)
 }
 
+// If returns a Value equal to l if cond is true, otherwise r.
+//
+// This is a typed form of Bool.IfThenElse.
+func (l BV) If(cond Bool, r BV) BV {
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_ite(ctx.c, cond.cref(), l.cref(), r.cref())
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(cond)
+	runtime.KeepAlive(r)
+	return BV(val)
+}
+
 // Not returns the bit-wise negation of l.
 func (l BV) Not() BV {
-	// Generated from bv.go:117.
+	// Generated from bv.go:476.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvnot(ctx.c, l.c)
+		return C.Z3_mk_bvnot(ctx.c, l.cref())
 	})
 	runtime.KeepAlive(l)
 	return BV(val)
@@ -41,10 +55,10 @@ func (l BV) Not() BV {
 // AllBits returns a 1-bit bit-vector that is the bit-wise "and" of
 // all bits.
 func (l BV) AllBits() BV {
-	// Generated from bv.go:122.
+	// Generated from bv.go:481.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvredand(ctx.c, l.c)
+		return C.Z3_mk_bvredand(ctx.c, l.cref())
 	})
 	runtime.KeepAlive(l)
 	return BV(val)
@@ -53,10 +67,10 @@ func (l BV) AllBits() BV {
 // AnyBits returns a 1-bit bit-vector that is the bit-wise "or" of all
 // bits.
 func (l BV) AnyBits() BV {
-	// Generated from bv.go:127.
+	// Generated from bv.go:486.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvredor(ctx.c, l.c)
+		return C.Z3_mk_bvredor(ctx.c, l.cref())
 	})
 	runtime.KeepAlive(l)
 	return BV(val)
@@ -66,10 +80,10 @@ func (l BV) AnyBits() BV {
 //
 // l and r must have the same size.
 func (l BV) And(r BV) BV {
-	// Generated from bv.go:133.
+	// Generated from bv.go:492.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvand(ctx.c, l.c, r.c)
+		return C.Z3_mk_bvand(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -80,10 +94,10 @@ func (l BV) And(r BV) BV {
 //
 // l and r must have the same size.
 func (l BV) Or(r BV) BV {
-	// Generated from bv.go:139.
+	// Generated from bv.go:498.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvor(ctx.c, l.c, r.c)
+		return C.Z3_mk_bvor(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -94,10 +108,10 @@ func (l BV) Or(r BV) BV {
 //
 // l and r must have the same size.
 func (l BV) Xor(r BV) BV {
-	// Generated from bv.go:145.
+	// Generated from bv.go:504.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvxor(ctx.c, l.c, r.c)
+		return C.Z3_mk_bvxor(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -108,10 +122,10 @@ func (l BV) Xor(r BV) BV {
 //
 // l and r must have the same size.
 func (l BV) Nand(r BV) BV {
-	// Generated from bv.go:151.
+	// Generated from bv.go:510.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvnand(ctx.c, l.c, r.c)
+		return C.Z3_mk_bvnand(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -122,10 +136,10 @@ func (l BV) Nand(r BV) BV {
 //
 // l and r must have the same size.
 func (l BV) Nor(r BV) BV {
-	// Generated from bv.go:157.
+	// Generated from bv.go:516.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvnor(ctx.c, l.c, r.c)
+		return C.Z3_mk_bvnor(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -136,10 +150,10 @@ func (l BV) Nor(r BV) BV {
 //
 // l and r must have the same size.
 func (l BV) Xnor(r BV) BV {
-	// Generated from bv.go:163.
+	// Generated from bv.go:522.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvxnor(ctx.c, l.c, r.c)
+		return C.Z3_mk_bvxnor(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -148,10 +162,10 @@ func (l BV) Xnor(r BV) BV {
 
 // Neg returns the two's complement negation of l.
 func (l BV) Neg() BV {
-	// Generated from bv.go:167.
+	// Generated from bv.go:526.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvneg(ctx.c, l.c)
+		return C.Z3_mk_bvneg(ctx.c, l.cref())
 	})
 	runtime.KeepAlive(l)
 	return BV(val)
@@ -161,10 +175,10 @@ func (l BV) Neg() BV {
 //
 // l and r must have the same size.
 func (l BV) Add(r BV) BV {
-	// Generated from bv.go:173.
+	// Generated from bv.go:532.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvadd(ctx.c, l.c, r.c)
+		return C.Z3_mk_bvadd(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -175,10 +189,10 @@ func (l BV) Add(r BV) BV {
 //
 // l and r must have the same size.
 func (l BV) Sub(r BV) BV {
-	// Generated from bv.go:179.
+	// Generated from bv.go:538.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvsub(ctx.c, l.c, r.c)
+		return C.Z3_mk_bvsub(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -189,10 +203,10 @@ func (l BV) Sub(r BV) BV {
 //
 // l and r must have the same size.
 func (l BV) Mul(r BV) BV {
-	// Generated from bv.go:185.
+	// Generated from bv.go:544.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvmul(ctx.c, l.c, r.c)
+		return C.Z3_mk_bvmul(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -205,10 +219,10 @@ func (l BV) Mul(r BV) BV {
 //
 // l and r must have the same size.
 func (l BV) UDiv(r BV) BV {
-	// Generated from bv.go:193.
+	// Generated from bv.go:552.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvudiv(ctx.c, l.c, r.c)
+		return C.Z3_mk_bvudiv(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -222,10 +236,10 @@ func (l BV) UDiv(r BV) BV {
 //
 // l and r must have the same size.
 func (l BV) SDiv(r BV) BV {
-	// Generated from bv.go:202.
+	// Generated from bv.go:561.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvsdiv(ctx.c, l.c, r.c)
+		return C.Z3_mk_bvsdiv(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -236,10 +250,10 @@ func (l BV) SDiv(r BV) BV {
 //
 // l and r must have the same size.
 func (l BV) URem(r BV) BV {
-	// Generated from bv.go:208.
+	// Generated from bv.go:567.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvurem(ctx.c, l.c, r.c)
+		return C.Z3_mk_bvurem(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -252,10 +266,10 @@ func (l BV) URem(r BV) BV {
 //
 // l and r must have the same size.
 func (l BV) SRem(r BV) BV {
-	// Generated from bv.go:216.
+	// Generated from bv.go:575.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvsrem(ctx.c, l.c, r.c)
+		return C.Z3_mk_bvsrem(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -268,10 +282,10 @@ func (l BV) SRem(r BV) BV {
 //
 // l and r must have the same size.
 func (l BV) SMod(r BV) BV {
-	// Generated from bv.go:224.
+	// Generated from bv.go:583.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvsmod(ctx.c, l.c, r.c)
+		return C.Z3_mk_bvsmod(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -282,10 +296,10 @@ func (l BV) SMod(r BV) BV {
 //
 // l and r must have the same size.
 func (l BV) ULT(r BV) Bool {
-	// Generated from bv.go:230.
+	// Generated from bv.go:589.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvult(ctx.c, l.c, r.c)
+		return C.Z3_mk_bvult(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -296,10 +310,10 @@ func (l BV) ULT(r BV) Bool {
 //
 // l and r must have the same size.
 func (l BV) SLT(r BV) Bool {
-	// Generated from bv.go:236.
+	// Generated from bv.go:595.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvslt(ctx.c, l.c, r.c)
+		return C.Z3_mk_bvslt(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -310,10 +324,10 @@ func (l BV) SLT(r BV) Bool {
 //
 // l and r must have the same size.
 func (l BV) ULE(r BV) Bool {
-	// Generated from bv.go:242.
+	// Generated from bv.go:601.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvule(ctx.c, l.c, r.c)
+		return C.Z3_mk_bvule(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -324,10 +338,10 @@ func (l BV) ULE(r BV) Bool {
 //
 // l and r must have the same size.
 func (l BV) SLE(r BV) Bool {
-	// Generated from bv.go:248.
+	// Generated from bv.go:607.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvsle(ctx.c, l.c, r.c)
+		return C.Z3_mk_bvsle(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -338,10 +352,10 @@ func (l BV) SLE(r BV) Bool {
 //
 // l and r must have the same size.
 func (l BV) UGE(r BV) Bool {
-	// Generated from bv.go:254.
+	// Generated from bv.go:613.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvuge(ctx.c, l.c, r.c)
+		return C.Z3_mk_bvuge(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -352,10 +366,10 @@ func (l BV) UGE(r BV) Bool {
 //
 // l and r must have the same size.
 func (l BV) SGE(r BV) Bool {
-	// Generated from bv.go:260.
+	// Generated from bv.go:619.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvsge(ctx.c, l.c, r.c)
+		return C.Z3_mk_bvsge(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -366,10 +380,10 @@ func (l BV) SGE(r BV) Bool {
 //
 // l and r must have the same size.
 func (l BV) UGT(r BV) Bool {
-	// Generated from bv.go:266.
+	// Generated from bv.go:625.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvugt(ctx.c, l.c, r.c)
+		return C.Z3_mk_bvugt(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -380,10 +394,10 @@ func (l BV) UGT(r BV) Bool {
 //
 // l and r must have the same size.
 func (l BV) SGT(r BV) Bool {
-	// Generated from bv.go:272.
+	// Generated from bv.go:631.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvsgt(ctx.c, l.c, r.c)
+		return C.Z3_mk_bvsgt(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -395,10 +409,10 @@ func (l BV) SGT(r BV) Bool {
 // The result is a bit-vector whose length is the sum of the lengths
 // of l and r.
 func (l BV) Concat(r BV) BV {
-	// Generated from bv.go:279.
+	// Generated from bv.go:638.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_concat(ctx.c, l.c, r.c)
+		return C.Z3_mk_concat(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -408,10 +422,10 @@ func (l BV) Concat(r BV) BV {
 // Extract returns bits [high, low] (inclusive) of l, where bit 0 is
 // the least significant bit.
 func (l BV) Extract(high int, low int) BV {
-	// Generated from bv.go:284.
+	// Generated from bv.go:643.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_extract(ctx.c, C.unsigned(high), C.unsigned(low), l.c)
+		return C.Z3_mk_extract(ctx.c, C.unsigned(high), C.unsigned(low), l.cref())
 	})
 	runtime.KeepAlive(l)
 	return BV(val)
@@ -420,10 +434,10 @@ func (l BV) Extract(high int, low int) BV {
 // SignExtend returns l sign-extended to a bit-vector of length m+i,
 // where m is the length of l.
 func (l BV) SignExtend(i int) BV {
-	// Generated from bv.go:289.
+	// Generated from bv.go:648.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_sign_ext(ctx.c, C.unsigned(i), l.c)
+		return C.Z3_mk_sign_ext(ctx.c, C.unsigned(i), l.cref())
 	})
 	runtime.KeepAlive(l)
 	return BV(val)
@@ -432,10 +446,10 @@ func (l BV) SignExtend(i int) BV {
 // ZeroExtend returns l zero-extended to a bit-vector of length m+i,
 // where m is the length of l.
 func (l BV) ZeroExtend(i int) BV {
-	// Generated from bv.go:294.
+	// Generated from bv.go:653.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_zero_ext(ctx.c, C.unsigned(i), l.c)
+		return C.Z3_mk_zero_ext(ctx.c, C.unsigned(i), l.cref())
 	})
 	runtime.KeepAlive(l)
 	return BV(val)
@@ -443,10 +457,10 @@ func (l BV) ZeroExtend(i int) BV {
 
 // Repeat returns l repeated up to length i.
 func (l BV) Repeat(i int) BV {
-	// Generated from bv.go:298.
+	// Generated from bv.go:657.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_repeat(ctx.c, C.unsigned(i), l.c)
+		return C.Z3_mk_repeat(ctx.c, C.unsigned(i), l.cref())
 	})
 	runtime.KeepAlive(l)
 	return BV(val)
@@ -458,10 +472,10 @@ func (l BV) Repeat(i int) BV {
 //
 // l and i must have the same size. The result has the same sort.
 func (l BV) Lsh(i BV) BV {
-	// Generated from bv.go:306.
+	// Generated from bv.go:665.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvshl(ctx.c, l.c, i.c)
+		return C.Z3_mk_bvshl(ctx.c, l.cref(), i.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(i)
@@ -474,10 +488,10 @@ func (l BV) Lsh(i BV) BV {
 //
 // l and i must have the same size. The result has the same sort.
 func (l BV) URsh(i BV) BV {
-	// Generated from bv.go:314.
+	// Generated from bv.go:673.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvlshr(ctx.c, l.c, i.c)
+		return C.Z3_mk_bvlshr(ctx.c, l.cref(), i.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(i)
@@ -490,10 +504,10 @@ func (l BV) URsh(i BV) BV {
 //
 // l and i must have the same size. The result has the same sort.
 func (l BV) SRsh(i BV) BV {
-	// Generated from bv.go:322.
+	// Generated from bv.go:681.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bvashr(ctx.c, l.c, i.c)
+		return C.Z3_mk_bvashr(ctx.c, l.cref(), i.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(i)
@@ -504,10 +518,10 @@ func (l BV) SRsh(i BV) BV {
 //
 // l and i must have the same size.
 func (l BV) RotateLeft(i BV) BV {
-	// Generated from bv.go:328.
+	// Generated from bv.go:687.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_ext_rotate_left(ctx.c, l.c, i.c)
+		return C.Z3_mk_ext_rotate_left(ctx.c, l.cref(), i.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(i)
@@ -518,22 +532,44 @@ func (l BV) RotateLeft(i BV) BV {
 //
 // l and i must have the same size.
 func (l BV) RotateRight(i BV) BV {
-	// Generated from bv.go:334.
+	// Generated from bv.go:693.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_ext_rotate_right(ctx.c, l.c, i.c)
+		return C.Z3_mk_ext_rotate_right(ctx.c, l.cref(), i.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(i)
 	return BV(val)
 }
 
+// RotateLeftConst returns l rotated left by the constant i bits.
+func (l BV) RotateLeftConst(i int) BV {
+	// Generated from bv.go:697.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_rotate_left(ctx.c, C.unsigned(i), l.cref())
+	})
+	runtime.KeepAlive(l)
+	return BV(val)
+}
+
+// RotateRightConst returns l rotated right by the constant i bits.
+func (l BV) RotateRightConst(i int) BV {
+	// Generated from bv.go:701.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_rotate_right(ctx.c, C.unsigned(i), l.cref())
+	})
+	runtime.KeepAlive(l)
+	return BV(val)
+}
+
 // SToInt converts signed bit-vector l to an integer.
 func (l BV) SToInt() Int {
-	// Generated from bv.go:338.
+	// Generated from bv.go:705.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bv2int(ctx.c, l.c, C.Z3_TRUE)
+		return C.Z3_mk_bv2int(ctx.c, l.cref(), boolToZ3(true))
 	})
 	runtime.KeepAlive(l)
 	return Int(val)
@@ -541,10 +577,10 @@ func (l BV) SToInt() Int {
 
 // UToInt converts unsigned bit-vector l to an integer.
 func (l BV) UToInt() Int {
-	// Generated from bv.go:342.
+	// Generated from bv.go:709.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_bv2int(ctx.c, l.c, C.Z3_FALSE)
+		return C.Z3_mk_bv2int(ctx.c, l.cref(), boolToZ3(false))
 	})
 	runtime.KeepAlive(l)
 	return Int(val)
@@ -555,10 +591,10 @@ func (l BV) UToInt() Int {
 //
 // The size of l must equal ebits+sbits of s.
 func (l BV) IEEEToFloat(s Sort) Float {
-	// Generated from bv.go:349.
+	// Generated from bv.go:716.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_to_fp_bv(ctx.c, l.c, s.c)
+		return C.Z3_mk_fpa_to_fp_bv(ctx.c, l.cref(), s.c)
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(s)
@@ -570,11 +606,11 @@ func (l BV) IEEEToFloat(s Sort) Float {
 // If necessary, the result will be rounded according to the current
 // rounding mode.
 func (l BV) SToFloat(s Sort) Float {
-	// Generated from bv.go:356.
+	// Generated from bv.go:723.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_to_fp_signed(ctx.c, rm.c, l.c, s.c)
+		return C.Z3_mk_fpa_to_fp_signed(ctx.c, rm.c, l.cref(), s.c)
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(s)
@@ -586,13 +622,382 @@ func (l BV) SToFloat(s Sort) Float {
 // If necessary, the result will be rounded according to the current
 // rounding mode.
 func (l BV) UToFloat(s Sort) Float {
-	// Generated from bv.go:363.
+	// Generated from bv.go:730.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_to_fp_unsigned(ctx.c, rm.c, l.c, s.c)
+		return C.Z3_mk_fpa_to_fp_unsigned(ctx.c, rm.c, l.cref(), s.c)
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(s)
 	return Float(val)
 }
+
+// ToChar converts l into the character whose code point is l.
+func (l BV) ToChar() Char {
+	// Generated from bv.go:734.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_char_from_bv(ctx.c, l.cref())
+	})
+	runtime.KeepAlive(l)
+	return Char(val)
+}
+
+// UAddNoOverflow returns a Bool that is true if l + r does not
+// overflow, treating l and r as unsigned.
+func (l BV) UAddNoOverflow(r BV) Bool {
+	// Generated from bv.go:739.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_bvadd_no_overflow(ctx.c, l.cref(), r.cref(), boolToZ3(false))
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return Bool(val)
+}
+
+// SAddNoOverflow returns a Bool that is true if l + r does not
+// overflow, treating l and r as two's complement signed numbers.
+func (l BV) SAddNoOverflow(r BV) Bool {
+	// Generated from bv.go:744.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_bvadd_no_overflow(ctx.c, l.cref(), r.cref(), boolToZ3(true))
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return Bool(val)
+}
+
+// SAddNoUnderflow returns a Bool that is true if l + r does not
+// underflow, treating l and r as two's complement signed numbers.
+func (l BV) SAddNoUnderflow(r BV) Bool {
+	// Generated from bv.go:749.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_bvadd_no_underflow(ctx.c, l.cref(), r.cref())
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return Bool(val)
+}
+
+// SSubNoOverflow returns a Bool that is true if l - r does not
+// overflow, treating l and r as two's complement signed numbers.
+func (l BV) SSubNoOverflow(r BV) Bool {
+	// Generated from bv.go:754.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_bvsub_no_overflow(ctx.c, l.cref(), r.cref())
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return Bool(val)
+}
+
+// USubNoUnderflow returns a Bool that is true if l - r does not
+// underflow, treating l and r as unsigned. That is, it is true if
+// l >= r.
+func (l BV) USubNoUnderflow(r BV) Bool {
+	// Generated from bv.go:760.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_bvsub_no_underflow(ctx.c, l.cref(), r.cref(), boolToZ3(false))
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return Bool(val)
+}
+
+// SSubNoUnderflow returns a Bool that is true if l - r does not
+// underflow, treating l and r as two's complement signed numbers.
+func (l BV) SSubNoUnderflow(r BV) Bool {
+	// Generated from bv.go:765.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_bvsub_no_underflow(ctx.c, l.cref(), r.cref(), boolToZ3(true))
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return Bool(val)
+}
+
+// UMulNoOverflow returns a Bool that is true if l * r does not
+// overflow, treating l and r as unsigned.
+func (l BV) UMulNoOverflow(r BV) Bool {
+	// Generated from bv.go:770.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_bvmul_no_overflow(ctx.c, l.cref(), r.cref(), boolToZ3(false))
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return Bool(val)
+}
+
+// SDivNoOverflow returns a Bool that is true if l / r does not
+// overflow, treating l and r as two's complement signed numbers.
+// The only overflowing case is the minimum signed value divided by
+// -1.
+func (l BV) SDivNoOverflow(r BV) Bool {
+	// Generated from bv.go:777.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_bvsdiv_no_overflow(ctx.c, l.cref(), r.cref())
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return Bool(val)
+}
+
+// NegNoOverflow returns a Bool that is true if -l does not overflow,
+// treating l as a two's complement signed number. The only
+// overflowing case is the minimum signed value.
+func (l BV) NegNoOverflow() Bool {
+	// Generated from bv.go:783.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_bvneg_no_overflow(ctx.c, l.cref())
+	})
+	runtime.KeepAlive(l)
+	return Bool(val)
+}
+
+// MatchBVNot returns l if x is l.Not().
+func MatchBVNot(x Value) (l BV, ok bool) {
+	// Generated from bv.go:792.
+	if !x.impl().isAppOf(C.Z3_OP_BNOT) || x.NumArgs() != 1 {
+		return
+	}
+	l = x.Arg(0).(BV)
+	ok = true
+	return
+}
+
+// MatchBVAnd returns l and r if x is l.And(r).
+func MatchBVAnd(x Value) (l BV, r BV, ok bool) {
+	// Generated from bv.go:796.
+	if !x.impl().isAppOf(C.Z3_OP_BAND) || x.NumArgs() != 2 {
+		return
+	}
+	l = x.Arg(0).(BV)
+	r = x.Arg(1).(BV)
+	ok = true
+	return
+}
+
+// MatchBVOr returns l and r if x is l.Or(r).
+func MatchBVOr(x Value) (l BV, r BV, ok bool) {
+	// Generated from bv.go:800.
+	if !x.impl().isAppOf(C.Z3_OP_BOR) || x.NumArgs() != 2 {
+		return
+	}
+	l = x.Arg(0).(BV)
+	r = x.Arg(1).(BV)
+	ok = true
+	return
+}
+
+// MatchBVXor returns l and r if x is l.Xor(r).
+func MatchBVXor(x Value) (l BV, r BV, ok bool) {
+	// Generated from bv.go:804.
+	if !x.impl().isAppOf(C.Z3_OP_BXOR) || x.NumArgs() != 2 {
+		return
+	}
+	l = x.Arg(0).(BV)
+	r = x.Arg(1).(BV)
+	ok = true
+	return
+}
+
+// MatchBVNeg returns l if x is l.Neg().
+func MatchBVNeg(x Value) (l BV, ok bool) {
+	// Generated from bv.go:808.
+	if !x.impl().isAppOf(C.Z3_OP_BNEG) || x.NumArgs() != 1 {
+		return
+	}
+	l = x.Arg(0).(BV)
+	ok = true
+	return
+}
+
+// MatchBVAdd returns l and r if x is l.Add(r).
+func MatchBVAdd(x Value) (l BV, r BV, ok bool) {
+	// Generated from bv.go:812.
+	if !x.impl().isAppOf(C.Z3_OP_BADD) || x.NumArgs() != 2 {
+		return
+	}
+	l = x.Arg(0).(BV)
+	r = x.Arg(1).(BV)
+	ok = true
+	return
+}
+
+// MatchBVSub returns l and r if x is l.Sub(r).
+func MatchBVSub(x Value) (l BV, r BV, ok bool) {
+	// Generated from bv.go:816.
+	if !x.impl().isAppOf(C.Z3_OP_BSUB) || x.NumArgs() != 2 {
+		return
+	}
+	l = x.Arg(0).(BV)
+	r = x.Arg(1).(BV)
+	ok = true
+	return
+}
+
+// MatchBVMul returns l and r if x is l.Mul(r).
+func MatchBVMul(x Value) (l BV, r BV, ok bool) {
+	// Generated from bv.go:820.
+	if !x.impl().isAppOf(C.Z3_OP_BMUL) || x.NumArgs() != 2 {
+		return
+	}
+	l = x.Arg(0).(BV)
+	r = x.Arg(1).(BV)
+	ok = true
+	return
+}
+
+// MatchBVUDiv returns l and r if x is l.UDiv(r).
+func MatchBVUDiv(x Value) (l BV, r BV, ok bool) {
+	// Generated from bv.go:824.
+	if !x.impl().isAppOf(C.Z3_OP_BUDIV) || x.NumArgs() != 2 {
+		return
+	}
+	l = x.Arg(0).(BV)
+	r = x.Arg(1).(BV)
+	ok = true
+	return
+}
+
+// MatchBVSDiv returns l and r if x is l.SDiv(r).
+func MatchBVSDiv(x Value) (l BV, r BV, ok bool) {
+	// Generated from bv.go:828.
+	if !x.impl().isAppOf(C.Z3_OP_BSDIV) || x.NumArgs() != 2 {
+		return
+	}
+	l = x.Arg(0).(BV)
+	r = x.Arg(1).(BV)
+	ok = true
+	return
+}
+
+// MatchBVURem returns l and r if x is l.URem(r).
+func MatchBVURem(x Value) (l BV, r BV, ok bool) {
+	// Generated from bv.go:832.
+	if !x.impl().isAppOf(C.Z3_OP_BUREM) || x.NumArgs() != 2 {
+		return
+	}
+	l = x.Arg(0).(BV)
+	r = x.Arg(1).(BV)
+	ok = true
+	return
+}
+
+// MatchBVSRem returns l and r if x is l.SRem(r).
+func MatchBVSRem(x Value) (l BV, r BV, ok bool) {
+	// Generated from bv.go:836.
+	if !x.impl().isAppOf(C.Z3_OP_BSREM) || x.NumArgs() != 2 {
+		return
+	}
+	l = x.Arg(0).(BV)
+	r = x.Arg(1).(BV)
+	ok = true
+	return
+}
+
+// MatchBVULT returns l and r if x is l.ULT(r).
+func MatchBVULT(x Value) (l BV, r BV, ok bool) {
+	// Generated from bv.go:840.
+	if !x.impl().isAppOf(C.Z3_OP_ULT) || x.NumArgs() != 2 {
+		return
+	}
+	l = x.Arg(0).(BV)
+	r = x.Arg(1).(BV)
+	ok = true
+	return
+}
+
+// MatchBVSLT returns l and r if x is l.SLT(r).
+func MatchBVSLT(x Value) (l BV, r BV, ok bool) {
+	// Generated from bv.go:844.
+	if !x.impl().isAppOf(C.Z3_OP_SLT) || x.NumArgs() != 2 {
+		return
+	}
+	l = x.Arg(0).(BV)
+	r = x.Arg(1).(BV)
+	ok = true
+	return
+}
+
+// MatchBVULE returns l and r if x is l.ULE(r).
+func MatchBVULE(x Value) (l BV, r BV, ok bool) {
+	// Generated from bv.go:848.
+	if !x.impl().isAppOf(C.Z3_OP_ULEQ) || x.NumArgs() != 2 {
+		return
+	}
+	l = x.Arg(0).(BV)
+	r = x.Arg(1).(BV)
+	ok = true
+	return
+}
+
+// MatchBVSLE returns l and r if x is l.SLE(r).
+func MatchBVSLE(x Value) (l BV, r BV, ok bool) {
+	// Generated from bv.go:852.
+	if !x.impl().isAppOf(C.Z3_OP_SLEQ) || x.NumArgs() != 2 {
+		return
+	}
+	l = x.Arg(0).(BV)
+	r = x.Arg(1).(BV)
+	ok = true
+	return
+}
+
+// MatchBVConcat returns l and r if x is l.Concat(r).
+func MatchBVConcat(x Value) (l BV, r BV, ok bool) {
+	// Generated from bv.go:856.
+	if !x.impl().isAppOf(C.Z3_OP_CONCAT) || x.NumArgs() != 2 {
+		return
+	}
+	l = x.Arg(0).(BV)
+	r = x.Arg(1).(BV)
+	ok = true
+	return
+}
+
+// MatchBVLsh returns l and i if x is l.Lsh(i).
+func MatchBVLsh(x Value) (l BV, i BV, ok bool) {
+	// Generated from bv.go:860.
+	if !x.impl().isAppOf(C.Z3_OP_BSHL) || x.NumArgs() != 2 {
+		return
+	}
+	l = x.Arg(0).(BV)
+	i = x.Arg(1).(BV)
+	ok = true
+	return
+}
+
+// MatchBVURsh returns l and i if x is l.URsh(i).
+func MatchBVURsh(x Value) (l BV, i BV, ok bool) {
+	// Generated from bv.go:864.
+	if !x.impl().isAppOf(C.Z3_OP_BLSHR) || x.NumArgs() != 2 {
+		return
+	}
+	l = x.Arg(0).(BV)
+	i = x.Arg(1).(BV)
+	ok = true
+	return
+}
+
+// MatchBVSRsh returns l and i if x is l.SRsh(i).
+func MatchBVSRsh(x Value) (l BV, i BV, ok bool) {
+	// Generated from bv.go:868.
+	if !x.impl().isAppOf(C.Z3_OP_BASHR) || x.NumArgs() != 2 {
+		return
+	}
+	l = x.Arg(0).(BV)
+	i = x.Arg(1).(BV)
+	ok = true
+	return
+}
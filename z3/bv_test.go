@@ -5,7 +5,9 @@
 package z3
 
 import (
+	"bytes"
 	"math/big"
+	"math/bits"
 	"testing"
 )
 
@@ -86,3 +88,437 @@ func TestBVAsInt64(t *testing.T) {
 		t.Errorf("-1:128 as int: expected %v, %v, %v; got %v, %v, %v", -1, true, true, vs, isConst, ok)
 	}
 }
+
+func TestBVOverflow(t *testing.T) {
+	ctx := NewContext(nil)
+	s8 := ctx.BVSort(8)
+
+	// Check each predicate exhaustively against Go int8/uint8
+	// arithmetic on a sample of values.
+	vals := []int{0, 1, 2, 15, 16, 100, 127, 128, 129, 200, 255}
+	for _, a := range vals {
+		for _, b := range vals {
+			l, r := ctx.FromInt(int64(a), s8).(BV), ctx.FromInt(int64(b), s8).(BV)
+			sa, sb := int(int8(a)), int(int8(b))
+			for _, test := range []struct {
+				name string
+				pred Bool
+				want bool
+			}{
+				{"UAddNoOverflow", l.UAddNoOverflow(r), a+b <= 255},
+				{"SAddNoOverflow", l.SAddNoOverflow(r), sa+sb <= 127},
+				{"SAddNoUnderflow", l.SAddNoUnderflow(r), sa+sb >= -128},
+				{"SSubNoOverflow", l.SSubNoOverflow(r), sa-sb <= 127},
+				{"USubNoUnderflow", l.USubNoUnderflow(r), a >= b},
+				{"SSubNoUnderflow", l.SSubNoUnderflow(r), sa-sb >= -128},
+				{"UMulNoOverflow", l.UMulNoOverflow(r), a*b <= 255},
+				{"SMulNoUnderflow", l.SMulNoUnderflow(r), sa*sb >= -128},
+				{"SDivNoOverflow", l.SDivNoOverflow(r), sb == 0 || sa/sb <= 127},
+				{"NegNoOverflow", l.NegNoOverflow(), -sa <= 127},
+			} {
+				if got := simplifyBool(t, ctx, test.pred); got != test.want {
+					t.Errorf("%d.%s(%d) = %v, want %v", a, test.name, b, got, test.want)
+				}
+			}
+		}
+	}
+}
+
+func TestBVSMulOverflow(t *testing.T) {
+	ctx := NewContext(nil)
+	s8 := ctx.BVSort(8)
+	vals := []int{0, 1, 2, 15, 16, 100, 127, 128, 129, 200, 255}
+	for _, a := range vals {
+		for _, b := range vals {
+			l, r := ctx.FromInt(int64(a), s8).(BV), ctx.FromInt(int64(b), s8).(BV)
+			want := int(int8(a))*int(int8(b)) <= 127
+			if got := simplifyBool(t, ctx, l.SMulNoOverflow(r)); got != want {
+				t.Errorf("%d.SMulNoOverflow(%d) = %v, want %v", a, b, got, want)
+			}
+		}
+	}
+}
+
+func TestBVFromBytes(t *testing.T) {
+	ctx := NewContext(nil)
+	b := []byte{0x12, 0x34, 0x56}
+	for _, test := range []struct {
+		endian Endianness
+		want   uint64
+	}{
+		{BigEndian, 0x123456},
+		{LittleEndian, 0x563412},
+	} {
+		bv := ctx.BVFromBytes(b, test.endian)
+		if size := bv.Sort().BVSize(); size != 24 {
+			t.Errorf("BVFromBytes(%x, %d) has size %d, want 24", b, test.endian, size)
+		}
+		if got, _, _ := bv.AsUint64(); got != test.want {
+			t.Errorf("BVFromBytes(%x, %d) = %#x, want %#x", b, test.endian, got, test.want)
+		}
+	}
+	if b[0] != 0x12 {
+		t.Errorf("BVFromBytes modified its argument")
+	}
+
+	bv := ctx.BVFromBitString("000101")
+	if size := bv.Sort().BVSize(); size != 6 {
+		t.Errorf("BVFromBitString(000101) has size %d, want 6", size)
+	}
+	if got, _, _ := bv.AsUint64(); got != 5 {
+		t.Errorf("BVFromBitString(000101) = %d, want 5", got)
+	}
+	for _, bad := range []string{"", "012", "-1", "1_0"} {
+		wantPanic(t, "", func() { ctx.BVFromBitString(bad) })
+	}
+}
+
+func TestBVAsStrings(t *testing.T) {
+	ctx := NewContext(nil)
+	for _, test := range []struct {
+		bits     int
+		val      int64
+		bin, hex string
+		be       []byte
+	}{
+		{8, 5, "00000101", "05", []byte{0x05}},
+		{12, 0xabc, "101010111100", "abc", []byte{0x0a, 0xbc}},
+		{6, -1, "111111", "3f", []byte{0x3f}},
+		{24, 0x123456, "000100100011010001010110", "123456", []byte{0x12, 0x34, 0x56}},
+	} {
+		bv := ctx.FromInt(test.val, ctx.BVSort(test.bits)).(BV)
+		if got, isLit := bv.AsBinaryString(); got != test.bin || !isLit {
+			t.Errorf("(%s).AsBinaryString() = %q, %v; want %q, true", bv, got, isLit, test.bin)
+		}
+		if got, isLit := bv.AsHexString(); got != test.hex || !isLit {
+			t.Errorf("(%s).AsHexString() = %q, %v; want %q, true", bv, got, isLit, test.hex)
+		}
+		if got, isLit := bv.AsBytes(BigEndian); !bytes.Equal(got, test.be) || !isLit {
+			t.Errorf("(%s).AsBytes(BigEndian) = %x, %v; want %x, true", bv, got, isLit, test.be)
+		}
+		le := reverseBytes(test.be)
+		if got, isLit := bv.AsBytes(LittleEndian); !bytes.Equal(got, le) || !isLit {
+			t.Errorf("(%s).AsBytes(LittleEndian) = %x, %v; want %x, true", bv, got, isLit, le)
+		}
+	}
+
+	// Round trip through BVFromBytes.
+	key := []byte{0xde, 0xad, 0xbe, 0xef, 0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b}
+	if got, _ := ctx.BVFromBytes(key, LittleEndian).AsBytes(LittleEndian); !bytes.Equal(got, key) {
+		t.Errorf("AsBytes(BVFromBytes(%x)) = %x", key, got)
+	}
+
+	if _, isLit := ctx.BVConst("x", 8).AsHexString(); isLit {
+		t.Errorf("constant x is a literal")
+	}
+}
+
+func TestBVReduce(t *testing.T) {
+	ctx := NewContext(nil)
+	for _, test := range []struct {
+		val      int64
+		all, any bool
+	}{
+		{0, false, false},
+		{1, false, true},
+		{0x80, false, true},
+		{0xfe, false, true},
+		{0xff, true, true},
+	} {
+		bv := ctx.FromInt(test.val, ctx.BVSort(8)).(BV)
+		all, _, _ := ctx.Simplify(bv.AllBits(), nil).(BV).AsUint64()
+		any, _, _ := ctx.Simplify(bv.AnyBits(), nil).(BV).AsUint64()
+		if (all == 1) != test.all || (any == 1) != test.any {
+			t.Errorf("%#x: AllBits() = %d, AnyBits() = %d; want %v, %v", test.val, all, any, test.all, test.any)
+		}
+		if got := simplifyBool(t, ctx, bv.AllBitsSet()); got != test.all {
+			t.Errorf("%#x.AllBitsSet() = %v, want %v", test.val, got, test.all)
+		}
+		if got := simplifyBool(t, ctx, bv.AnyBitSet()); got != test.any {
+			t.Errorf("%#x.AnyBitSet() = %v, want %v", test.val, got, test.any)
+		}
+	}
+}
+
+func TestBVBit(t *testing.T) {
+	ctx := NewContext(nil)
+	bv := ctx.FromInt(0xa5, ctx.BVSort(8)).(BV)
+	for i := 0; i < 8; i++ {
+		want := 0xa5&(1<<uint(i)) != 0
+		if got := simplifyBool(t, ctx, bv.Bit(i)); got != want {
+			t.Errorf("0xa5.Bit(%d) = %v, want %v", i, got, want)
+		}
+	}
+
+	// Setting flag bits determines the value.
+	x := ctx.BVConst("x", 4)
+	s := NewSolver(ctx)
+	s.Assert(x.Bit(0).And(x.Bit(2), x.Bit(1).Not(), x.Bit(3).Not()))
+	if sat, err := s.Check(); !sat {
+		t.Fatalf("%s not satisfiable: %v", s, err)
+	}
+	if got, _, _ := s.Model().Eval(x, true).(BV).AsUint64(); got != 5 {
+		t.Errorf("got x = %d, want 5", got)
+	}
+}
+
+func TestBVSAbs(t *testing.T) {
+	ctx := NewContext(nil)
+	for _, test := range []struct{ val, want uint64 }{
+		{0x05, 0x05},
+		{0xfb, 0x05}, // -5
+		{0x00, 0x00},
+		{0x7f, 0x7f},
+		{0x80, 0x80}, // -128 wraps around
+	} {
+		x := ctx.FromInt(int64(test.val), ctx.BVSort(8)).(BV)
+		if got, _, _ := ctx.Simplify(x.SAbs(), nil).(BV).AsUint64(); got != test.want {
+			t.Errorf("SAbs(%#x) = %#x, want %#x", test.val, got, test.want)
+		}
+	}
+}
+
+func TestBVBoolConversions(t *testing.T) {
+	ctx := NewContext(nil)
+	bits := ctx.FromInt(0xa5, ctx.BVSort(8)).(BV).Bits()
+	if len(bits) != 8 {
+		t.Fatalf("0xa5 has %d bits, want 8", len(bits))
+	}
+	for i, b := range bits {
+		want := 0xa5&(1<<uint(i)) != 0
+		if got := simplifyBool(t, ctx, b); got != want {
+			t.Errorf("0xa5.Bits()[%d] = %v, want %v", i, got, want)
+		}
+	}
+
+	for _, v := range []bool{false, true} {
+		bv := ctx.FromBool(v).ToBV(4)
+		want := uint64(0)
+		if v {
+			want = 1
+		}
+		if got, _, _ := ctx.Simplify(bv, nil).(BV).AsUint64(); got != want {
+			t.Errorf("%v.ToBV(4) = %d, want %d", v, got, want)
+		}
+		if bv.Sort().BVSize() != 4 {
+			t.Errorf("%v.ToBV(4) has sort %s", v, bv.Sort())
+		}
+		if got := simplifyBool(t, ctx, ctx.FromBool(v).ToBV(1).ToBool()); got != v {
+			t.Errorf("%v.ToBV(1).ToBool() = %v", v, got)
+		}
+	}
+
+	// Summing Bools as bit-vectors counts the true ones.
+	x := ctx.BVConst("x", 8)
+	xbits := x.Bits()
+	count := xbits[0].ToBV(8)
+	for _, b := range xbits[1:] {
+		count = count.Add(b.ToBV(8))
+	}
+	s := NewSolver(ctx)
+	s.Assert(count.NE(x.Popcount()))
+	if sat, err := s.Check(); sat || err != nil {
+		t.Fatalf("sum of Bits differs from Popcount: %v, %v", sat, err)
+	}
+
+	wantPanic(t, "ToBool of 8-bit", func() { x.ToBool() })
+}
+
+func TestBVBitCounts(t *testing.T) {
+	ctx := NewContext(nil)
+	for _, width := range []int{1, 5, 8, 13, 64} {
+		sort := ctx.BVSort(width)
+		for _, val := range []uint64{0, 1, 2, 3, 0x10, 0x55, 0x1234, 1<<63 | 1, ^uint64(0)} {
+			val &= ^uint64(0) >> uint(64-width)
+			bv := ctx.FromBigInt(new(big.Int).SetUint64(val), sort).(BV)
+			pop := bits.OnesCount64(val)
+			clz := bits.LeadingZeros64(val) - (64 - width)
+			ctz := bits.TrailingZeros64(val)
+			if ctz > width {
+				ctz = width
+			}
+			for _, test := range []struct {
+				name string
+				got  BV
+				want int
+			}{
+				{"Popcount", bv.Popcount(), pop},
+				{"Clz", bv.Clz(), clz},
+				{"Ctz", bv.Ctz(), ctz},
+			} {
+				got, _, _ := ctx.Simplify(test.got, nil).(BV).AsUint64()
+				if got != uint64(test.want) {
+					t.Errorf("%#x:%d.%s() = %d, want %d", val, width, test.name, got, test.want)
+				}
+			}
+		}
+	}
+}
+
+func TestBVRotate(t *testing.T) {
+	ctx := NewContext(nil)
+	sort := ctx.BVSort(8)
+	x := ctx.FromInt(0x96, sort).(BV)
+	for i := 0; i <= 9; i++ {
+		want := uint64(bits.RotateLeft8(0x96, i))
+		for _, test := range []struct {
+			name string
+			got  BV
+			want uint64
+		}{
+			{"RotateLeft", x.RotateLeft(ctx.FromInt(int64(i), sort).(BV)), want},
+			{"RotateLeftConst", x.RotateLeftConst(i), want},
+			{"RotateRight", x.RotateRight(ctx.FromInt(int64(8-i%8), sort).(BV)), want},
+			{"RotateRightConst", x.RotateRightConst(8 - i%8), want},
+		} {
+			if got, _, _ := ctx.Simplify(test.got, nil).(BV).AsUint64(); got != test.want {
+				t.Errorf("%s by %d: got %#x, want %#x", test.name, i, got, test.want)
+			}
+		}
+	}
+}
+
+func TestFromBigIntBV(t *testing.T) {
+	ctx := NewContext(nil)
+	wide, _ := new(big.Int).SetString("123456789abcdef0123456789abcdef0123456789abcdef", 16)
+	for _, test := range []struct {
+		val  *big.Int
+		bits int
+	}{
+		{big.NewInt(5), 8},
+		{big.NewInt(-1), 8},
+		{big.NewInt(-129), 8},
+		{big.NewInt(300), 8},
+		{wide, 200},
+		{wide, 100},
+		{new(big.Int).Neg(wide), 256},
+	} {
+		got := ctx.FromBigIntBV(test.val, test.bits)
+		// FromBigInt goes through Z3's decimal parser, which
+		// also truncates.
+		want := ctx.FromBigInt(test.val, ctx.BVSort(test.bits)).(BV)
+		if size := got.Sort().BVSize(); size != test.bits {
+			t.Errorf("FromBigIntBV(%v, %d) has size %d", test.val, test.bits, size)
+		}
+		if !simplifyBool(t, ctx, got.Eq(want)) {
+			t.Errorf("FromBigIntBV(%v, %d) = %s, want %s", test.val, test.bits, got, want)
+		}
+	}
+}
+
+func TestBVSaturating(t *testing.T) {
+	ctx := NewContext(nil)
+	s8 := ctx.BVSort(8)
+	clampU := func(x int) int {
+		if x < 0 {
+			return 0
+		} else if x > 255 {
+			return 255
+		}
+		return x
+	}
+	clampS := func(x int) int {
+		if x < -128 {
+			return -128
+		} else if x > 127 {
+			return 127
+		}
+		return x
+	}
+	vals := []int{0, 1, 2, 15, 16, 100, 127, 128, 129, 200, 255}
+	for _, a := range vals {
+		for _, b := range vals {
+			l, r := ctx.FromInt(int64(a), s8).(BV), ctx.FromInt(int64(b), s8).(BV)
+			sa, sb := int(int8(a)), int(int8(b))
+			for _, test := range []struct {
+				name   string
+				got    BV
+				want   int
+				signed bool
+			}{
+				{"UAddSat", l.UAddSat(r), clampU(a + b), false},
+				{"SAddSat", l.SAddSat(r), clampS(sa + sb), true},
+				{"USubSat", l.USubSat(r), clampU(a - b), false},
+				{"SSubSat", l.SSubSat(r), clampS(sa - sb), true},
+				{"UMulSat", l.UMulSat(r), clampU(a * b), false},
+				{"SMulSat", l.SMulSat(r), clampS(sa * sb), true},
+			} {
+				lit := ctx.Simplify(test.got, nil).(BV)
+				var got int
+				if test.signed {
+					v, _, _ := lit.AsInt64()
+					got = int(v)
+				} else {
+					v, _, _ := lit.AsUint64()
+					got = int(v)
+				}
+				if got != test.want {
+					t.Errorf("%d.%s(%d) = %d, want %d", a, test.name, b, got, test.want)
+				}
+			}
+		}
+	}
+}
+
+func TestBVMatch(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y := ctx.BVConst("x", 8), ctx.BVConst("y", 8)
+	eq := func(v, w Value) bool { return v.AsAST().Equal(w.AsAST()) }
+
+	for _, test := range []struct {
+		name  string
+		match func(Value) (BV, BV, bool)
+		val   Value
+	}{
+		{"Add", MatchBVAdd, x.Add(y)},
+		{"Sub", MatchBVSub, x.Sub(y)},
+		{"Mul", MatchBVMul, x.Mul(y)},
+		{"And", MatchBVAnd, x.And(y)},
+		{"Concat", MatchBVConcat, x.Concat(y)},
+		{"Lsh", MatchBVLsh, x.Lsh(y)},
+		{"SRsh", MatchBVSRsh, x.SRsh(y)},
+		{"ULT", MatchBVULT, x.ULT(y)},
+		{"SLE", MatchBVSLE, x.SLE(y)},
+	} {
+		if l, r, ok := test.match(test.val); !ok || !eq(l, x) || !eq(r, y) {
+			t.Errorf("MatchBV%s(%v) = %v, %v, %v", test.name, test.val, l, r, ok)
+		}
+		if _, _, ok := test.match(x); ok {
+			t.Errorf("MatchBV%s matched constant", test.name)
+		}
+	}
+	if _, _, ok := MatchBVAdd(x.Sub(y)); ok {
+		t.Errorf("MatchBVAdd matched Sub")
+	}
+	if l, ok := MatchBVNeg(x.Neg()); !ok || !eq(l, x) {
+		t.Errorf("MatchBVNeg = %v, %v", l, ok)
+	}
+}
+
+func TestBVAppendWords(t *testing.T) {
+	ctx := NewContext(nil)
+	want := new(big.Int).Lsh(big.NewInt(0x1234), 130)
+	want.Add(want, big.NewInt(0xabcdef))
+	lit := ctx.FromBigInt(want, ctx.BVSort(150)).(BV)
+
+	buf := make([]uint64, 0, 8)
+	words, ok := lit.AppendWords(buf)
+	if !ok || len(words) != 3 || &words[0] != &buf[:1][0] {
+		t.Fatalf("AppendWords = %v, %v, want 3 words in buf", words, ok)
+	}
+	if words[0] != 0xabcdef || words[1] != 0 || words[2] != 0x1234<<2 {
+		t.Errorf("AppendWords = %#x", words)
+	}
+	words, _ = lit.AppendWords(words)
+	if len(words) != 6 || words[3] != 0xabcdef {
+		t.Errorf("appending again = %#x", words)
+	}
+	if got, _ := lit.AsBigUnsigned(); got.Cmp(want) != 0 {
+		t.Errorf("AsBigUnsigned = %v, want %v", got, want)
+	}
+
+	x := ctx.BVConst("x", 8)
+	if words, ok := x.AppendWords(nil); ok || words != nil {
+		t.Errorf("AppendWords of non-literal = %v, %v", words, ok)
+	}
+}
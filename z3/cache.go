@@ -0,0 +1,77 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+*/
+import "C"
+
+// ctxCache memoizes sorts and numerals that encodings use over and
+// over, so that getting them again requires neither a call into Z3
+// nor a new wrapper.
+//
+// Cached objects hold their Z3 reference until the Context is
+// deleted and have no finalizers. Hence, even though they refer back
+// to the Context, they don't prevent it from being finalized.
+type ctxCache struct {
+	boolSort, intSort, realSort Sort
+	bvSorts                     map[int]Sort
+
+	// numerals maps a sort and -1, 0, or 1 to that numeral.
+	numerals map[numeralKey]value
+}
+
+type numeralKey struct {
+	// sort cannot be reused by another sort because the cached
+	// numeral refers to it.
+	sort C.Z3_sort
+	val  int64
+}
+
+// cachedSort returns *s, first setting it to the sort returned by mk
+// if it isn't set. This must be called with the ctx.lock held.
+func (ctx *Context) cachedSort(s *Sort, kind Kind, mk func() C.Z3_sort) Sort {
+	if s.sortImpl == nil {
+		c := mk()
+		C.Z3_inc_ref(ctx.c, C.Z3_sort_to_ast(ctx.c, c))
+		*s = Sort{&sortImpl{ctx, c, kind}, noEq{}}
+	}
+	return *s
+}
+
+// bvSort returns the cached bit-vector sort of the given width. This
+// must be called with the ctx.lock held.
+func (ctx *Context) bvSort(bits int) Sort {
+	s := ctx.cache.bvSorts[bits]
+	if s.sortImpl == nil {
+		s = ctx.cachedSort(&s, KindBV, func() C.Z3_sort {
+			return C.Z3_mk_bv_sort(ctx.c, C.unsigned(bits))
+		})
+		if ctx.cache.bvSorts == nil {
+			ctx.cache.bvSorts = make(map[int]Sort)
+		}
+		ctx.cache.bvSorts[bits] = s
+	}
+	return s
+}
+
+// smallNumeral returns the cached numeral val of sort, where val is
+// -1, 0, or 1. This must be called with the ctx.lock held.
+func (ctx *Context) smallNumeral(val int64, sort Sort) value {
+	key := numeralKey{sort.c, val}
+	if v, ok := ctx.cache.numerals[key]; ok {
+		return v
+	}
+	c := C.Z3_mk_int64(ctx.c, C.int64_t(val), sort.c)
+	C.Z3_inc_ref(ctx.c, c)
+	v := value{&valueImpl{ctx, c, true, nil}, noEq{}}
+	if ctx.cache.numerals == nil {
+		ctx.cache.numerals = make(map[numeralKey]value)
+	}
+	ctx.cache.numerals[key] = v
+	return v
+}
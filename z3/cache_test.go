@@ -0,0 +1,42 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	ctx := NewContext(nil)
+	if ctx.BoolSort().sortImpl != ctx.BoolSort().sortImpl ||
+		ctx.IntSort().sortImpl != ctx.IntSort().sortImpl ||
+		ctx.RealSort().sortImpl != ctx.RealSort().sortImpl ||
+		ctx.BVSort(8).sortImpl != ctx.BVSort(8).sortImpl {
+		t.Errorf("sorts not cached")
+	}
+	if ctx.BVSort(8).sortImpl == ctx.BVSort(16).sortImpl {
+		t.Errorf("BV sorts of different widths are the same")
+	}
+
+	for _, sort := range []Sort{ctx.IntSort(), ctx.RealSort(), ctx.BVSort(8)} {
+		for val := int64(-1); val <= 1; val++ {
+			x, y := ctx.FromInt(val, sort), ctx.FromInt(val, sort)
+			if x.impl() != y.impl() {
+				t.Errorf("%v of sort %v not cached", val, sort)
+			}
+			// Releasing a cached numeral must not affect
+			// later users.
+			x.Release()
+		}
+	}
+	runtime.GC()
+	if got := ctx.FromInt(-1, ctx.BVSort(8)).String(); got != "#xff" {
+		t.Errorf("-1 as BV(8) is %s, want #xff", got)
+	}
+	if got := ctx.FromInt(2, ctx.IntSort()).String(); got != "2" {
+		t.Errorf("2 as Int is %s, want 2", got)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// callbacks maps the user context IDs passed to Z3 callback
+// registration functions to the Go state they identify. Z3 must not
+// be given pointers to Go memory, so it refers to Go state by these
+// IDs instead.
+var callbacks struct {
+	sync.Mutex
+	m    map[uintptr]callbackState
+	next uintptr
+}
+
+type callbackState struct {
+	ctx *Context
+	v   interface{}
+}
+
+// newCallback registers v as the state for a callback in ctx and
+// returns its ID. The caller must eventually call removeCallback.
+func newCallback(ctx *Context, v interface{}) uintptr {
+	callbacks.Lock()
+	defer callbacks.Unlock()
+	if callbacks.m == nil {
+		callbacks.m = make(map[uintptr]callbackState)
+	}
+	callbacks.next++
+	callbacks.m[callbacks.next] = callbackState{ctx, v}
+	return callbacks.next
+}
+
+func removeCallback(id uintptr) {
+	callbacks.Lock()
+	defer callbacks.Unlock()
+	delete(callbacks.m, id)
+}
+
+// callback runs f with the state registered as id. Z3 invokes
+// callbacks from within an operation that holds the context lock, so
+// callback passes f the context's callback handle, whose operations
+// run without acquiring the lock while f runs. Other goroutines
+// using the context wait until the operation finishes.
+func callback(id unsafe.Pointer, f func(ctx *Context, v interface{})) {
+	callbacks.Lock()
+	st, ok := callbacks.m[uintptr(id)]
+	callbacks.Unlock()
+	if !ok {
+		return
+	}
+	h := st.ctx.callbackHandle()
+	atomic.AddInt32(&h.active, 1)
+	defer func() {
+		if atomic.AddInt32(&h.active, -1) == 0 {
+			// Perform the releases deferred while f ran.
+			h.flushReleases()
+		}
+	}()
+	f(h, st.v)
+}
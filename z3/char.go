@@ -0,0 +1,69 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+*/
+import "C"
+
+// Char is a symbolic value representing a single Unicode character.
+//
+// Strings are sequences of characters. An individual character of a
+// String can be retrieved with String.CharAt, and a Char can be
+// converted to a single-character String using Context.SeqUnit.
+//
+// Char implements Value.
+type Char value
+
+func init() {
+	kindWrappers[KindChar] = func(x value) Value {
+		return Char(x)
+	}
+}
+
+// CharSort returns the character sort.
+func (ctx *Context) CharSort() Sort {
+	var sort Sort
+	ctx.do(func() {
+		sort = wrapSort(ctx, C.Z3_mk_char_sort(ctx.c), KindChar)
+	})
+	return sort
+}
+
+// CharConst returns a character constant named "name".
+func (ctx *Context) CharConst(name string) Char {
+	return ctx.Const(name, ctx.CharSort()).(Char)
+}
+
+// FromRune returns a character literal whose code point is val.
+//
+// val must be within the range of characters supported by Z3, which
+// by default is [0, 0x2FFFF].
+func (ctx *Context) FromRune(val rune) Char {
+	return Char(wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_char(ctx.c, C.unsigned(val))
+	}))
+}
+
+//go:generate go run genwrap.go -t Char $GOFILE
+
+// LE returns l <= r, comparing l and r by code point.
+//
+//wrap:expr LE:Bool Z3_mk_char_le l r
+
+// ToInt returns the code point of l.
+//
+//wrap:expr ToInt:Int Z3_mk_char_to_int l
+
+// ToBV returns the code point of l as a bit-vector.
+//
+//wrap:expr ToBV:BV Z3_mk_char_to_bv l
+
+// IsDigit returns a Value that is true if l is a decimal digit
+// character ('0' through '9').
+//
+//wrap:expr IsDigit:Bool Z3_mk_char_is_digit l
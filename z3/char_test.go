@@ -0,0 +1,34 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestChar(t *testing.T) {
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	s := ctx.FromString("a1")
+	c0 := s.CharAt(ctx.FromInt(0, ints).(Int))
+	c1 := s.CharAt(ctx.FromInt(1, ints).(Int))
+
+	if !simplifyBool(t, ctx, c0.Eq(ctx.FromRune('a'))) {
+		t.Errorf("%s.CharAt(0) != 'a'", s)
+	}
+	if simplifyBool(t, ctx, c0.IsDigit()) {
+		t.Errorf("'a'.IsDigit() is true")
+	}
+	if !simplifyBool(t, ctx, c1.IsDigit()) {
+		t.Errorf("'1'.IsDigit() is false")
+	}
+	if !simplifyBool(t, ctx, c1.ToInt().Eq(ctx.FromInt('1', ints).(Int))) {
+		t.Errorf("'1'.ToInt() != %d", '1')
+	}
+	if !simplifyBool(t, ctx, c1.LE(c0)) {
+		t.Errorf("'1'.LE('a') is false")
+	}
+	if !simplifyBool(t, ctx, ctx.SeqUnit(c0).(String).Eq(ctx.FromString("a"))) {
+		t.Errorf("SeqUnit('a') != \"a\"")
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"runtime"
+	"unsafe"
+)
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+#include <stdlib.h>
+*/
+import "C"
+
+// A CNF is a propositional formula in conjunctive normal form.
+type CNF struct {
+	// Clauses is the conjunction of clauses. Each clause is a
+	// disjunction of non-zero literals, where literal n refers
+	// to Vars[n-1] and -n refers to its negation, as in DIMACS.
+	// An empty clause is false.
+	Clauses [][]int
+
+	// Vars gives the propositional variable for each literal.
+	// These are generally fresh constants introduced by
+	// bit-blasting, rather than constants in the original
+	// formulas.
+	Vars []Bool
+}
+
+// BitBlast converts the conjunction of fs into an equisatisfiable
+// propositional formula in conjunctive normal form.
+//
+// This simplifies fs, bit-blasts bit-vector operations, and converts
+// the result to CNF using the Tseitin encoding. It returns an error
+// if fs contain terms that cannot be bit-blasted, such as integer
+// arithmetic.
+func (ctx *Context) BitBlast(fs ...Bool) (*CNF, error) {
+	var formulas []Bool
+	err := Try(func() {
+		ctx.do(func() {
+			goal := C.Z3_mk_goal(ctx.c, boolToZ3(false), boolToZ3(false), boolToZ3(false))
+			C.Z3_goal_inc_ref(ctx.c, goal)
+			defer C.Z3_goal_dec_ref(ctx.c, goal)
+			for _, f := range fs {
+				C.Z3_goal_assert(ctx.c, goal, f.c)
+			}
+
+			tactic := ctx.mkTactic("simplify")
+			for _, name := range []string{"bit-blast", "tseitin-cnf"} {
+				next := ctx.mkTactic(name)
+				t := C.Z3_tactic_and_then(ctx.c, tactic, next)
+				C.Z3_tactic_inc_ref(ctx.c, t)
+				C.Z3_tactic_dec_ref(ctx.c, tactic)
+				C.Z3_tactic_dec_ref(ctx.c, next)
+				tactic = t
+			}
+			defer C.Z3_tactic_dec_ref(ctx.c, tactic)
+
+			res := C.Z3_tactic_apply(ctx.c, tactic, goal)
+			C.Z3_apply_result_inc_ref(ctx.c, res)
+			defer C.Z3_apply_result_dec_ref(ctx.c, res)
+			// These tactics don't split goals, so there's
+			// exactly one subgoal.
+			sub := C.Z3_apply_result_get_subgoal(ctx.c, res, 0)
+			n := C.Z3_goal_size(ctx.c, sub)
+			for i := C.uint(0); i < n; i++ {
+				f := wrapAST(ctx, C.Z3_goal_formula(ctx.c, sub, i))
+				formulas = append(formulas, Bool(value{(*valueImpl)(f.astImpl), noEq{}}))
+			}
+		})
+	})
+	runtime.KeepAlive(fs)
+	if err != nil {
+		return nil, fmt.Errorf("z3: bit-blasting: %v", err)
+	}
+
+	cnf := new(CNF)
+	vars := make(map[uint64]int)
+	lit := func(x Bool) (int, error) {
+		neg := 1
+		if l, ok := MatchNot(x); ok {
+			x, neg = l, -1
+		}
+		if x.NumArgs() != 0 || !x.isAppOf(C.Z3_OP_UNINTERPRETED) {
+			return 0, fmt.Errorf("z3: bit-blasting: %v is not propositional", x)
+		}
+		id := x.AsAST().ID()
+		v, ok := vars[id]
+		if !ok {
+			cnf.Vars = append(cnf.Vars, x)
+			v = len(cnf.Vars)
+			vars[id] = v
+		}
+		return neg * v, nil
+	}
+	for _, f := range formulas {
+		if f.isAppOf(C.Z3_OP_TRUE) {
+			continue
+		}
+		var clause []int
+		if f.isAppOf(C.Z3_OP_FALSE) {
+			clause = []int{}
+		} else {
+			lits, ok := MatchOr(f)
+			if !ok {
+				lits = []Bool{f}
+			}
+			for _, l := range lits {
+				n, err := lit(l)
+				if err != nil {
+					return nil, err
+				}
+				clause = append(clause, n)
+			}
+		}
+		cnf.Clauses = append(cnf.Clauses, clause)
+	}
+	return cnf, nil
+}
+
+// mkTactic returns a new reference to the named tactic. This must be
+// called with the ctx.lock held.
+func (ctx *Context) mkTactic(name string) C.Z3_tactic {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	t := C.Z3_mk_tactic(ctx.c, cname)
+	C.Z3_tactic_inc_ref(ctx.c, t)
+	return t
+}
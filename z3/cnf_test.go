@@ -0,0 +1,63 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestBitBlast(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y := ctx.BVConst("x", 4), ctx.BVConst("y", 4)
+	f := x.Add(y).Eq(ctx.FromInt(5, x.Sort()).(BV))
+	g := x.UGT(y)
+
+	cnf, err := ctx.BitBlast(f, g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cnf.Clauses) == 0 || len(cnf.Vars) < 8 {
+		t.Fatalf("got %d clauses over %d variables", len(cnf.Clauses), len(cnf.Vars))
+	}
+
+	// The CNF must be satisfiable, like f and g.
+	s := NewSolver(ctx)
+	for _, clause := range cnf.Clauses {
+		lits := make([]Bool, len(clause))
+		for i, l := range clause {
+			if l == 0 || abs(l) > len(cnf.Vars) {
+				t.Fatalf("bad literal %d", l)
+			}
+			lits[i] = cnf.Vars[abs(l)-1]
+			if l < 0 {
+				lits[i] = lits[i].Not()
+			}
+		}
+		s.Assert(ctx.FromBool(false).Or(lits...))
+	}
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("CNF is not satisfiable: %v, %v", sat, err)
+	}
+
+	// A trivially unsatisfiable problem produces an empty clause.
+	cnf, err = ctx.BitBlast(x.ULT(x))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cnf.Clauses) != 1 || len(cnf.Clauses[0]) != 0 {
+		t.Errorf("BitBlast(x < x) = %v, want [[]]", cnf.Clauses)
+	}
+
+	// Integer arithmetic can't be bit-blasted.
+	i := ctx.IntConst("i")
+	if _, err := ctx.BitBlast(i.Mul(i).GT(i)); err == nil {
+		t.Errorf("BitBlast of integer formula succeeded")
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -4,6 +4,11 @@
 
 package z3
 
+import (
+	"fmt"
+	"sort"
+)
+
 /*
 #cgo LDFLAGS: -lz3
 #include <z3.h>
@@ -13,17 +18,109 @@ import "C"
 // Config stores a set of configuration parameters. Configs are used
 // to configure many different objects in Z3.
 type Config struct {
-	m   map[string]interface{}
-	set func(name string, value interface{})
+	m    map[string]interface{}
+	set  func(name string, value interface{})
+	desc []ParamDesc
+}
+
+// A ParamDesc describes a configuration parameter.
+type ParamDesc struct {
+	// Name is the name of the parameter.
+	Name string
+
+	// Type is the type of the parameter's value: "bool", "uint",
+	// "double", "string", or "symbol". Use SetFloat for "double"
+	// parameters and SetString for "string" and "symbol"
+	// parameters.
+	Type string
+
+	// Description is a short description of the parameter.
+	Description string
+}
+
+func newConfig(desc []ParamDesc) *Config {
+	return &Config{m: make(map[string]interface{}), desc: desc}
 }
 
-type param struct {
-	name, typ, description string
+// Params returns descriptions of the parameters that p accepts.
+func (p *Config) Params() []ParamDesc {
+	return append([]ParamDesc(nil), p.desc...)
+}
+
+// paramDescs converts a C Z3_param_descrs to a slice of ParamDescs.
+// This must be called with the ctx.lock held.
+func paramDescs(ctx *Context, c C.Z3_param_descrs) []ParamDesc {
+	C.Z3_param_descrs_inc_ref(ctx.c, c)
+	defer C.Z3_param_descrs_dec_ref(ctx.c, c)
+	n := C.Z3_param_descrs_size(ctx.c, c)
+	desc := make([]ParamDesc, n)
+	for i := C.uint(0); i < n; i++ {
+		sym := C.Z3_param_descrs_get_name(ctx.c, c, i)
+		var typ string
+		switch C.Z3_param_descrs_get_kind(ctx.c, c, sym) {
+		case C.Z3_PK_UINT:
+			typ = "uint"
+		case C.Z3_PK_BOOL:
+			typ = "bool"
+		case C.Z3_PK_DOUBLE:
+			typ = "double"
+		case C.Z3_PK_SYMBOL:
+			typ = "symbol"
+		case C.Z3_PK_STRING:
+			typ = "string"
+		default:
+			typ = "other"
+		}
+		desc[i] = ParamDesc{
+			Name:        C.GoString(C.Z3_get_symbol_string(ctx.c, sym)),
+			Type:        typ,
+			Description: C.GoString(C.Z3_param_descrs_get_documentation(ctx.c, c, sym)),
+		}
+	}
+	return desc
 }
 
-func newConfig(desc []param) *Config {
-	// TODO: API to access the parameter descriptions.
-	return &Config{m: make(map[string]interface{})}
+// Validate checks that every parameter set in p is one of p's
+// Params and that its value has the parameter's type. It returns an
+// error describing the first problem found. If p does not know its
+// parameters, Validate returns nil.
+func (p *Config) Validate() error {
+	if p.desc == nil {
+		return nil
+	}
+	types := make(map[string]string, len(p.desc))
+	for _, d := range p.desc {
+		types[d.Name] = d.Type
+	}
+	names := make([]string, 0, len(p.m))
+	for name := range p.m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		typ, ok := types[name]
+		if !ok {
+			return fmt.Errorf("z3: unknown parameter %q", name)
+		}
+		var want string
+		switch p.m[name].(type) {
+		case bool:
+			want = "bool"
+		case uint:
+			want = "uint"
+		case float64:
+			want = "double"
+		case string:
+			if typ == "symbol" {
+				continue
+			}
+			want = "string"
+		}
+		if typ != want {
+			return fmt.Errorf("z3: parameter %q has type %s, but was set to %s %v", name, typ, want, p.m[name])
+		}
+	}
+	return nil
 }
 
 func (p *Config) SetBool(name string, value bool) *Config {
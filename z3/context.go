@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"runtime"
 	"sync"
+	"sync/atomic"
+	"time"
 	"unsafe"
 )
 
@@ -43,29 +45,85 @@ type Context struct {
 	// to get the rounding mode AST.
 	roundingModeAST value
 
+	// printMode is the current AST printing mode.
+	printMode PrintMode
+
+	// cache memoizes frequently used sorts and numerals. It is
+	// protected by lock.
+	cache ctxCache
+
+	// arena is the Arena that owns ASTs created in ctx, or nil.
+	// It is set when ctx is created by NewArena and never
+	// changes.
+	arena *Arena
+
 	// extra contains extra values associated with this Context.
 	// This must be outside contextImpl so objects that reference
 	// Context (e.g., Values and Sorts) can be added to here
 	// without creating a cycle and preventing finalization.
 	extra map[interface{}]interface{}
 
+	// parent is the Context that created ctx as its callback
+	// handle, or nil. See callbackHandle.
+	parent *Context
+
+	// handle is ctx's callback handle, or nil if no callback has
+	// run in ctx yet. It is protected by lock.
+	handle *Context
+
+	// active is the number of callbacks running in ctx, which
+	// must be a callback handle. It is accessed atomically.
+	active int32
+}
+
+type contextImpl struct {
+	c C.Z3_context
+
+	// The fields below are shared by a Context and its callback
+	// handle.
+
 	// lock protects AST reference counts and the context's last
 	// error. Use Context.do to acquire this around a Z3 operation
 	// and panic if the operation has an error status.
 	lock sync.Mutex
-}
 
-type contextImpl struct {
-	c C.Z3_context
+	// interruptLock protects the fields below against Interrupt
+	// and concurrent releases, which must not wait for lock.
+	// These fields are only set with both locks held, so either
+	// lock suffices to read them.
+	interruptLock sync.Mutex
+
+	// closed indicates that Close has deleted the Z3 context.
+	closed bool
+
+	// concurrentDecRef indicates that Z3 permits releasing ASTs
+	// without lock. See EnableConcurrentDecRef.
+	concurrentDecRef bool
+
+	// leaks records the creation sites of live ASTs if tracking
+	// is enabled by TrackASTs, and is otherwise nil.
+	leaks *leakTracker
+
+	// unlocked indicates that ctx does not use lock. See
+	// DisableLocking.
+	unlocked bool
+
+	// pending is the queue of releases made while unlocked is
+	// set or a callback is running, to be performed by the next
+	// operation or when the callback returns. npending is 1
+	// if pending is non-empty, and may be read atomically without
+	// interruptLock.
+	pending  []func()
+	npending int32
 }
 
 //export goZ3ErrorHandler
 func goZ3ErrorHandler(ctx C.Z3_context, e C.Z3_error_code) {
-	msg := C.Z3_get_error_msg_ex(ctx, e)
-	// TODO: Lift the Z3 errors to better Go errors. At least wrap
-	// the string in a type and consider using the error code to
-	// determine which of different error types to use.
-	panic(C.GoString(msg))
+	msg := C.GoString(C.Z3_get_error_msg(ctx, e))
+	if f := errorHandler(ctx); f != nil {
+		f(ErrorCode(e), msg)
+	}
+	panic(&Error{ErrorCode(e), msg})
 }
 
 // NewContext returns a new Z3 context with the given configuration.
@@ -85,8 +143,9 @@ func NewContext(config *Config) *Context {
 		}
 	}
 	// Construct the Z3_context.
-	impl := &contextImpl{C.Z3_mk_context_rc(cfg)}
+	impl := &contextImpl{c: C.Z3_mk_context_rc(cfg)}
 	runtime.SetFinalizer(impl, func(impl *contextImpl) {
+		setErrorHandler(impl.c, nil)
 		C.Z3_del_context(impl.c)
 	})
 	ctx := &Context{
@@ -94,8 +153,13 @@ func NewContext(config *Config) *Context {
 		make(map[string]C.Z3_symbol),
 		RoundToNearestEven,
 		value{},
+		PrintSMTLIB2Full,
+		ctxCache{},
 		nil,
-		sync.Mutex{},
+		nil,
+		nil,
+		nil,
+		0,
 	}
 	// Install an error handler that turns errors into Go panics.
 	// This error handler is equivalent to a longjmp on the C++
@@ -121,7 +185,7 @@ func NewContext(config *Config) *Context {
 func NewContextConfig() *Config {
 	// Based on context_params.cpp:collect_param_descrs.
 	// Unfortunately, there's no way to access this from the API.
-	return newConfig([]param{
+	return newConfig([]ParamDesc{
 		{"timeout", "uint", "Timeout in milliseconds used for solvers"},
 		{"rlimit", "uint", "Resource limit used for solvers"},
 		{"well_sorted_check", "bool", "Type checker"},
@@ -139,6 +203,59 @@ func NewContextConfig() *Config {
 	})
 }
 
+// A ContextOption configures a new Context. See NewContextWith.
+type ContextOption func(*Config)
+
+// WithModel sets whether solvers generate models by default.
+func WithModel(enable bool) ContextOption {
+	return func(cfg *Config) { cfg.SetBool("model", enable) }
+}
+
+// WithProof sets whether solvers generate proofs.
+func WithProof(enable bool) ContextOption {
+	return func(cfg *Config) { cfg.SetBool("proof", enable) }
+}
+
+// WithUnsatCore sets whether solvers generate unsatisfiable cores by
+// default.
+func WithUnsatCore(enable bool) ContextOption {
+	return func(cfg *Config) { cfg.SetBool("unsat_core", enable) }
+}
+
+// WithAutoConfig sets whether Z3 uses heuristics to select and
+// configure solvers.
+func WithAutoConfig(enable bool) ContextOption {
+	return func(cfg *Config) { cfg.SetBool("auto_config", enable) }
+}
+
+// WithTimeout sets the timeout for solvers, rounded up to a whole
+// number of milliseconds.
+func WithTimeout(d time.Duration) ContextOption {
+	ms := (d + time.Millisecond - 1) / time.Millisecond
+	return func(cfg *Config) { cfg.SetUint("timeout", uint(ms)) }
+}
+
+// WithParam sets the named parameter to value, which must be a bool,
+// uint, float64, or string. See NewContextConfig for the available
+// parameters.
+func WithParam(name string, value interface{}) ContextOption {
+	return func(cfg *Config) { cfg.m[name] = value }
+}
+
+// NewContextWith returns a new Z3 context configured by opts. Unlike
+// NewContext, it checks the configuration and returns an error if
+// it sets an unknown parameter or a parameter to the wrong type.
+func NewContextWith(opts ...ContextOption) (*Context, error) {
+	cfg := NewContextConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return NewContext(cfg), nil
+}
+
 // Config returns a *Config object for dynamically changing ctx's
 // configuration.
 func (ctx *Context) Config() *Config {
@@ -156,48 +273,343 @@ func (ctx *Context) setParam(name string, val interface{}) {
 	})
 }
 
-// Interrupt stops the current solver, simplifier, or tactic being
-// executed by ctx.
+// PrintMode is a format for converting ASTs to strings.
+type PrintMode int
+
+const (
+	// PrintSMTLIB2Full prints ASTs as SMT-LIB2 S-expressions,
+	// without sharing common subexpressions. This is the default.
+	PrintSMTLIB2Full PrintMode = C.Z3_PRINT_SMTLIB_FULL
+
+	// PrintLowLevel prints ASTs in Z3's internal format, where
+	// each shared subexpression is printed once and referred to
+	// by number.
+	PrintLowLevel PrintMode = C.Z3_PRINT_LOW_LEVEL
+
+	// PrintSMTLIB2Compliant prints ASTs in strictly SMT-LIB2
+	// compliant syntax, avoiding Z3-specific extensions.
+	PrintSMTLIB2Compliant PrintMode = C.Z3_PRINT_SMTLIB2_COMPLIANT
+)
+
+// SetPrintMode sets the format used to convert ASTs, Values, and other
+// objects created by ctx to strings, and returns ctx's old print mode.
+func (ctx *Context) SetPrintMode(mode PrintMode) PrintMode {
+	var old PrintMode
+	ctx.do(func() {
+		old = ctx.printMode
+		C.Z3_set_ast_print_mode(ctx.c, C.Z3_ast_print_mode(mode))
+		ctx.printMode = mode
+	})
+	return old
+}
+
+// Close releases the native resources held by ctx and every object
+// created in it, without waiting for the garbage collector. Any use
+// of ctx or its objects after Close panics, except for releasing
+// them, which does nothing. Close waits for any in-flight operation
+// in ctx to finish, so call Interrupt first to stop it promptly.
+//
+// Calling Close is optional; ctx is also released when it becomes
+// unreachable.
+func (ctx *Context) Close() {
+	ctx.checkNotInCallback("Close")
+	ctx.lock.Lock()
+	defer ctx.lock.Unlock()
+	ctx.interruptLock.Lock()
+	defer ctx.interruptLock.Unlock()
+	if ctx.closed {
+		return
+	}
+	ctx.closed = true
+	ctx.leaks = nil
+	ctx.pending = nil
+	runtime.SetFinalizer(ctx.contextImpl, nil)
+	setErrorHandler(ctx.c, nil)
+	C.Z3_del_context(ctx.c)
+}
+
+// Interrupt stops the current solver, simplifier, tactic, or
+// fixedpoint query being executed by ctx. The interrupted operation
+// fails as if it had reached a resource limit; for example,
+// Solver.Check returns an *ErrSatUnknown whose reason is
+// "canceled".
+//
+// Since ctx executes one operation at a time, this aborts all
+// in-flight work in ctx. Interrupt does not block and may be called
+// from any goroutine. It has no effect on operations started after
+// it returns, so callers shutting down a Context should also stop
+// issuing new work.
 func (ctx *Context) Interrupt() {
-	C.Z3_interrupt(ctx.c)
+	ctx.interruptLock.Lock()
+	defer ctx.interruptLock.Unlock()
+	if !ctx.closed {
+		C.Z3_interrupt(ctx.c)
+	}
 	runtime.KeepAlive(ctx)
 }
 
+// EnableConcurrentDecRef allows ASTs (including Values, Sorts, and
+// FuncDecls) in ctx to be released while another operation, such as
+// Solver.Check, is running in ctx.
+//
+// Normally, the finalizers that release unreachable ASTs must wait
+// for the operation in progress to finish, which stalls all
+// finalizers in the program during a long-running operation. With
+// concurrent dec-ref enabled, Z3 queues these releases and applies
+// them at a safe point instead. This cannot be disabled once
+// enabled.
+func (ctx *Context) EnableConcurrentDecRef() {
+	ctx.checkNotInCallback("EnableConcurrentDecRef")
+	ctx.lock.Lock()
+	defer ctx.lock.Unlock()
+	ctx.interruptLock.Lock()
+	defer ctx.interruptLock.Unlock()
+	if ctx.closed || ctx.concurrentDecRef {
+		return
+	}
+	C.Z3_enable_concurrent_dec_ref(ctx.c)
+	ctx.concurrentDecRef = true
+}
+
+// DisableLocking declares that ctx, and every object created in it,
+// will only be used by one goroutine at a time, and turns off the
+// lock ctx uses to serialize operations.
+//
+// By default, every operation on a Context acquires a lock, since a
+// Z3 context cannot be used by multiple threads at once. This has a
+// cost even if there is no contention, and encoders that build many
+// small expressions may spend significant time on it. A program that
+// wants parallelism should create one Context per goroutine (see
+// Pool and AST.Translate) and disable locking in each.
+//
+// The garbage collector still releases unreachable objects from
+// other goroutines, so with locking disabled ctx queues these
+// releases and performs them during the next operation in ctx. This
+// includes explicit releases, such as AST.Release.
+//
+// DisableLocking must be called before ctx is shared with other
+// goroutines, and cannot be undone. Interrupt may still be called from
+// any goroutine.
+func (ctx *Context) DisableLocking() {
+	ctx.checkNotInCallback("DisableLocking")
+	ctx.lock.Lock()
+	defer ctx.lock.Unlock()
+	ctx.interruptLock.Lock()
+	defer ctx.interruptLock.Unlock()
+	ctx.unlocked = true
+}
+
 // Extra returns the "extra" data associated with key, or nil if there
 // is no data associated with key.
 func (ctx *Context) Extra(key interface{}) interface{} {
-	ctx.lock.Lock()
-	defer ctx.lock.Unlock()
-	return ctx.extra[key]
+	var v interface{}
+	ctx.withLock(func() {
+		v = ctx.extra[key]
+	})
+	return v
 }
 
 // SetExtra associates key with value in ctx's "extra" data. This can
 // be used by other packages to associate other data with ctx, such as
 // caches. key must support comparison.
 func (ctx *Context) SetExtra(key, value interface{}) {
-	ctx.lock.Lock()
-	defer ctx.lock.Unlock()
-	if value == nil {
-		if ctx.extra != nil {
-			delete(ctx.extra, key)
-		}
-	} else {
-		if ctx.extra == nil {
-			ctx.extra = make(map[interface{}]interface{})
+	ctx.withLock(func() {
+		if value == nil {
+			if ctx.extra != nil {
+				delete(ctx.extra, key)
+			}
+		} else {
+			if ctx.extra == nil {
+				ctx.extra = make(map[interface{}]interface{})
+			}
+			ctx.extra[key] = value
 		}
-		ctx.extra[key] = value
-	}
+	})
 }
 
-// do calls f with a per-context lock held.
+// do calls f with a per-context lock held, unless locking is
+// disabled (see DisableLocking). If ctx is a callback handle whose
+// callback is running, the lock is already held by the operation
+// that invoked the callback.
 //
 // Unfortunately, we can't just say that Contexts are not thread-safe
 // because we can't help but run finalizers asynchronously, which
 // means we need to synchronize both reference counts and the
 // per-context last error state.
 func (ctx *Context) do(f func()) {
+	if ctx.unlocked {
+		if ctx.closed {
+			panic("z3: use of closed Context")
+		}
+		ctx.flushReleases()
+		f()
+		return
+	}
+	if !ctx.inCallback() {
+		ctx.lock.Lock()
+		defer ctx.lock.Unlock()
+	}
+	if ctx.closed {
+		panic("z3: use of closed Context")
+	}
+	f()
+}
+
+// withLock calls f with ctx's lock held. If ctx is a callback handle
+// whose callback is running, the lock is already held and withLock
+// calls f directly.
+func (ctx *Context) withLock(f func()) {
+	if !ctx.inCallback() {
+		ctx.lock.Lock()
+		defer ctx.lock.Unlock()
+	}
+	f()
+}
+
+// callbackHandle returns the Context passed to callbacks from Z3 in
+// ctx. The handle shares ctx's Z3 context, but while a callback is
+// running, its operations skip acquiring the lock, which is held by
+// the operation that invoked the callback. This must be called with
+// ctx's lock held.
+func (ctx *Context) callbackHandle() *Context {
+	h := ctx.handle
+	if h == nil {
+		h = ctx.derive()
+		h.arena = ctx.arena
+		h.parent = ctx
+		ctx.handle = h
+	}
+	if h.roundingMode != ctx.roundingMode {
+		h.roundingMode, h.roundingModeAST = ctx.roundingMode, value{}
+	}
+	h.printMode = ctx.printMode
+	return h
+}
+
+// derive returns a new Context that shares ctx's Z3 context and
+// settings, but has its own caches. This must be called with ctx's
+// lock held.
+func (ctx *Context) derive() *Context {
+	return &Context{
+		contextImpl:  ctx.contextImpl,
+		syms:         ctx.syms,
+		roundingMode: ctx.roundingMode,
+		printMode:    ctx.printMode,
+	}
+}
+
+// inCallback reports whether ctx is a callback handle whose callback
+// is running.
+func (ctx *Context) inCallback() bool {
+	return ctx.parent != nil && atomic.LoadInt32(&ctx.active) != 0
+}
+
+// checkNotInCallback panics if ctx is a callback handle whose
+// callback is running. op must not be called from a callback because
+// it would invalidate the operation that invoked it.
+func (ctx *Context) checkNotInCallback(op string) {
+	if ctx.inCallback() {
+		panic("z3: " + op + " called from a callback")
+	}
+}
+
+// flushReleases performs the releases queued while locking is
+// disabled or a callback was running. This must only be called by
+// the goroutine using ctx, or with ctx's lock held.
+func (ctx *Context) flushReleases() {
+	if atomic.LoadInt32(&ctx.npending) == 0 {
+		return
+	}
+	ctx.interruptLock.Lock()
+	pending := ctx.pending
+	ctx.pending = nil
+	atomic.StoreInt32(&ctx.npending, 0)
+	ctx.interruptLock.Unlock()
+	for _, f := range pending {
+		f()
+	}
+}
+
+// release is like do, but is used to release a Z3 object. If ctx has
+// been closed, the object is already gone and release does nothing.
+//
+// If locking is disabled, release instead queues f to be run by the
+// next operation. Likewise, if ctx is a callback handle whose
+// callback is running, release may be called from a finalizer, which
+// must not run f concurrently with the callback, so it queues f to be
+// run when the callback returns.
+func (ctx *Context) release(f func()) {
+	if ctx.inCallback() {
+		ctx.interruptLock.Lock()
+		defer ctx.interruptLock.Unlock()
+		if !ctx.closed {
+			ctx.pending = append(ctx.pending, f)
+			atomic.StoreInt32(&ctx.npending, 1)
+		}
+		return
+	}
 	ctx.lock.Lock()
 	defer ctx.lock.Unlock()
+	if ctx.closed {
+		return
+	}
+	if ctx.unlocked {
+		ctx.interruptLock.Lock()
+		defer ctx.interruptLock.Unlock()
+		ctx.pending = append(ctx.pending, f)
+		atomic.StoreInt32(&ctx.npending, 1)
+		return
+	}
+	f()
+}
+
+// releaseAST is like release, but is used to release an AST. If
+// concurrent dec-ref is enabled, it does not wait for the operation
+// in progress, if any.
+func (ctx *Context) releaseAST(f func()) {
+	ctx.interruptLock.Lock()
+	if ctx.concurrentDecRef && !ctx.unlocked {
+		defer ctx.interruptLock.Unlock()
+		if !ctx.closed {
+			f()
+		}
+		return
+	}
+	// concurrentDecRef cannot become false, so it's safe to drop
+	// interruptLock. release handles the case that locking is
+	// disabled.
+	ctx.interruptLock.Unlock()
+	ctx.release(f)
+}
+
+// doWith is like do, but holds the locks of both ctx and other. This
+// is necessary for operations like Z3_translate that access two
+// contexts. Locks are acquired in a fixed order so concurrent
+// operations in opposite directions cannot deadlock.
+func (ctx *Context) doWith(other *Context, f func()) {
+	if ctx.contextImpl == other.contextImpl {
+		if other.inCallback() {
+			ctx = other
+		}
+		ctx.do(f)
+		return
+	}
+	a, b := ctx, other
+	if uintptr(unsafe.Pointer(a.c)) > uintptr(unsafe.Pointer(b.c)) {
+		a, b = b, a
+	}
+	for _, c := range []*Context{a, b} {
+		if !c.unlocked && !c.inCallback() {
+			c.lock.Lock()
+			defer c.lock.Unlock()
+		}
+		if c.closed {
+			panic("z3: use of closed Context")
+		}
+		if c.unlocked {
+			c.flushReleases()
+		}
+	}
 	f()
 }
 
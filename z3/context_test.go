@@ -7,7 +7,12 @@ package z3
 import (
 	"fmt"
 	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func expectPanic(t *testing.T, pattern string, f func()) {
@@ -32,3 +37,257 @@ func TestErrorHandling(t *testing.T) {
 	y := ctx.BVConst("y", 2)
 	expectPanic(t, "are incompatible", func() { x.Eq(y) })
 }
+
+func TestPrintMode(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.IntConst("x")
+	y := x.Add(x)
+	e := y.Mul(y)
+
+	full := e.String()
+	if old := ctx.SetPrintMode(PrintLowLevel); old != PrintSMTLIB2Full {
+		t.Errorf("default print mode is %v, want PrintSMTLIB2Full", old)
+	}
+	if low := e.String(); low == full {
+		t.Errorf("low-level and full print modes both gave %s", full)
+	}
+	ctx.SetPrintMode(PrintSMTLIB2Compliant)
+	src := "(declare-const x Int) (assert (> " + e.String() + " 0))"
+	if _, err := ctx.ParseSMTLIB2(src, nil, nil); err != nil {
+		t.Errorf("compliant print mode gave unparseable %s: %v", e, err)
+	}
+	if old := ctx.SetPrintMode(PrintSMTLIB2Full); old != PrintSMTLIB2Compliant {
+		t.Errorf("SetPrintMode returned %v, want PrintSMTLIB2Compliant", old)
+	}
+	if got := e.String(); got != full {
+		t.Errorf("after restoring print mode, got %s, want %s", got, full)
+	}
+}
+
+func TestNewContextWith(t *testing.T) {
+	ctx, err := NewContextWith(WithModel(true), WithProof(true), WithTimeout(1500*time.Microsecond), WithAutoConfig(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	x := ctx.BoolConst("x")
+	s := NewSolver(ctx)
+	s.Assert(x.And(x.Not()))
+	if sat, err := s.Check(); err != nil || sat {
+		t.Fatalf("want unsat, got %v, %v", sat, err)
+	}
+	// Proofs are only available if WithProof took effect.
+	if c := s.Proof().Conclusion(); !c.AsAST().Equal(ctx.FromBool(false).AsAST()) {
+		t.Errorf("proof concludes %v, want false", c)
+	}
+
+	for i, opts := range [][]ContextOption{
+		{WithParam("no_such_param", true)},
+		{WithParam("proof", uint(1))},
+		{WithParam("timeout", "10")},
+	} {
+		if _, err := NewContextWith(opts...); err == nil {
+			t.Errorf("invalid options %d accepted", i)
+		}
+	}
+	if _, err := NewContextWith(WithParam("trace_file_name", "z3.log")); err != nil {
+		t.Errorf("valid string parameter rejected: %v", err)
+	}
+}
+
+func TestTry(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.BVConst("x", 1)
+	y := ctx.BVConst("y", 2)
+
+	var res Bool
+	err := Try(func() { res = x.Eq(y) })
+	zerr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("want *Error, got %#v", err)
+	}
+	if !strings.Contains(zerr.Msg, "incompatible") {
+		t.Errorf("got error %v (%v), want incompatible sort error", zerr, zerr.Code)
+	}
+	if res.valueImpl != nil {
+		t.Errorf("failed operation produced %v", res)
+	}
+
+	if err := Try(func() { res = x.Eq(x) }); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	expectPanic(t, "not a Z3 error", func() {
+		Try(func() { panic("not a Z3 error") })
+	})
+}
+
+func TestOnError(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.BVConst("x", 1)
+	y := ctx.BVConst("y", 2)
+
+	var logged []string
+	ctx.OnError(func(code ErrorCode, msg string) {
+		logged = append(logged, msg)
+	})
+	err := Try(func() { x.Eq(y) })
+	if err == nil || len(logged) != 1 || logged[0] != err.Error() {
+		t.Errorf("got error %v, logged %q; want the error logged once", err, logged)
+	}
+
+	// Handlers can translate errors by panicking.
+	type myError struct{ msg string }
+	ctx.OnError(func(code ErrorCode, msg string) {
+		panic(myError{msg})
+	})
+	func() {
+		defer func() {
+			if _, ok := recover().(myError); !ok {
+				t.Errorf("error was not translated")
+			}
+		}()
+		x.Eq(y)
+	}()
+
+	ctx.OnError(nil)
+	expectPanic(t, "are incompatible", func() { x.Eq(y) })
+	if len(logged) != 1 {
+		t.Errorf("removed handler was called")
+	}
+}
+
+func TestInterrupt(t *testing.T) {
+	ctx := NewContext(nil)
+	s := NewSolver(ctx)
+	// Eleven pigeons do not fit in ten holes, but this takes the
+	// solver a long time to prove.
+	const holes = 10
+	var p [holes + 1][holes]Bool
+	for i := range p {
+		for j := range p[i] {
+			p[i][j] = ctx.BoolConst(fmt.Sprintf("p%d_%d", i, j))
+		}
+		s.Assert(p[i][0].Or(p[i][1:]...))
+	}
+	for j := 0; j < holes; j++ {
+		for i := range p {
+			for k := i + 1; k < len(p); k++ {
+				s.Assert(p[i][j].And(p[k][j]).Not())
+			}
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		// Interrupts that arrive before Check starts are lost,
+		// so keep interrupting until it returns.
+		for {
+			select {
+			case <-done:
+				return
+			case <-time.After(10 * time.Millisecond):
+				ctx.Interrupt()
+			}
+		}
+	}()
+	sat, err := s.Check()
+	close(done)
+	if _, ok := err.(*ErrSatUnknown); !ok {
+		t.Errorf("Check() = %v, %v, want *ErrSatUnknown", sat, err)
+	}
+}
+
+func TestClose(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	s := NewSolver(ctx)
+	s.Assert(x.Eq(ctx.FromInt(3, ctx.IntSort()).(Int)))
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("Check() = %v, %v, want sat", sat, err)
+	}
+	m := s.Model()
+	v := m.Eval(x, true)
+	m.Close()
+	m.Close()
+	s.Close()
+	s.Close()
+	if got := v.String(); got != "3" {
+		t.Errorf("value from closed model is %s, want 3", got)
+	}
+	y.Release()
+	y.Release()
+
+	ctx.Close()
+	expectPanic(t, "closed Context", func() { ctx.IntConst("z") })
+	expectPanic(t, "closed Context", func() { _ = x.String() })
+	ctx.Close()
+	ctx.Interrupt()
+	// Finalizers of temporaries created above must not touch the
+	// closed Context.
+	runtime.GC()
+	runtime.GC()
+}
+
+func TestConcurrentDecRef(t *testing.T) {
+	ctx := NewContext(nil)
+	ctx.EnableConcurrentDecRef()
+	ctx.TrackASTs(true)
+	for i := 0; i < 100; i++ {
+		ctx.IntConst(fmt.Sprint("x", i))
+	}
+	live := func() int {
+		t := ctx.leaks
+		t.Lock()
+		defer t.Unlock()
+		return len(t.live)
+	}
+
+	// Hold ctx's lock, as a long-running Check would. Finalizers
+	// must still be able to release the garbage.
+	ctx.lock.Lock()
+	deadline := time.Now().Add(10 * time.Second)
+	for live() > 0 && time.Now().Before(deadline) {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+	n := live()
+	ctx.lock.Unlock()
+	if n > 0 {
+		t.Errorf("%d ASTs not released while ctx was busy", n)
+	}
+}
+
+func TestDisableLocking(t *testing.T) {
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := NewContext(nil)
+			ctx.DisableLocking()
+			ctx.TrackASTs(true)
+			for i := 0; i < 100; i++ {
+				ctx.IntConst(fmt.Sprint("x", i))
+			}
+			// Finalizers queue their releases rather than
+			// using ctx.
+			for i := 0; i < 100 && atomic.LoadInt32(&ctx.npending) == 0; i++ {
+				runtime.GC()
+				time.Sleep(time.Millisecond)
+			}
+			x := ctx.IntConst("x")
+			s := NewSolver(ctx)
+			s.Assert(x.Mul(x).Eq(ctx.FromInt(49, ctx.IntSort()).(Int)))
+			if sat, err := s.Check(); !sat || err != nil {
+				t.Errorf("Check() = %v, %v, want sat", sat, err)
+			}
+			if got := s.Model().Eval(x, true).String(); got != "7" && got != "(- 7)" {
+				t.Errorf("x = %s, want ±7", got)
+			}
+			if sites := ctx.LeakSites(0); len(sites) > 0 && sites[0].Count >= 100 {
+				t.Errorf("queued releases not performed: %v", sites[0])
+			}
+		}()
+	}
+	wg.Wait()
+}
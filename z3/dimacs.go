@@ -0,0 +1,102 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// FromDIMACS reads a SAT problem in DIMACS CNF format from r and
+// asserts each of its clauses in s.
+//
+// It returns the problem's variables, where vars[i] is DIMACS
+// variable i+1. These are ordinary Bool constants named by their
+// DIMACS number (for example, "12"), so they can be combined with
+// other constraints in s. Variables are created as clauses use them,
+// so vars extends only to the largest variable that appears in a
+// clause. Literals must not exceed the variable count declared by
+// the problem line.
+func (s *Solver) FromDIMACS(r io.Reader) (vars []Bool, err error) {
+	ctx := s.ctx
+	getVar := func(n int) Bool {
+		for len(vars) < n {
+			vars = append(vars, ctx.BoolConst(strconv.Itoa(len(vars)+1)))
+		}
+		return vars[n-1]
+	}
+
+	sc := bufio.NewScanner(r)
+	var clause []Bool
+	sawHeader, nvars := false, 0
+	lineno := 0
+	for sc.Scan() {
+		lineno++
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || line[0] == 'c' {
+			continue
+		}
+		if line[0] == '%' {
+			// Some SATLIB benchmarks end with a "%" line.
+			break
+		}
+		if line[0] == 'p' {
+			f := strings.Fields(line)
+			if sawHeader || len(f) != 4 || f[1] != "cnf" {
+				return nil, fmt.Errorf("line %d: bad problem line %q", lineno, line)
+			}
+			n, err := strconv.Atoi(f[2])
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("line %d: bad variable count %q", lineno, f[2])
+			}
+			nvars = n
+			sawHeader = true
+			continue
+		}
+		if !sawHeader {
+			return nil, fmt.Errorf("line %d: clause before problem line", lineno)
+		}
+		for _, tok := range strings.Fields(line) {
+			lit, err := strconv.Atoi(tok)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: bad literal %q", lineno, tok)
+			}
+			v := lit
+			if v < 0 {
+				v = -v
+			}
+			if v < 0 || v > nvars {
+				return nil, fmt.Errorf("line %d: literal %d exceeds variable count %d", lineno, lit, nvars)
+			}
+			switch {
+			case lit == 0:
+				if len(clause) == 0 {
+					s.Assert(ctx.FromBool(false))
+				} else {
+					s.Assert(clause[0].Or(clause[1:]...))
+				}
+				clause = clause[:0]
+			case lit > 0:
+				clause = append(clause, getVar(lit))
+			default:
+				clause = append(clause, getVar(-lit).Not())
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if !sawHeader {
+		return nil, fmt.Errorf("missing problem line")
+	}
+	if len(clause) > 0 {
+		// Tolerate a missing 0 after the last clause.
+		s.Assert(clause[0].Or(clause[1:]...))
+	}
+	return vars, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFromDIMACS(t *testing.T) {
+	const cnf = `c A small example.
+p cnf 3 3
+1 -2 0
+2 3
+0
+-1 -3 0
+`
+	ctx := NewContext(nil)
+	s := NewSolver(ctx)
+	vars, err := s.FromDIMACS(strings.NewReader(cnf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vars) != 3 {
+		t.Fatalf("got %d variables, want 3", len(vars))
+	}
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("Check() = %v, %v, want sat", sat, err)
+	}
+
+	// Combine with additional constraints. 2 forces 1, which
+	// forces not 3, which contradicts not 2 or 3.
+	s.Push()
+	s.Assert(vars[2].Not())
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("Check() = %v, %v, want sat", sat, err)
+	}
+	m := s.Model()
+	for i, want := range []bool{true, true, false} {
+		got, _ := m.Eval(vars[i], true).(Bool).AsBool()
+		if got != want {
+			t.Errorf("variable %d = %v, want %v", i+1, got, want)
+		}
+	}
+	s.Pop()
+	s.Assert(ctx.BoolConst("1").Not())
+	s.Assert(vars[1])
+	if sat, err := s.Check(); sat || err != nil {
+		t.Errorf("Check() = %v, %v, want unsat", sat, err)
+	}
+
+	for _, bad := range []string{
+		"1 2 0\n",
+		"p cnf x 1\n",
+		"p cnf 2 1\n1 foo 0\n",
+		"",
+	} {
+		if _, err := NewSolver(ctx).FromDIMACS(strings.NewReader(bad)); err == nil {
+			t.Errorf("FromDIMACS(%q) succeeded", bad)
+		}
+	}
+
+	// A large declared count does not create unused variables.
+	vars, err = NewSolver(ctx).FromDIMACS(strings.NewReader("p cnf 2000000000 1\n1 -2 0\n"))
+	if err != nil || len(vars) != 2 {
+		t.Errorf("FromDIMACS with large header: got %d variables, %v; want 2, nil", len(vars), err)
+	}
+
+	bad := "p cnf 2 2\n1 2 0\n-3 1 0\n"
+	_, err = NewSolver(ctx).FromDIMACS(strings.NewReader(bad))
+	if want := "line 3: literal -3 exceeds variable count 2"; err == nil || err.Error() != want {
+		t.Errorf("FromDIMACS(%q) = %v, want %s", bad, err, want)
+	}
+}
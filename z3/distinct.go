@@ -0,0 +1,145 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"runtime"
+)
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+#include <stdlib.h>
+
+// z3goDistinctBlocks splits vals into blocks of k values and returns
+// the conjunction of Z3_mk_distinct over every pair of blocks. If k
+// is 0, it instead returns the conjunction of x != y for every pair
+// of values.
+static Z3_ast z3goDistinctBlocks(Z3_context c, unsigned n, Z3_ast *vals, unsigned k) {
+	unsigned bs = k == 0 ? 1 : k;
+	unsigned nb = (n + bs - 1) / bs;
+	Z3_ast *terms = malloc((nb * (nb + 1) / 2 + 1) * sizeof(Z3_ast));
+	Z3_ast *buf = malloc(2 * bs * sizeof(Z3_ast));
+	unsigned i, j, x, m, t = 0;
+	Z3_ast term, res;
+
+	for (i = 0; i < nb; i++) {
+		for (j = i; j < nb; j++) {
+			m = 0;
+			for (x = i * bs; x < n && x < (i + 1) * bs; x++)
+				buf[m++] = vals[x];
+			if (j != i) {
+				for (x = j * bs; x < n && x < (j + 1) * bs; x++)
+					buf[m++] = vals[x];
+			}
+			if (m < 2)
+				continue;
+			if (k == 0) {
+				Z3_ast eq = Z3_mk_eq(c, buf[0], buf[1]);
+				Z3_inc_ref(c, eq);
+				term = Z3_mk_not(c, eq);
+				Z3_inc_ref(c, term);
+				Z3_dec_ref(c, eq);
+			} else {
+				term = Z3_mk_distinct(c, m, buf);
+				Z3_inc_ref(c, term);
+			}
+			terms[t++] = term;
+		}
+	}
+	if (t == 0)
+		res = Z3_mk_true(c);
+	else
+		res = Z3_mk_and(c, t, terms);
+	// res holds references to terms, so it's safe to drop ours.
+	for (i = 0; i < t; i++)
+		Z3_dec_ref(c, terms[i]);
+	free(terms);
+	free(buf);
+	return res;
+}
+*/
+import "C"
+
+// DistinctEncoding selects how DistinctWith encodes an all-different
+// constraint. Which encoding solves fastest depends heavily on the
+// problem, particularly for large sets of values.
+type DistinctEncoding int
+
+const (
+	// DistinctNative uses a single Z3 distinct term, like
+	// Context.Distinct.
+	DistinctNative DistinctEncoding = iota
+
+	// DistinctPairwise uses a conjunction of x != y for every
+	// pair of values. This produces O(n²) terms, but exposes each
+	// inequality directly to the solver.
+	DistinctPairwise
+
+	// DistinctChunked splits the values into chunks and uses a
+	// distinct term over each pair of chunks. This is a middle
+	// ground between DistinctNative and DistinctPairwise.
+	DistinctChunked
+)
+
+func (e DistinctEncoding) String() string {
+	switch e {
+	case DistinctNative:
+		return "native"
+	case DistinctPairwise:
+		return "pairwise"
+	case DistinctChunked:
+		return "chunked"
+	}
+	return fmt.Sprintf("DistinctEncoding(%d)", int(e))
+}
+
+// DistinctOptions configures DistinctWith.
+type DistinctOptions struct {
+	// Encoding is the encoding of the constraint.
+	Encoding DistinctEncoding
+
+	// ChunkSize is the number of values per chunk for
+	// DistinctChunked. If 0, it defaults to 16.
+	ChunkSize int
+}
+
+// DistinctWith returns a Bool that is true if no two vals are equal,
+// using the encoding selected by opts. Every encoding is logically
+// equivalent to Distinct. If there are fewer than two vals, the
+// result is true.
+//
+// All vals must have the same sort.
+func (ctx *Context) DistinctWith(opts DistinctOptions, vals ...Value) Bool {
+	var k int
+	switch opts.Encoding {
+	case DistinctNative:
+		return ctx.DistinctN(vals...)
+	case DistinctPairwise:
+		k = 0
+	case DistinctChunked:
+		k = opts.ChunkSize
+		if k == 0 {
+			k = 16
+		} else if k < 0 {
+			panic("z3: negative Distinct chunk size")
+		}
+	default:
+		panic(fmt.Sprintf("z3: unknown %v", opts.Encoding))
+	}
+	if len(vals) < 2 {
+		return ctx.FromBool(true)
+	}
+	cargs := make([]C.Z3_ast, len(vals))
+	for i, v := range vals {
+		cargs[i] = v.impl().c
+	}
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.z3goDistinctBlocks(ctx.c, C.uint(len(cargs)), &cargs[0], C.uint(k))
+	})
+	runtime.KeepAlive(vals)
+	return Bool(val)
+}
@@ -0,0 +1,58 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDistinctWith(t *testing.T) {
+	ctx := NewContext(nil)
+	const n = 10
+	vals := make([]Value, n)
+	for i := range vals {
+		vals[i] = ctx.IntConst(fmt.Sprintf("x%d", i))
+	}
+	zero, top := ctx.FromInt(0, ctx.IntSort()).(Int), ctx.FromInt(n, ctx.IntSort()).(Int)
+
+	for _, opts := range []DistinctOptions{
+		{Encoding: DistinctNative},
+		{Encoding: DistinctPairwise},
+		{Encoding: DistinctChunked},
+		{Encoding: DistinctChunked, ChunkSize: 3},
+		{Encoding: DistinctChunked, ChunkSize: 1},
+	} {
+		t.Run(fmt.Sprintf("%v/%d", opts.Encoding, opts.ChunkSize), func(t *testing.T) {
+			d := ctx.DistinctWith(opts, vals...)
+
+			// Must be equivalent to Distinct.
+			s := NewSolver(ctx)
+			s.Assert(d.Xor(ctx.Distinct(vals...)))
+			if sat, err := s.Check(); sat || err != nil {
+				t.Fatalf("not equivalent to Distinct: %v, %v", sat, err)
+			}
+
+			// The values can all differ, but not if two of
+			// them must be equal.
+			s = NewSolver(ctx)
+			s.Assert(d)
+			for _, x := range vals {
+				s.Assert(x.(Int).GE(zero).And(x.(Int).LT(top)))
+			}
+			if sat, err := s.Check(); !sat || err != nil {
+				t.Fatalf("want sat, got %v, %v", sat, err)
+			}
+			s.Assert(vals[1].(Int).Eq(vals[n-1].(Int)))
+			if sat, err := s.Check(); sat || err != nil {
+				t.Fatalf("want unsat, got %v, %v", sat, err)
+			}
+		})
+	}
+
+	if got := ctx.DistinctWith(DistinctOptions{Encoding: DistinctPairwise}, vals[0]).String(); got != "true" {
+		t.Errorf("pairwise Distinct of one value = %s, want true", got)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// DOTOptions controls the output of WriteDOT.
+type DOTOptions struct {
+	// ShowSorts includes the sort of each subexpression in its
+	// label.
+	ShowSorts bool
+
+	// MaxNodes limits the size of the graph. If it is non-zero,
+	// WriteDOT stops expanding subexpressions once it has written
+	// this many nodes. Nodes whose arguments were omitted are
+	// drawn with a dashed outline.
+	MaxNodes int
+}
+
+// WriteDOT writes the DAG of expression x to w in Graphviz DOT
+// format. Each distinct subexpression appears as a single node, so
+// shared subexpressions have several incoming edges. opts may be
+// nil.
+//
+// Like Walk, WriteDOT treats quantifiers and bound variables as
+// leaves.
+func WriteDOT(w io.Writer, x Value, opts *DOTOptions) error {
+	if opts == nil {
+		opts = &DOTOptions{}
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "digraph expr {\n")
+	fmt.Fprintf(bw, "\tnode [shape=box];\n")
+	nodes := 0
+	Walk(x, func(v Value) bool {
+		id := v.AsAST().ID()
+		n := v.NumArgs()
+		label := v.String()
+		if n > 0 {
+			label = v.Decl().Name()
+		}
+		if opts.ShowSorts {
+			label += "\n" + v.Sort().String()
+		}
+		nodes++
+		truncated := opts.MaxNodes > 0 && nodes >= opts.MaxNodes && n > 0
+		style := ""
+		if truncated {
+			style = ", style=dashed"
+		}
+		fmt.Fprintf(bw, "\tn%d [label=%s%s];\n", id, strconv.Quote(label), style)
+		if truncated {
+			return false
+		}
+		for i := 0; i < n; i++ {
+			attrs := ""
+			if n > 1 {
+				attrs = fmt.Sprintf(" [label=%d]", i)
+			}
+			fmt.Fprintf(bw, "\tn%d -> n%d%s;\n", id, v.Arg(i).AsAST().ID(), attrs)
+		}
+		return true
+	})
+	fmt.Fprintf(bw, "}\n")
+	return bw.Flush()
+}
@@ -0,0 +1,46 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestWriteDOT(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	sum := x.Add(y)
+	e := sum.Mul(sum)
+
+	var buf bytes.Buffer
+	if err := WriteDOT(&buf, e, &DOTOptions{ShowSorts: true}); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.HasPrefix(out, "digraph expr {\n") || !strings.HasSuffix(out, "}\n") {
+		t.Errorf("malformed graph:\n%s", out)
+	}
+	// 4 distinct nodes: *, +, x, y.
+	if n := strings.Count(out, "[label=\""); n != 4 {
+		t.Errorf("got %d nodes, want 4:\n%s", n, out)
+	}
+	// The shared sum has two incoming edges.
+	sumID := sum.AsAST().ID()
+	if n := strings.Count(out, fmt.Sprintf("-> n%d ", sumID)); n != 2 {
+		t.Errorf("sum has %d incoming edges, want 2:\n%s", n, out)
+	}
+	if !strings.Contains(out, `"x\nInt"`) {
+		t.Errorf("missing label for x with sort:\n%s", out)
+	}
+
+	buf.Reset()
+	WriteDOT(&buf, e, &DOTOptions{MaxNodes: 1})
+	if out := buf.String(); !strings.Contains(out, "style=dashed") || strings.Contains(out, "->") {
+		t.Errorf("MaxNodes not respected:\n%s", out)
+	}
+}
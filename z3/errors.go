@@ -0,0 +1,140 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"strconv"
+	"sync"
+)
+
+/*
+#include <z3.h>
+*/
+import "C"
+
+// ErrorCode is a category of Z3 error.
+type ErrorCode int
+
+const (
+	ErrorSort           = ErrorCode(C.Z3_SORT_ERROR)        // Ill-sorted AST
+	ErrorIOB            = ErrorCode(C.Z3_IOB)               // Index out of bounds
+	ErrorInvalidArg     = ErrorCode(C.Z3_INVALID_ARG)       // Invalid argument
+	ErrorParser         = ErrorCode(C.Z3_PARSER_ERROR)      // Error parsing a string or file
+	ErrorNoParser       = ErrorCode(C.Z3_NO_PARSER)         // Parser output is not available
+	ErrorInvalidPattern = ErrorCode(C.Z3_INVALID_PATTERN)   // Invalid quantifier pattern
+	ErrorMemout         = ErrorCode(C.Z3_MEMOUT_FAIL)       // Memory allocation failure
+	ErrorFileAccess     = ErrorCode(C.Z3_FILE_ACCESS_ERROR) // File could not be accessed
+	ErrorInternalFatal  = ErrorCode(C.Z3_INTERNAL_FATAL)    // Internal Z3 error
+	ErrorInvalidUsage   = ErrorCode(C.Z3_INVALID_USAGE)     // Operation invalid in the current state
+	ErrorDecRef         = ErrorCode(C.Z3_DEC_REF_ERROR)     // Bad reference count
+	ErrorException      = ErrorCode(C.Z3_EXCEPTION)         // Other Z3 exception
+)
+
+// String returns c as a string like "ErrorSort".
+func (c ErrorCode) String() string {
+	switch c {
+	case ErrorSort:
+		return "ErrorSort"
+	case ErrorIOB:
+		return "ErrorIOB"
+	case ErrorInvalidArg:
+		return "ErrorInvalidArg"
+	case ErrorParser:
+		return "ErrorParser"
+	case ErrorNoParser:
+		return "ErrorNoParser"
+	case ErrorInvalidPattern:
+		return "ErrorInvalidPattern"
+	case ErrorMemout:
+		return "ErrorMemout"
+	case ErrorFileAccess:
+		return "ErrorFileAccess"
+	case ErrorInternalFatal:
+		return "ErrorInternalFatal"
+	case ErrorInvalidUsage:
+		return "ErrorInvalidUsage"
+	case ErrorDecRef:
+		return "ErrorDecRef"
+	case ErrorException:
+		return "ErrorException"
+	}
+	return "ErrorCode(" + strconv.Itoa(int(c)) + ")"
+}
+
+// Error is an error reported by Z3, such as applying an operation to
+// values of the wrong sort.
+//
+// By default, Z3 errors cause a panic with an *Error value. Use Try
+// to receive them as errors instead.
+type Error struct {
+	Code ErrorCode
+	Msg  string
+}
+
+func (e *Error) Error() string {
+	return e.Msg
+}
+
+// Try calls f and returns the first Z3 error raised by an operation
+// in f as an *Error, rather than panicking. Operations in f after the
+// failing one are not performed. Panics other than Z3 errors are not
+// recovered.
+//
+// Try makes it possible to build values from untrusted input, such
+// as terms of unknown sorts, without crashing the program:
+//
+//	err := z3.Try(func() {
+//		sum = x.Add(y)
+//	})
+func Try(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			zerr, ok := r.(*Error)
+			if !ok {
+				panic(r)
+			}
+			err = zerr
+		}
+	}()
+	f()
+	return nil
+}
+
+// errorHandlers maps Z3 contexts to the handlers registered by
+// Context.OnError.
+var errorHandlers struct {
+	sync.Mutex
+	m map[C.Z3_context]func(ErrorCode, string)
+}
+
+// OnError registers f to be called when a Z3 operation in ctx fails,
+// before the failure panics with an *Error (or is returned by Try).
+// This lets an application log errors or translate them according
+// to its own policies: if f panics, its panic replaces the *Error.
+// If f is nil, OnError removes the handler.
+//
+// f is called with ctx's lock held, so it must not use ctx.
+func (ctx *Context) OnError(f func(code ErrorCode, msg string)) {
+	setErrorHandler(ctx.c, f)
+}
+
+func setErrorHandler(c C.Z3_context, f func(ErrorCode, string)) {
+	errorHandlers.Lock()
+	defer errorHandlers.Unlock()
+	if f == nil {
+		delete(errorHandlers.m, c)
+		return
+	}
+	if errorHandlers.m == nil {
+		errorHandlers.m = make(map[C.Z3_context]func(ErrorCode, string))
+	}
+	errorHandlers.m[c] = f
+}
+
+func errorHandler(c C.Z3_context) func(ErrorCode, string) {
+	errorHandlers.Lock()
+	defer errorHandlers.Unlock()
+	return errorHandlers.m[c]
+}
@@ -44,6 +44,34 @@ type Value interface {
 	// String returns an S-expression representation of this value.
 	String() string
 
+	// IsApp returns true if this value is a function application,
+	// including constants and numerals. Only applications have a
+	// Decl and arguments.
+	IsApp() bool
+
+	// Decl returns the function declaration of this application.
+	// It panics if this value is not an application.
+	Decl() FuncDecl
+
+	// NumArgs returns the number of arguments of this application,
+	// or 0 if this value is not an application.
+	NumArgs() int
+
+	// Arg returns the i'th argument of this application. It
+	// panics if this value is not an application or i is out of
+	// range.
+	Arg(i int) Value
+
+	// UpdateArgs returns a copy of this application with its
+	// arguments replaced by args. It panics if this value is not
+	// an application or len(args) != NumArgs().
+	UpdateArgs(args ...Value) Value
+
+	// Release drops this value's reference to the underlying Z3
+	// object now, rather than when it is garbage collected. The
+	// value must not be used after Release.
+	Release()
+
 	astKind() C.Z3_ast_kind
 	impl() *valueImpl
 }
@@ -136,13 +164,20 @@ func (ctx *Context) FromBigInt(val *big.Int, sort Sort) Value {
 // FromInt returns a literal whose value is val. sort must have kind
 // int, real, finite-domain, bit-vector, or float.
 func (ctx *Context) FromInt(val int64, sort Sort) Value {
-	if sort.Kind() == KindFloatingPoint {
+	switch kind := sort.Kind(); {
+	case kind == KindFloatingPoint:
 		return ctx.floatFromInt(val, sort)
+	case -1 <= val && val <= 1 && (kind == KindInt || kind == KindReal || kind == KindBV):
+		var sval value
+		ctx.do(func() {
+			sval = ctx.smallNumeral(val, sort)
+		})
+		return sval.lift(kind)
 	}
 	sval := wrapValue(ctx, func() C.Z3_ast {
 		// Z3_mk_int64 doesn't say real sorts are accepted,
 		// but the C++ bindings use it for reals.
-		return C.Z3_mk_int64(ctx.c, C.__int64(val), sort.c)
+		return C.Z3_mk_int64(ctx.c, C.int64_t(val), sort.c)
 	})
 	runtime.KeepAlive(sort)
 	return sval.lift(sort.Kind())
@@ -152,6 +187,17 @@ func (expr *valueImpl) impl() *valueImpl {
 	return expr
 }
 
+// cref returns expr's Z3 AST, or panics if expr has been released.
+// It must be called with the ctx.lock held.
+func (expr *valueImpl) cref() C.Z3_ast {
+	return (*astImpl)(expr).cref()
+}
+
+// do is like expr.ctx.do, but panics if expr has been released.
+func (expr *valueImpl) do(f func()) {
+	(*astImpl)(expr).do(f)
+}
+
 // Context returns the Context that created expr.
 func (expr *valueImpl) Context() *Context {
 	if expr == nil {
@@ -163,7 +209,7 @@ func (expr *valueImpl) Context() *Context {
 // String returns a string representation of expr.
 func (expr *valueImpl) String() string {
 	var res string
-	expr.ctx.do(func() {
+	expr.do(func() {
 		res = C.GoString(C.Z3_ast_to_string(expr.ctx.c, expr.c))
 	})
 	runtime.KeepAlive(expr)
@@ -177,10 +223,16 @@ func (expr *valueImpl) AsAST() AST {
 	return ast
 }
 
+// Release drops expr's reference to the underlying Z3 object. See
+// AST.Release.
+func (expr *valueImpl) Release() {
+	expr.AsAST().Release()
+}
+
 // Sort returns expr's sort.
 func (expr *valueImpl) Sort() Sort {
 	var sort Sort
-	expr.ctx.do(func() {
+	expr.do(func() {
 		sort = wrapSort(expr.ctx, C.Z3_get_sort(expr.ctx.c, expr.c), KindUnknown)
 	})
 	runtime.KeepAlive(expr)
@@ -189,7 +241,7 @@ func (expr *valueImpl) Sort() Sort {
 
 func (expr *valueImpl) astKind() C.Z3_ast_kind {
 	var ckind C.Z3_ast_kind
-	expr.ctx.do(func() {
+	expr.do(func() {
 		ckind = C.Z3_get_ast_kind(expr.ctx.c, expr.c)
 	})
 	runtime.KeepAlive(expr)
@@ -206,7 +258,7 @@ func (expr *valueImpl) asBigInt() (val *big.Int, isLiteral bool) {
 		return nil, false
 	}
 	var str string
-	expr.ctx.do(func() {
+	expr.do(func() {
 		cstr := C.Z3_get_numeral_string(expr.ctx.c, expr.c)
 		str = C.GoString(cstr)
 	})
@@ -226,8 +278,8 @@ func (expr *valueImpl) asInt64() (val int64, isLiteral, ok bool) {
 	if expr.astKind() != C.Z3_NUMERAL_AST {
 		return 0, false, false
 	}
-	var cval C.__int64
-	expr.ctx.do(func() {
+	var cval C.int64_t
+	expr.do(func() {
 		ok = z3ToBool(C.Z3_get_numeral_int64(expr.ctx.c, expr.c, &cval))
 	})
 	return int64(cval), true, ok
@@ -242,8 +294,8 @@ func (expr *valueImpl) asUint64() (val uint64, isLiteral, ok bool) {
 	if expr.astKind() != C.Z3_NUMERAL_AST {
 		return 0, false, false
 	}
-	var cval C.__uint64
-	expr.ctx.do(func() {
+	var cval C.uint64_t
+	expr.do(func() {
 		ok = z3ToBool(C.Z3_get_numeral_uint64(expr.ctx.c, expr.c, &cval))
 	})
 	return uint64(cval), true, ok
@@ -251,9 +303,91 @@ func (expr *valueImpl) asUint64() (val uint64, isLiteral, ok bool) {
 
 func (expr *valueImpl) isAppOf(k C.Z3_decl_kind) bool {
 	var res bool
-	expr.ctx.do(func() {
+	expr.do(func() {
 		res = z3ToBool(C.Z3_is_app(expr.ctx.c, expr.c)) && C.Z3_get_decl_kind(expr.ctx.c, C.Z3_get_app_decl(expr.ctx.c, C.Z3_to_app(expr.ctx.c, expr.c))) == k
 	})
 	runtime.KeepAlive(expr)
 	return res
 }
+
+// IsApp returns true if expr is a function application, including
+// constants and numerals.
+func (expr *valueImpl) IsApp() bool {
+	var res bool
+	expr.do(func() {
+		res = z3ToBool(C.Z3_is_app(expr.ctx.c, expr.c))
+	})
+	runtime.KeepAlive(expr)
+	return res
+}
+
+// Decl returns the function declaration of application expr.
+//
+// It panics if expr is not an application.
+func (expr *valueImpl) Decl() FuncDecl {
+	if !expr.IsApp() {
+		panic("value is not an application")
+	}
+	var decl FuncDecl
+	expr.do(func() {
+		capp := C.Z3_to_app(expr.ctx.c, expr.c)
+		decl = wrapFuncDecl(expr.ctx, C.Z3_get_app_decl(expr.ctx.c, capp))
+	})
+	runtime.KeepAlive(expr)
+	return decl
+}
+
+// NumArgs returns the number of arguments of application expr, or 0
+// if expr is not an application.
+func (expr *valueImpl) NumArgs() int {
+	var res int
+	expr.do(func() {
+		if !z3ToBool(C.Z3_is_app(expr.ctx.c, expr.c)) {
+			return
+		}
+		capp := C.Z3_to_app(expr.ctx.c, expr.c)
+		res = int(C.Z3_get_app_num_args(expr.ctx.c, capp))
+	})
+	runtime.KeepAlive(expr)
+	return res
+}
+
+// Arg returns the i'th argument of application expr.
+//
+// It panics if expr is not an application or i is out of range.
+func (expr *valueImpl) Arg(i int) Value {
+	if !expr.IsApp() {
+		panic("value is not an application")
+	}
+	if n := expr.NumArgs(); i < 0 || i >= n {
+		panic("argument index out of range")
+	}
+	val := wrapValue(expr.ctx, func() C.Z3_ast {
+		capp := C.Z3_to_app(expr.ctx.c, expr.c)
+		return C.Z3_get_app_arg(expr.ctx.c, capp, C.uint(i))
+	})
+	runtime.KeepAlive(expr)
+	return val.lift(KindUnknown)
+}
+
+// UpdateArgs returns a copy of application expr with its arguments
+// replaced by args.
+//
+// This is cheaper than rebuilding expr by applying its Decl to args.
+// It panics if expr is not an application or len(args) is not
+// expr.NumArgs().
+func (expr *valueImpl) UpdateArgs(args ...Value) Value {
+	if !expr.IsApp() {
+		panic("value is not an application")
+	}
+	if len(args) != expr.NumArgs() {
+		panic("wrong number of arguments")
+	}
+	cargs := valuesToC(args)
+	val := wrapValue(expr.ctx, func() C.Z3_ast {
+		return C.Z3_update_term(expr.ctx.c, expr.c, C.uint(len(cargs)), cptr(cargs))
+	})
+	runtime.KeepAlive(expr)
+	runtime.KeepAlive(args)
+	return val.lift(KindUnknown)
+}
@@ -0,0 +1,469 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"unsafe"
+)
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+#include <stdlib.h>
+*/
+import "C"
+
+// A Fixedpoint is a Z3 fixedpoint engine, which solves Datalog
+// programs and constrained Horn clauses.
+type Fixedpoint struct {
+	*fixedpointImpl
+	noEq
+}
+
+type fixedpointImpl struct {
+	ctx *Context
+	c   C.Z3_fixedpoint
+}
+
+// NewFixedpoint returns a new, empty fixedpoint engine.
+func NewFixedpoint(ctx *Context) *Fixedpoint {
+	var impl *fixedpointImpl
+	ctx.do(func() {
+		impl = &fixedpointImpl{ctx, C.Z3_mk_fixedpoint(ctx.c)}
+		C.Z3_fixedpoint_inc_ref(ctx.c, impl.c)
+	})
+	runtime.SetFinalizer(impl, func(impl *fixedpointImpl) {
+		impl.ctx.release(func() {
+			C.Z3_fixedpoint_dec_ref(impl.ctx.c, impl.c)
+		})
+	})
+	return &Fixedpoint{impl, noEq{}}
+}
+
+// FromString parses src and adds its relations, rules, and
+// assertions to f. It returns the queries in src.
+//
+// src uses SMT-LIB2 syntax, extended with the Datalog commands
+// declare-rel, declare-var, rule, and query. Alternatively, src may
+// give constrained Horn clauses as universally quantified assertions
+// (set-logic HORN), in which case a query is an assertion whose head
+// is false.
+func (f *Fixedpoint) FromString(src string) ([]Bool, error) {
+	csrc := C.CString(src)
+	defer C.free(unsafe.Pointer(csrc))
+	return f.parse(func() C.Z3_ast_vector {
+		return C.Z3_fixedpoint_from_string(f.ctx.c, f.c, csrc)
+	})
+}
+
+// FromFile is like FromString, but reads from the named file.
+func (f *Fixedpoint) FromFile(filename string) ([]Bool, error) {
+	cname := C.CString(filename)
+	defer C.free(unsafe.Pointer(cname))
+	return f.parse(func() C.Z3_ast_vector {
+		return C.Z3_fixedpoint_from_file(f.ctx.c, f.c, cname)
+	})
+}
+
+func (f *Fixedpoint) parse(parse func() C.Z3_ast_vector) ([]Bool, error) {
+	var queries *ASTVector
+	err := Try(func() {
+		f.ctx.do(func() {
+			queries = wrapASTVector(f.ctx, parse())
+		})
+	})
+	runtime.KeepAlive(f)
+	if err != nil {
+		return nil, fmt.Errorf("z3: parsing fixedpoint: %v", err)
+	}
+	var res []Bool
+	for _, q := range queries.Slice() {
+		res = append(res, q.AsValue().(Bool))
+	}
+	return res, nil
+}
+
+// Rules returns the rules in f.
+func (f *Fixedpoint) Rules() []Bool {
+	var vec *ASTVector
+	f.ctx.do(func() {
+		vec = wrapASTVector(f.ctx, C.Z3_fixedpoint_get_rules(f.ctx.c, f.c))
+	})
+	runtime.KeepAlive(f)
+	var res []Bool
+	for _, r := range vec.Slice() {
+		res = append(res, r.AsValue().(Bool))
+	}
+	return res
+}
+
+// Assertions returns the background axioms added to f by Assert.
+func (f *Fixedpoint) Assertions() []Bool {
+	var vec *ASTVector
+	f.ctx.do(func() {
+		vec = wrapASTVector(f.ctx, C.Z3_fixedpoint_get_assertions(f.ctx.c, f.c))
+	})
+	runtime.KeepAlive(f)
+	var res []Bool
+	for _, a := range vec.Slice() {
+		res = append(res, a.AsValue().(Bool))
+	}
+	return res
+}
+
+// Statistics returns performance counters for the queries f has
+// answered so far, such as the number of frames and lemmas Spacer
+// used.
+func (f *Fixedpoint) Statistics() Statistics {
+	var stats Statistics
+	f.ctx.do(func() {
+		stats = wrapStatistics(f.ctx, C.Z3_fixedpoint_get_statistics(f.ctx.c, f.c))
+	})
+	runtime.KeepAlive(f)
+	return stats
+}
+
+// String returns f's rules and assertions in SMT-LIB2 syntax.
+func (f *Fixedpoint) String() string {
+	var res string
+	f.ctx.do(func() {
+		res = C.GoString(C.Z3_fixedpoint_to_string(f.ctx.c, f.c, 0, nil))
+	})
+	runtime.KeepAlive(f)
+	return res
+}
+
+// RegisterRelation declares rel as a relation of f. rel must be an
+// uninterpreted function with range Bool. Every relation that
+// appears in the head of a rule must be registered before f is
+// queried.
+func (f *Fixedpoint) RegisterRelation(rel FuncDecl) {
+	f.ctx.do(func() {
+		C.Z3_fixedpoint_register_relation(f.ctx.c, f.c, rel.c)
+	})
+	runtime.KeepAlive(f)
+	runtime.KeepAlive(rel)
+}
+
+// AddRule adds a Horn clause to f. rule typically has the form
+//
+//	ctx.Forall(vars, body.Implies(head))
+//
+// where head is an application of a relation (see RegisterRelation)
+// and body is a conjunction of relation applications and
+// constraints. A rule with no body is a fact.
+func (f *Fixedpoint) AddRule(rule Bool) {
+	f.AddNamedRule("", rule)
+}
+
+// AddNamedRule is like AddRule, but labels rule with name. The name
+// identifies the rule to UpdateRule and appears in String. With the
+// datalog engine and the datalog.generate_explanations parameter set,
+// the derivations returned by Answer are written in terms of rule
+// names.
+func (f *Fixedpoint) AddNamedRule(name string, rule Bool) {
+	sym := f.ctx.symbol(name)
+	f.ctx.do(func() {
+		C.Z3_fixedpoint_add_rule(f.ctx.c, f.c, rule.c, sym)
+	})
+	runtime.KeepAlive(f)
+	runtime.KeepAlive(rule)
+}
+
+// UpdateRule replaces the rule named name with rule.
+func (f *Fixedpoint) UpdateRule(name string, rule Bool) {
+	sym := f.ctx.symbol(name)
+	f.ctx.do(func() {
+		C.Z3_fixedpoint_update_rule(f.ctx.c, f.c, rule.c, sym)
+	})
+	runtime.KeepAlive(f)
+	runtime.KeepAlive(rule)
+}
+
+// Assert adds a background axiom to f. Unlike rules, axioms
+// constrain only non-relation symbols.
+func (f *Fixedpoint) Assert(axiom Bool) {
+	f.ctx.do(func() {
+		C.Z3_fixedpoint_assert(f.ctx.c, f.c, axiom.c)
+	})
+	runtime.KeepAlive(f)
+	runtime.KeepAlive(axiom)
+}
+
+// Query determines whether query is derivable from the rules in f.
+// query is typically an application of a relation, or an
+// existentially quantified conjunction of relation applications and
+// constraints.
+//
+// Query returns true if query is derivable and false if it is not.
+// In either case, Answer returns more information. If Z3 is unable to
+// decide, Query returns an *ErrSatUnknown error.
+func (f *Fixedpoint) Query(query Bool) (derivable bool, err error) {
+	var res C.Z3_lbool
+	f.ctx.do(func() {
+		res = C.Z3_fixedpoint_query(f.ctx.c, f.c, query.c)
+	})
+	if res == C.Z3_L_UNDEF {
+		f.ctx.do(func() {
+			cerr := C.Z3_fixedpoint_get_reason_unknown(f.ctx.c, f.c)
+			err = &ErrSatUnknown{C.GoString(cerr)}
+		})
+	}
+	runtime.KeepAlive(f)
+	runtime.KeepAlive(query)
+	return res == C.Z3_L_TRUE, err
+}
+
+// Answer returns the answer to the last Query.
+//
+// If the query was derivable, the answer describes a derivation (for
+// the Spacer engine, this is a proof term; see Proof). If it was not,
+// the answer is typically a Bool giving an inductive invariant that
+// excludes the query.
+func (f *Fixedpoint) Answer() AST {
+	var res AST
+	f.ctx.do(func() {
+		res = wrapAST(f.ctx, C.Z3_fixedpoint_get_answer(f.ctx.c, f.c))
+	})
+	runtime.KeepAlive(f)
+	return res
+}
+
+// Derivation returns the answer to the last Query as a proof term,
+// which can be checked step by step. ok is false if the answer is not
+// a proof term; this is the case if the query was not derivable or
+// the engine does not produce proofs. The Spacer engine produces
+// proofs built from hyper-resolution steps.
+func (f *Fixedpoint) Derivation() (p Proof, ok bool) {
+	ans := f.Answer()
+	if ans.Kind() != ASTKindApp {
+		return Proof{}, false
+	}
+	p = Proof{ans}
+	if _, ok := proofRuleNames[p.Rule()]; !ok {
+		return Proof{}, false
+	}
+	return p, true
+}
+
+// Facts returns the tuples of rel that are derivable from the rules
+// in f. Each tuple is a slice of values, one for each argument of
+// rel. Facts uses the answer to a query of rel, which only the
+// datalog engine gives as a finite set of tuples, so rel's arguments
+// must have finite sorts such as bit-vectors or finite domains.
+func (f *Fixedpoint) Facts(rel FuncDecl) ([][]Value, error) {
+	var res C.Z3_lbool
+	var arity int
+	f.ctx.do(func() {
+		crel := rel.c
+		arity = int(C.Z3_get_arity(f.ctx.c, crel))
+		res = C.Z3_fixedpoint_query_relations(f.ctx.c, f.c, 1, &crel)
+	})
+	runtime.KeepAlive(f)
+	runtime.KeepAlive(rel)
+	switch res {
+	case C.Z3_L_FALSE:
+		return nil, nil
+	case C.Z3_L_UNDEF:
+		var err error
+		f.ctx.do(func() {
+			cerr := C.Z3_fixedpoint_get_reason_unknown(f.ctx.c, f.c)
+			err = &ErrSatUnknown{C.GoString(cerr)}
+		})
+		return nil, err
+	}
+
+	ans := f.Answer().AsValue()
+	disjuncts, ok := MatchOr(ans)
+	if !ok {
+		disjuncts = []Bool{ans.(Bool)}
+	}
+	var tuples [][]Value
+	for _, d := range disjuncts {
+		conjuncts, ok := MatchAnd(d)
+		if !ok {
+			conjuncts = []Bool{d}
+		}
+		tuple := make([]Value, arity)
+		for _, c := range conjuncts {
+			if lit, isLit := c.AsBool(); isLit && lit {
+				continue
+			}
+			l, r, ok := MatchEq(c)
+			if !ok {
+				return nil, fmt.Errorf("z3: answer for %s is not a set of tuples: %v", rel.Name(), c)
+			}
+			if l.AsAST().Kind() != ASTKindVar {
+				l, r = r, l
+			}
+			i, ok := f.ctx.varIndex(l)
+			if !ok || i >= arity {
+				return nil, fmt.Errorf("z3: answer for %s is not a set of tuples: %v", rel.Name(), c)
+			}
+			tuple[i] = r
+		}
+		for i, v := range tuple {
+			if v == nil {
+				return nil, fmt.Errorf("z3: argument %d of %s is unconstrained in %v", i, rel.Name(), d)
+			}
+		}
+		tuples = append(tuples, tuple)
+	}
+	return tuples, nil
+}
+
+// varIndex returns the de Bruijn index of x if x is a bound
+// variable.
+func (ctx *Context) varIndex(x Value) (int, bool) {
+	var idx int
+	var ok bool
+	ctx.do(func() {
+		c := x.impl().c
+		if C.Z3_get_ast_kind(ctx.c, c) == C.Z3_VAR_AST {
+			idx, ok = int(C.Z3_get_index_value(ctx.c, c)), true
+		}
+	})
+	runtime.KeepAlive(x)
+	return idx, ok
+}
+
+// Config returns a *Config for changing f's parameters, such as
+// "engine" (for example, "datalog" or "spacer"). Changes take effect
+// immediately. The Config's Params method lists the available
+// parameters.
+func (f *Fixedpoint) Config() *Config {
+	var desc []ParamDesc
+	f.ctx.do(func() {
+		desc = paramDescs(f.ctx, C.Z3_fixedpoint_get_param_descrs(f.ctx.c, f.c))
+	})
+	cfg := newConfig(desc)
+	cfg.set = func(name string, val interface{}) {
+		one := newConfig(nil)
+		one.m[name] = val
+		cparams := one.toC(f.ctx)
+		f.ctx.do(func() {
+			C.Z3_fixedpoint_set_params(f.ctx.c, f.c, cparams)
+			C.Z3_params_dec_ref(f.ctx.c, cparams)
+		})
+		runtime.KeepAlive(f)
+	}
+	return cfg
+}
+
+// A FixedpointEngine is an algorithm for answering Fixedpoint queries.
+type FixedpointEngine string
+
+const (
+	// EngineAuto selects an engine based on the rules. This is
+	// the default.
+	EngineAuto FixedpointEngine = "auto-config"
+
+	// EngineDatalog computes relations bottom-up. It requires
+	// relations over finite sorts, such as bit-vectors and finite
+	// domains.
+	EngineDatalog FixedpointEngine = "datalog"
+
+	// EngineSpacer solves constrained Horn clauses over
+	// arithmetic, arrays, and other theories by finding
+	// inductive invariants.
+	EngineSpacer FixedpointEngine = "spacer"
+
+	// EngineBMC unrolls rules up to a bound. It can show that a
+	// query is derivable, but generally not that it is
+	// underivable.
+	EngineBMC FixedpointEngine = "bmc"
+)
+
+// SetEngine sets the engine f uses to answer queries. This is
+// equivalent to setting the "engine" parameter.
+func (f *Fixedpoint) SetEngine(engine FixedpointEngine) {
+	f.Config().SetString("engine", string(engine))
+}
+
+// SpacerOptions are the commonly tuned parameters of the Spacer
+// engine. Fields correspond to the "spacer." and "xform." parameters
+// named in their comments. DefaultSpacerOptions returns Z3's default
+// settings, which can be adjusted before passing them to
+// Fixedpoint.SetSpacerOptions.
+type SpacerOptions struct {
+	// MaxLevel is the maximum number of frames to explore
+	// (spacer.max_level). 0 means no limit.
+	MaxLevel uint
+
+	// Propagate enables pushing lemmas to later frames
+	// (spacer.propagate).
+	Propagate bool
+
+	// PushPOB pushes blocked proof obligations to later frames
+	// (spacer.push_pob), up to depth PushPOBMaxDepth
+	// (spacer.push_pob_max_depth; 0 means no limit).
+	PushPOB         bool
+	PushPOBMaxDepth uint
+
+	// CTP enables counterexample-to-pushing (spacer.ctp).
+	CTP bool
+
+	// GroundPOBs grounds proof obligations using values from a
+	// model (spacer.ground_pobs).
+	GroundPOBs bool
+
+	// Quantified allows quantified lemmas in frames (spacer.q3),
+	// which are needed for invariants over arrays.
+	Quantified bool
+
+	// MBQI enables model-based quantifier instantiation
+	// (spacer.mbqi).
+	MBQI bool
+
+	// RandomSeed seeds the underlying SMT solver
+	// (spacer.random_seed).
+	RandomSeed uint
+
+	// Preprocess enables rule inlining and slicing
+	// (xform.inline_linear, xform.inline_eager, and xform.slice).
+	// Disabling these preserves the original relations, so that
+	// Answer gives an invariant for each of them.
+	Preprocess bool
+}
+
+// DefaultSpacerOptions returns Z3's default Spacer options.
+func DefaultSpacerOptions() SpacerOptions {
+	return SpacerOptions{
+		Propagate:  true,
+		CTP:        true,
+		GroundPOBs: true,
+		Quantified: true,
+		MBQI:       true,
+		Preprocess: true,
+	}
+}
+
+// SetSpacerOptions selects the Spacer engine and sets its options to
+// opts.
+func (f *Fixedpoint) SetSpacerOptions(opts SpacerOptions) {
+	noLimit := func(n uint) uint {
+		if n == 0 {
+			return math.MaxUint32
+		}
+		return n
+	}
+	f.Config().
+		SetString("engine", string(EngineSpacer)).
+		SetUint("spacer.max_level", noLimit(opts.MaxLevel)).
+		SetBool("spacer.propagate", opts.Propagate).
+		SetBool("spacer.push_pob", opts.PushPOB).
+		SetUint("spacer.push_pob_max_depth", noLimit(opts.PushPOBMaxDepth)).
+		SetBool("spacer.ctp", opts.CTP).
+		SetBool("spacer.ground_pobs", opts.GroundPOBs).
+		SetBool("spacer.q3", opts.Quantified).
+		SetBool("spacer.mbqi", opts.MBQI).
+		SetUint("spacer.random_seed", opts.RandomSeed).
+		SetBool("xform.inline_linear", opts.Preprocess).
+		SetBool("xform.inline_eager", opts.Preprocess).
+		SetBool("xform.slice", opts.Preprocess)
+}
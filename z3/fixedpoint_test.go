@@ -0,0 +1,323 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const edgeDatalog = `
+(declare-rel edge (Int Int))
+(declare-rel path (Int Int))
+(declare-var a Int)
+(declare-var b Int)
+(declare-var c Int)
+(rule (edge 1 2))
+(rule (edge 2 3))
+(rule (=> (edge a b) (path a b)))
+(rule (=> (and (path a b) (edge b c)) (path a c)))
+(declare-rel reach13 ())
+(rule (=> (path 1 3) reach13))
+(query reach13)
+`
+
+func TestFixedpointFromString(t *testing.T) {
+	ctx := NewContext(nil)
+	f := NewFixedpoint(ctx)
+	queries, err := f.FromString(edgeDatalog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queries) != 1 {
+		t.Errorf("got %d queries, want 1", len(queries))
+	}
+	if n := len(f.Rules()); n != 5 {
+		t.Errorf("got %d rules, want 5:\n%s", n, f)
+	}
+
+	const chc = `
+(set-logic HORN)
+(declare-fun inv (Int) Bool)
+(assert (inv 0))
+(assert (forall ((x Int)) (=> (and (inv x) (< x 10)) (inv (+ x 1)))))
+(assert (forall ((x Int)) (=> (and (inv x) (> x 10)) false)))
+`
+	f = NewFixedpoint(ctx)
+	if _, err := f.FromString(chc); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewFixedpoint(ctx).FromString("(rule (undeclared 1))"); err == nil {
+		t.Errorf("parsing undeclared relation succeeded")
+	}
+}
+
+func TestFixedpointQuery(t *testing.T) {
+	ctx := NewContext(nil)
+	ints, bools := ctx.IntSort(), ctx.BoolSort()
+	edge := ctx.FuncDecl("edge", []Sort{ints, ints}, bools)
+	path := ctx.FuncDecl("path", []Sort{ints, ints}, bools)
+	a, b, c := ctx.IntConst("a"), ctx.IntConst("b"), ctx.IntConst("c")
+	n := func(i int64) Value { return ctx.FromInt(i, ints) }
+	app := func(f FuncDecl, args ...Value) Bool { return f.Apply(args...).(Bool) }
+
+	f := NewFixedpoint(ctx)
+	f.Config().SetString("engine", "spacer")
+	f.RegisterRelation(edge)
+	f.RegisterRelation(path)
+	f.AddRule(app(edge, n(1), n(2)))
+	f.AddRule(app(edge, n(2), n(3)))
+	f.AddRule(app(edge, n(4), n(1)))
+	vars := []Value{a, b}
+	f.AddRule(ctx.Forall(vars, app(edge, a, b).Implies(app(path, a, b))))
+	vars = []Value{a, b, c}
+	f.AddRule(ctx.Forall(vars, app(path, a, b).And(app(edge, b, c)).Implies(app(path, a, c))))
+
+	for _, test := range []struct {
+		from, to int64
+		want     bool
+	}{
+		{1, 3, true},
+		{4, 3, true},
+		{3, 1, false},
+	} {
+		got, err := f.Query(app(path, n(test.from), n(test.to)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.want {
+			t.Errorf("path(%d, %d) = %v, want %v", test.from, test.to, got, test.want)
+		}
+	}
+
+	found := false
+	for _, p := range f.Config().Params() {
+		if p.Name == "engine" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("fixedpoint parameters do not include engine")
+	}
+}
+
+func TestFixedpointSpacer(t *testing.T) {
+	// A simple loop: x starts at 0 and increments while x < 10.
+	// x > 10 is unreachable.
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	inv := ctx.FuncDecl("inv", []Sort{ints}, ctx.BoolSort())
+	x := ctx.IntConst("x")
+	n := func(i int64) Int { return ctx.FromInt(i, ints).(Int) }
+
+	f := NewFixedpoint(ctx)
+	f.Config().SetString("engine", "spacer")
+	f.RegisterRelation(inv)
+	f.AddRule(inv.Apply(n(0)).(Bool))
+	f.AddRule(ctx.Forall([]Value{x}, inv.Apply(x).(Bool).And(x.LT(n(10))).Implies(inv.Apply(x.Add(n(1))).(Bool))))
+
+	bad, err := f.Query(ctx.Exists([]Value{x}, inv.Apply(x).(Bool).And(x.GT(n(10)))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bad {
+		t.Errorf("x > 10 is reachable")
+	}
+	if ans := f.Answer(); ans.Kind() != ASTKindApp && ans.Kind() != ASTKindQuantifier {
+		t.Errorf("unexpected answer %v", ans)
+	}
+}
+
+func TestFixedpointNamedRules(t *testing.T) {
+	ctx := NewContext(nil)
+	nodes, bools := ctx.BVSort(8), ctx.BoolSort()
+	edge := ctx.FuncDecl("edge", []Sort{nodes, nodes}, bools)
+	path := ctx.FuncDecl("path", []Sort{nodes, nodes}, bools)
+	a, b, c := ctx.Const("a", nodes), ctx.Const("b", nodes), ctx.Const("c", nodes)
+	n := func(i int64) Value { return ctx.FromInt(i, nodes) }
+	app := func(f FuncDecl, args ...Value) Bool { return f.Apply(args...).(Bool) }
+
+	f := NewFixedpoint(ctx)
+	f.Config().SetString("engine", "datalog")
+	f.Config().SetBool("datalog.generate_explanations", true)
+	f.RegisterRelation(edge)
+	f.RegisterRelation(path)
+	f.AddNamedRule("e12", app(edge, n(1), n(2)))
+	f.AddNamedRule("e23", app(edge, n(2), n(4)))
+	f.AddNamedRule("base", ctx.Forall([]Value{a, b}, app(edge, a, b).Implies(app(path, a, b))))
+	f.AddNamedRule("step", ctx.Forall([]Value{a, b, c}, app(path, a, b).And(app(edge, b, c)).Implies(app(path, a, c))))
+	f.UpdateRule("e23", app(edge, n(2), n(3)))
+
+	if s := f.String(); !strings.Contains(s, ":named step") {
+		t.Errorf("rule name missing from %s", s)
+	}
+	got, err := f.Query(app(path, n(1), n(3)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got {
+		t.Fatalf("path(1, 3) not derivable")
+	}
+	if ans, want := f.Answer().String(), "(step e23 (base e12))"; !strings.Contains(ans, want) {
+		t.Errorf("answer %s does not contain derivation %s", ans, want)
+	}
+}
+
+func TestFixedpointFacts(t *testing.T) {
+	ctx := NewContext(nil)
+	nodes, bools := ctx.BVSort(8), ctx.BoolSort()
+	edge := ctx.FuncDecl("edge", []Sort{nodes, nodes}, bools)
+	path := ctx.FuncDecl("path", []Sort{nodes, nodes}, bools)
+	cycle := ctx.FuncDecl("cycle", []Sort{nodes}, bools)
+	a, b, c := ctx.Const("a", nodes), ctx.Const("b", nodes), ctx.Const("c", nodes)
+	n := func(i int64) Value { return ctx.FromInt(i, nodes) }
+	app := func(f FuncDecl, args ...Value) Bool { return f.Apply(args...).(Bool) }
+
+	f := NewFixedpoint(ctx)
+	f.Config().SetString("engine", "datalog")
+	f.RegisterRelation(edge)
+	f.RegisterRelation(path)
+	f.RegisterRelation(cycle)
+	f.AddRule(app(edge, n(1), n(2)))
+	f.AddRule(app(edge, n(2), n(3)))
+	f.AddRule(ctx.Forall([]Value{a, b}, app(edge, a, b).Implies(app(path, a, b))))
+	f.AddRule(ctx.Forall([]Value{a, b, c}, app(path, a, b).And(app(edge, b, c)).Implies(app(path, a, c))))
+	f.AddRule(ctx.Forall([]Value{a}, app(path, a, a).Implies(app(cycle, a))))
+
+	facts, err := f.Facts(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, tuple := range facts {
+		x, _, _ := tuple[0].(BV).AsInt64()
+		y, _, _ := tuple[1].(BV).AsInt64()
+		got = append(got, fmt.Sprintf("%d->%d", x, y))
+	}
+	sort.Strings(got)
+	if want := []string{"1->2", "1->3", "2->3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("path facts = %v, want %v", got, want)
+	}
+
+	facts, err = f.Facts(cycle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(facts) != 0 {
+		t.Errorf("cycle facts = %v, want none", facts)
+	}
+}
+
+func TestFixedpointDerivation(t *testing.T) {
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	inv := ctx.FuncDecl("inv", []Sort{ints}, ctx.BoolSort())
+	x := ctx.IntConst("x")
+	n := func(i int64) Int { return ctx.FromInt(i, ints).(Int) }
+
+	f := NewFixedpoint(ctx)
+	f.Config().SetString("engine", "spacer")
+	f.RegisterRelation(inv)
+	f.AddRule(inv.Apply(n(0)).(Bool))
+	f.AddRule(ctx.Forall([]Value{x}, inv.Apply(x).(Bool).And(x.LT(n(3))).Implies(inv.Apply(x.Add(n(1))).(Bool))))
+
+	reached, err := f.Query(inv.Apply(n(3)).(Bool))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reached {
+		t.Fatalf("inv(3) not derivable")
+	}
+	p, ok := f.Derivation()
+	if !ok {
+		t.Fatalf("answer %v is not a derivation", f.Answer())
+	}
+	if concl := p.Conclusion(); !concl.AsAST().Equal(ctx.FromBool(false).AsAST()) {
+		t.Errorf("derivation concludes %v, want false", concl)
+	}
+
+	if reached, _ := f.Query(inv.Apply(n(4)).(Bool)); reached {
+		t.Fatalf("inv(4) derivable")
+	}
+	if _, ok := f.Derivation(); ok {
+		t.Errorf("underivable query has a derivation")
+	}
+}
+
+func TestFixedpointEngines(t *testing.T) {
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	inv := ctx.FuncDecl("inv", []Sort{ints}, ctx.BoolSort())
+	x := ctx.IntConst("x")
+	n := func(i int64) Int { return ctx.FromInt(i, ints).(Int) }
+	newLoop := func() *Fixedpoint {
+		f := NewFixedpoint(ctx)
+		f.RegisterRelation(inv)
+		f.AddRule(inv.Apply(n(0)).(Bool))
+		f.AddRule(ctx.Forall([]Value{x}, inv.Apply(x).(Bool).And(x.LT(n(10))).Implies(inv.Apply(x.Add(n(1))).(Bool))))
+		return f
+	}
+
+	f := newLoop()
+	f.SetEngine(EngineBMC)
+	if reached, err := f.Query(inv.Apply(n(5)).(Bool)); err != nil || !reached {
+		t.Errorf("BMC: inv(5) derivable = %v, %v; want true", reached, err)
+	}
+
+	f = newLoop()
+	opts := DefaultSpacerOptions()
+	opts.Preprocess = false
+	opts.PushPOB = true
+	f.SetSpacerOptions(opts)
+	reached, err := f.Query(ctx.Exists([]Value{x}, inv.Apply(x).(Bool).And(x.GT(n(10)))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reached {
+		t.Errorf("Spacer: x > 10 is reachable")
+	}
+	if ans := f.Answer().String(); !strings.Contains(ans, "inv") {
+		t.Errorf("Spacer answer %s does not give an invariant for inv", ans)
+	}
+}
+
+func TestFixedpointIntrospection(t *testing.T) {
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	inv := ctx.FuncDecl("inv", []Sort{ints}, ctx.BoolSort())
+	x, k := ctx.IntConst("x"), ctx.IntConst("k")
+	n := func(i int64) Int { return ctx.FromInt(i, ints).(Int) }
+
+	f := NewFixedpoint(ctx)
+	f.SetEngine(EngineSpacer)
+	f.RegisterRelation(inv)
+	f.Assert(k.GT(n(0)))
+	f.AddRule(inv.Apply(n(0)).(Bool))
+	f.AddRule(ctx.Forall([]Value{x}, inv.Apply(x).(Bool).Implies(inv.Apply(x.Add(n(1))).(Bool))))
+
+	if rules := f.Rules(); len(rules) != 2 {
+		t.Errorf("got %d rules, want 2: %v", len(rules), rules)
+	}
+	if as := f.Assertions(); len(as) != 1 || !as[0].AsAST().Equal(k.GT(n(0)).AsAST()) {
+		t.Errorf("assertions = %v, want [%v]", as, k.GT(n(0)))
+	}
+
+	if _, err := f.Query(ctx.Exists([]Value{x}, inv.Apply(x).(Bool).And(x.LT(n(0))))); err != nil {
+		t.Fatal(err)
+	}
+	stats := f.Statistics()
+	if len(stats) == 0 {
+		t.Errorf("no statistics after query")
+	}
+	for key := range stats {
+		if key == "" {
+			t.Errorf("statistic with empty name")
+		}
+	}
+}
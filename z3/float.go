@@ -5,6 +5,7 @@
 package z3
 
 import (
+	"math"
 	"math/big"
 	"runtime"
 )
@@ -66,6 +67,25 @@ func (ctx *Context) FloatSort(ebits, sbits int) Sort {
 	return sort
 }
 
+// Float32Sort returns the IEEE single-precision floating-point sort,
+// which corresponds to Go's float32.
+func (ctx *Context) Float32Sort() Sort {
+	return ctx.FloatSort(8, 24)
+}
+
+// Float64Sort returns the IEEE double-precision floating-point sort,
+// which corresponds to Go's float64.
+func (ctx *Context) Float64Sort() Sort {
+	return ctx.FloatSort(11, 53)
+}
+
+// FloatConst returns a floating-point constant named "name" with
+// ebits exponent bits and sbits significand bits. See FloatSort for
+// the meaning of ebits and sbits.
+func (ctx *Context) FloatConst(name string, ebits, sbits int) Float {
+	return ctx.Const(name, ctx.FloatSort(ebits, sbits)).(Float)
+}
+
 // RoundingMode represents a floating-point rounding mode.
 //
 // The zero value of RoundingMode is RoundToNearestEven, which is the
@@ -132,15 +152,16 @@ func (rm RoundingMode) ast(ctx *Context) value {
 // RoundToNearestEven if it isn't set. The ctx lock must *not* be
 // held.
 func (ctx *Context) rm() value {
-	ctx.lock.Lock()
-	rm := ctx.roundingModeAST
-	ctx.lock.Unlock()
+	var rm value
+	ctx.withLock(func() {
+		rm = ctx.roundingModeAST
+	})
 	if rm.valueImpl == nil {
 		// Lazily initialize the rounding mode.
 		rm = ctx.roundingMode.ast(ctx)
-		ctx.lock.Lock()
-		ctx.roundingModeAST = rm
-		ctx.lock.Unlock()
+		ctx.withLock(func() {
+			ctx.roundingModeAST = rm
+		})
 	}
 	return rm
 }
@@ -149,20 +170,23 @@ func (ctx *Context) rm() value {
 // operations and returns ctx's old rounding mode.
 func (ctx *Context) SetRoundingMode(rm RoundingMode) RoundingMode {
 	rmv := rm.ast(ctx)
-	ctx.lock.Lock()
-	old := ctx.roundingMode
-	ctx.roundingMode = rm
-	ctx.roundingModeAST = rmv
-	ctx.lock.Unlock()
+	var old RoundingMode
+	ctx.withLock(func() {
+		old = ctx.roundingMode
+		ctx.roundingMode = rm
+		ctx.roundingModeAST = rmv
+	})
 	return old
 }
 
 // RoundingMode returns ctx's current rounding mode for floating-point
 // operations.
 func (ctx *Context) RoundingMode() RoundingMode {
-	ctx.lock.Lock()
-	defer ctx.lock.Unlock()
-	return ctx.roundingMode
+	var rm RoundingMode
+	ctx.withLock(func() {
+		rm = ctx.roundingMode
+	})
+	return rm
 }
 
 // FloatNaN returns a floating-point NaN of sort s.
@@ -331,7 +355,7 @@ exact:
 		// exponent, but a shifted significand with the
 		// most-significant bit stripped.
 		out := Float(wrapValue(ctx, func() C.Z3_ast {
-			return C.Z3_mk_fpa_numeral_int64_uint64(ctx.c, boolToZ3(neg), C.__int64(exp+lost), C.__uint64(val<<(uint(sbits)-exp-1)), sort.c)
+			return C.Z3_mk_fpa_numeral_int64_uint64(ctx.c, boolToZ3(neg), C.int64_t(exp+lost), C.uint64_t(val<<(uint(sbits)-exp-1)), sort.c)
 		}))
 		runtime.KeepAlive(ctx)
 		return out
@@ -466,11 +490,11 @@ func (lit Float) AsBigFloat() (val *big.Float, isLiteral bool) {
 	case lit.isAppOf(C.Z3_OP_FPA_NUM):
 		var sign C.int
 		var sig string
-		var exp C.__int64
+		var exp C.int64_t
 		lit.ctx.do(func() {
 			C.Z3_fpa_get_numeral_sign(lit.ctx.c, lit.c, &sign)
 			sig = C.GoString(C.Z3_fpa_get_numeral_significand_string(lit.ctx.c, lit.c))
-			C.Z3_fpa_get_numeral_exponent_int64(lit.ctx.c, lit.c, &exp, C.Z3_FALSE)
+			C.Z3_fpa_get_numeral_exponent_int64(lit.ctx.c, lit.c, &exp, boolToZ3(false))
 		})
 		out.Parse(sig, 10)
 		if sign > 0 {
@@ -492,6 +516,68 @@ func (lit Float) AsBigFloat() (val *big.Float, isLiteral bool) {
 	return &out, true
 }
 
+// AsFloat64 returns the value of lit as a float64. If lit is not a
+// literal, it returns 0, false, false. If lit is a literal, but its
+// value cannot be represented exactly as a float64, it returns the
+// nearest float64 (rounding to nearest even), true, false. NaN and
+// infinities are preserved.
+func (lit Float) AsFloat64() (val float64, isLiteral, ok bool) {
+	bf, isLiteral := lit.AsBigFloat()
+	if !isLiteral {
+		return 0, false, false
+	}
+	if bf == nil {
+		return math.NaN(), true, true
+	}
+	val, acc := bf.Float64()
+	return val, true, acc == big.Exact
+}
+
+// AsFloat32 is like AsFloat64, but returns a float32.
+func (lit Float) AsFloat32() (val float32, isLiteral, ok bool) {
+	bf, isLiteral := lit.AsBigFloat()
+	if !isLiteral {
+		return 0, false, false
+	}
+	if bf == nil {
+		return float32(math.NaN()), true, true
+	}
+	val, acc := bf.Float32()
+	return val, true, acc == big.Exact
+}
+
+// AsFloat64Bits returns the IEEE 754 binary representation of lit,
+// which must have sort Float64Sort. Unlike AsFloat64, this never
+// rounds: ok is false if lit's sort is not the float64 sort. If lit
+// is not a literal, it returns 0, false, false.
+//
+// Z3 has only a single NaN value, so there are no NaN payloads to
+// recover. AsFloat64Bits represents NaN using the same bits as
+// math.NaN.
+func (lit Float) AsFloat64Bits() (bits uint64, isLiteral, ok bool) {
+	val, isLiteral, ok := lit.AsFloat64()
+	if !isLiteral {
+		return 0, false, false
+	}
+	if ebits, sbits := lit.Sort().FloatSize(); ebits != 11 || sbits != 53 {
+		ok = false
+	}
+	return math.Float64bits(val), true, ok
+}
+
+// AsFloat32Bits is like AsFloat64Bits, but for literals of sort
+// Float32Sort.
+func (lit Float) AsFloat32Bits() (bits uint32, isLiteral, ok bool) {
+	val, isLiteral, ok := lit.AsFloat32()
+	if !isLiteral {
+		return 0, false, false
+	}
+	if ebits, sbits := lit.Sort().FloatSize(); ebits != 8 || sbits != 24 {
+		ok = false
+	}
+	return math.Float32bits(val), true, ok
+}
+
 //go:generate go run genwrap.go -t Float $GOFILE
 
 // Abs returns the absolute value of l.
@@ -526,6 +612,41 @@ func (lit Float) AsBigFloat() (val *big.Float, isLiteral bool) {
 //
 //wrap:expr Div Z3_mk_fpa_div @rm l r
 
+// AddRM is like Add, but rounds the result according to rm instead
+// of the current rounding mode. This makes it possible to use a
+// symbolic rounding mode.
+//
+//wrap:expr AddRM l r rm:RM : Z3_mk_fpa_add rm l r
+
+// SubRM is like Sub, but rounds the result according to rm instead
+// of the current rounding mode.
+//
+//wrap:expr SubRM l r rm:RM : Z3_mk_fpa_sub rm l r
+
+// MulRM is like Mul, but rounds the result according to rm instead
+// of the current rounding mode.
+//
+//wrap:expr MulRM l r rm:RM : Z3_mk_fpa_mul rm l r
+
+// DivRM is like Div, but rounds the result according to rm instead
+// of the current rounding mode.
+//
+//wrap:expr DivRM l r rm:RM : Z3_mk_fpa_div rm l r
+
+// MulAddRM is like MulAdd, but rounds the result according to rm
+// instead of the current rounding mode.
+//
+//wrap:expr MulAddRM l r a rm:RM : Z3_mk_fpa_fma rm l r a
+
+// SqrtRM is like Sqrt, but rounds the result according to rm instead
+// of the current rounding mode.
+//
+//wrap:expr SqrtRM l rm:RM : Z3_mk_fpa_sqrt rm l
+
+// RoundRM is like Round, but takes a symbolic rounding mode.
+//
+//wrap:expr RoundRM l rm:RM : Z3_mk_fpa_round_to_integral rm l
+
 // MulAdd returns l*r+a (fused multiply and add).
 //
 // MulAdd uses the current rounding mode on the result of the whole
@@ -539,7 +660,10 @@ func (lit Float) AsBigFloat() (val *big.Float, isLiteral bool) {
 //
 //wrap:expr Sqrt Z3_mk_fpa_sqrt @rm l
 
-// Rem returns the remainder of l/r.
+// Rem returns the IEEE remainder of l/r. This is l-r*n, where n is
+// the integer nearest to l/r, with ties going to even. Like
+// math.Remainder, it is always exact and does not depend on the
+// rounding mode.
 //
 //wrap:expr Rem Z3_mk_fpa_rem l r
 
@@ -600,11 +724,13 @@ func (lit Float) AsBigFloat() (val *big.Float, isLiteral bool) {
 //
 //wrap:expr IsNaN:Bool Z3_mk_fpa_is_nan l
 
-// IsNegative returns true if l is negative.
+// IsNegative returns true if l is negative, including -0 and -∞.
+// NaN is neither negative nor positive.
 //
 //wrap:expr IsNegative:Bool Z3_mk_fpa_is_negative l
 
-// IsPositive returns true if l is positive.
+// IsPositive returns true if l is positive, including +0 and +∞.
+// NaN is neither negative nor positive.
 //
 //wrap:expr IsPositive:Bool Z3_mk_fpa_is_positive l
 
@@ -15,7 +15,7 @@ import "C"
 func (l Float) Eq(r Float) Bool {
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_eq(ctx.c, l.c, r.c)
+		return C.Z3_mk_eq(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -27,12 +27,26 @@ func (l Float) NE(r Float) Bool {
 	return l.ctx.Distinct(l, r)
 }
 
+// If returns a Value equal to l if cond is true, otherwise r.
+//
+// This is a typed form of Bool.IfThenElse.
+func (l Float) If(cond Bool, r Float) Float {
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_ite(ctx.c, cond.cref(), l.cref(), r.cref())
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(cond)
+	runtime.KeepAlive(r)
+	return Float(val)
+}
+
 // Abs returns the absolute value of l.
 func (l Float) Abs() Float {
-	// Generated from float.go:499.
+	// Generated from float.go:585.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_abs(ctx.c, l.c)
+		return C.Z3_mk_fpa_abs(ctx.c, l.cref())
 	})
 	runtime.KeepAlive(l)
 	return Float(val)
@@ -40,10 +54,10 @@ func (l Float) Abs() Float {
 
 // Neg returns -l.
 func (l Float) Neg() Float {
-	// Generated from float.go:503.
+	// Generated from float.go:589.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_neg(ctx.c, l.c)
+		return C.Z3_mk_fpa_neg(ctx.c, l.cref())
 	})
 	runtime.KeepAlive(l)
 	return Float(val)
@@ -53,11 +67,11 @@ func (l Float) Neg() Float {
 //
 // Add uses the current rounding mode.
 func (l Float) Add(r Float) Float {
-	// Generated from float.go:509.
+	// Generated from float.go:595.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_add(ctx.c, rm.c, l.c, r.c)
+		return C.Z3_mk_fpa_add(ctx.c, rm.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -68,11 +82,11 @@ func (l Float) Add(r Float) Float {
 //
 // Sub uses the current rounding mode.
 func (l Float) Sub(r Float) Float {
-	// Generated from float.go:515.
+	// Generated from float.go:601.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_sub(ctx.c, rm.c, l.c, r.c)
+		return C.Z3_mk_fpa_sub(ctx.c, rm.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -83,11 +97,11 @@ func (l Float) Sub(r Float) Float {
 //
 // Mul uses the current rounding mode.
 func (l Float) Mul(r Float) Float {
-	// Generated from float.go:521.
+	// Generated from float.go:607.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_mul(ctx.c, rm.c, l.c, r.c)
+		return C.Z3_mk_fpa_mul(ctx.c, rm.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -98,14 +112,111 @@ func (l Float) Mul(r Float) Float {
 //
 // Div uses the current rounding mode.
 func (l Float) Div(r Float) Float {
-	// Generated from float.go:527.
+	// Generated from float.go:613.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_div(ctx.c, rm.c, l.c, r.c)
+		return C.Z3_mk_fpa_div(ctx.c, rm.c, l.cref(), r.cref())
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return Float(val)
+}
+
+// AddRM is like Add, but rounds the result according to rm instead
+// of the current rounding mode. This makes it possible to use a
+// symbolic rounding mode.
+func (l Float) AddRM(r Float, rm RM) Float {
+	// Generated from float.go:619.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_fpa_add(ctx.c, rm.cref(), l.cref(), r.cref())
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	runtime.KeepAlive(rm)
+	return Float(val)
+}
+
+// SubRM is like Sub, but rounds the result according to rm instead
+// of the current rounding mode.
+func (l Float) SubRM(r Float, rm RM) Float {
+	// Generated from float.go:624.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_fpa_sub(ctx.c, rm.cref(), l.cref(), r.cref())
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	runtime.KeepAlive(rm)
+	return Float(val)
+}
+
+// MulRM is like Mul, but rounds the result according to rm instead
+// of the current rounding mode.
+func (l Float) MulRM(r Float, rm RM) Float {
+	// Generated from float.go:629.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_fpa_mul(ctx.c, rm.cref(), l.cref(), r.cref())
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	runtime.KeepAlive(rm)
+	return Float(val)
+}
+
+// DivRM is like Div, but rounds the result according to rm instead
+// of the current rounding mode.
+func (l Float) DivRM(r Float, rm RM) Float {
+	// Generated from float.go:634.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_fpa_div(ctx.c, rm.cref(), l.cref(), r.cref())
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	runtime.KeepAlive(rm)
+	return Float(val)
+}
+
+// MulAddRM is like MulAdd, but rounds the result according to rm
+// instead of the current rounding mode.
+func (l Float) MulAddRM(r Float, a Float, rm RM) Float {
+	// Generated from float.go:639.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_fpa_fma(ctx.c, rm.cref(), l.cref(), r.cref(), a.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
+	runtime.KeepAlive(a)
+	runtime.KeepAlive(rm)
+	return Float(val)
+}
+
+// SqrtRM is like Sqrt, but rounds the result according to rm instead
+// of the current rounding mode.
+func (l Float) SqrtRM(rm RM) Float {
+	// Generated from float.go:644.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_fpa_sqrt(ctx.c, rm.cref(), l.cref())
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(rm)
+	return Float(val)
+}
+
+// RoundRM is like Round, but takes a symbolic rounding mode.
+func (l Float) RoundRM(rm RM) Float {
+	// Generated from float.go:648.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_fpa_round_to_integral(ctx.c, rm.cref(), l.cref())
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(rm)
 	return Float(val)
 }
 
@@ -114,11 +225,11 @@ func (l Float) Div(r Float) Float {
 // MulAdd uses the current rounding mode on the result of the whole
 // operation.
 func (l Float) MulAdd(r Float, a Float) Float {
-	// Generated from float.go:534.
+	// Generated from float.go:655.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_fma(ctx.c, rm.c, l.c, r.c, a.c)
+		return C.Z3_mk_fpa_fma(ctx.c, rm.c, l.cref(), r.cref(), a.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -130,22 +241,25 @@ func (l Float) MulAdd(r Float, a Float) Float {
 //
 // Sqrt uses the current rounding mode.
 func (l Float) Sqrt() Float {
-	// Generated from float.go:540.
+	// Generated from float.go:661.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_sqrt(ctx.c, rm.c, l.c)
+		return C.Z3_mk_fpa_sqrt(ctx.c, rm.c, l.cref())
 	})
 	runtime.KeepAlive(l)
 	return Float(val)
 }
 
-// Rem returns the remainder of l/r.
+// Rem returns the IEEE remainder of l/r. This is l-r*n, where n is
+// the integer nearest to l/r, with ties going to even. Like
+// math.Remainder, it is always exact and does not depend on the
+// rounding mode.
 func (l Float) Rem(r Float) Float {
-	// Generated from float.go:544.
+	// Generated from float.go:668.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_rem(ctx.c, l.c, r.c)
+		return C.Z3_mk_fpa_rem(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -155,11 +269,11 @@ func (l Float) Rem(r Float) Float {
 // Round rounds l to an integral floating-point value according to
 // rounding mode rm.
 func (l Float) Round(rm RoundingMode) Float {
-	// Generated from float.go:549.
+	// Generated from float.go:673.
 	ctx := l.ctx
 	rmc := rm.ast(ctx)
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_round_to_integral(ctx.c, rmc.c, l.c)
+		return C.Z3_mk_fpa_round_to_integral(ctx.c, rmc.c, l.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(rm)
@@ -168,10 +282,10 @@ func (l Float) Round(rm RoundingMode) Float {
 
 // Min returns the minimum of l and r.
 func (l Float) Min(r Float) Float {
-	// Generated from float.go:553.
+	// Generated from float.go:677.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_min(ctx.c, l.c, r.c)
+		return C.Z3_mk_fpa_min(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -180,10 +294,10 @@ func (l Float) Min(r Float) Float {
 
 // Max returns the maximum of l and r.
 func (l Float) Max(r Float) Float {
-	// Generated from float.go:557.
+	// Generated from float.go:681.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_max(ctx.c, l.c, r.c)
+		return C.Z3_mk_fpa_max(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -196,10 +310,10 @@ func (l Float) Max(r Float) Float {
 // contrast, under IEEE equality, ±0 == ±0, while NaN != NaN and ±inf
 // != ±inf.
 func (l Float) IEEEEq(r Float) Bool {
-	// Generated from float.go:565.
+	// Generated from float.go:689.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_eq(ctx.c, l.c, r.c)
+		return C.Z3_mk_fpa_eq(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -208,10 +322,10 @@ func (l Float) IEEEEq(r Float) Bool {
 
 // LT returns l < r.
 func (l Float) LT(r Float) Bool {
-	// Generated from float.go:569.
+	// Generated from float.go:693.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_lt(ctx.c, l.c, r.c)
+		return C.Z3_mk_fpa_lt(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -220,10 +334,10 @@ func (l Float) LT(r Float) Bool {
 
 // LE returns l <= r.
 func (l Float) LE(r Float) Bool {
-	// Generated from float.go:573.
+	// Generated from float.go:697.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_leq(ctx.c, l.c, r.c)
+		return C.Z3_mk_fpa_leq(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -232,10 +346,10 @@ func (l Float) LE(r Float) Bool {
 
 // GT returns l > r.
 func (l Float) GT(r Float) Bool {
-	// Generated from float.go:577.
+	// Generated from float.go:701.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_gt(ctx.c, l.c, r.c)
+		return C.Z3_mk_fpa_gt(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -244,10 +358,10 @@ func (l Float) GT(r Float) Bool {
 
 // GE returns l >= r.
 func (l Float) GE(r Float) Bool {
-	// Generated from float.go:581.
+	// Generated from float.go:705.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_geq(ctx.c, l.c, r.c)
+		return C.Z3_mk_fpa_geq(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -256,10 +370,10 @@ func (l Float) GE(r Float) Bool {
 
 // IsNormal returns true if l is a normal floating-point number.
 func (l Float) IsNormal() Bool {
-	// Generated from float.go:585.
+	// Generated from float.go:709.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_is_normal(ctx.c, l.c)
+		return C.Z3_mk_fpa_is_normal(ctx.c, l.cref())
 	})
 	runtime.KeepAlive(l)
 	return Bool(val)
@@ -267,10 +381,10 @@ func (l Float) IsNormal() Bool {
 
 // IsSubnormal returns true if l is a subnormal floating-point number.
 func (l Float) IsSubnormal() Bool {
-	// Generated from float.go:589.
+	// Generated from float.go:713.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_is_subnormal(ctx.c, l.c)
+		return C.Z3_mk_fpa_is_subnormal(ctx.c, l.cref())
 	})
 	runtime.KeepAlive(l)
 	return Bool(val)
@@ -278,10 +392,10 @@ func (l Float) IsSubnormal() Bool {
 
 // IsZero returns true if l is ±0.
 func (l Float) IsZero() Bool {
-	// Generated from float.go:593.
+	// Generated from float.go:717.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_is_zero(ctx.c, l.c)
+		return C.Z3_mk_fpa_is_zero(ctx.c, l.cref())
 	})
 	runtime.KeepAlive(l)
 	return Bool(val)
@@ -289,10 +403,10 @@ func (l Float) IsZero() Bool {
 
 // IsInfinite returns true if l is ±∞.
 func (l Float) IsInfinite() Bool {
-	// Generated from float.go:597.
+	// Generated from float.go:721.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_is_infinite(ctx.c, l.c)
+		return C.Z3_mk_fpa_is_infinite(ctx.c, l.cref())
 	})
 	runtime.KeepAlive(l)
 	return Bool(val)
@@ -300,32 +414,34 @@ func (l Float) IsInfinite() Bool {
 
 // IsNaN returns true if l is NaN.
 func (l Float) IsNaN() Bool {
-	// Generated from float.go:601.
+	// Generated from float.go:725.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_is_nan(ctx.c, l.c)
+		return C.Z3_mk_fpa_is_nan(ctx.c, l.cref())
 	})
 	runtime.KeepAlive(l)
 	return Bool(val)
 }
 
-// IsNegative returns true if l is negative.
+// IsNegative returns true if l is negative, including -0 and -∞.
+// NaN is neither negative nor positive.
 func (l Float) IsNegative() Bool {
-	// Generated from float.go:605.
+	// Generated from float.go:730.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_is_negative(ctx.c, l.c)
+		return C.Z3_mk_fpa_is_negative(ctx.c, l.cref())
 	})
 	runtime.KeepAlive(l)
 	return Bool(val)
 }
 
-// IsPositive returns true if l is positive.
+// IsPositive returns true if l is positive, including +0 and +∞.
+// NaN is neither negative nor positive.
 func (l Float) IsPositive() Bool {
-	// Generated from float.go:609.
+	// Generated from float.go:735.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_is_positive(ctx.c, l.c)
+		return C.Z3_mk_fpa_is_positive(ctx.c, l.cref())
 	})
 	runtime.KeepAlive(l)
 	return Bool(val)
@@ -337,11 +453,11 @@ func (l Float) IsPositive() Bool {
 // If necessary, the result will be rounded according to the current
 // rounding mode.
 func (l Float) ToFloat(s Sort) Float {
-	// Generated from float.go:617.
+	// Generated from float.go:743.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_to_fp_float(ctx.c, rm.c, l.c, s.c)
+		return C.Z3_mk_fpa_to_fp_float(ctx.c, rm.c, l.cref(), s.c)
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(s)
@@ -354,11 +470,11 @@ func (l Float) ToFloat(s Sort) Float {
 // If the result is not in the range [0, 2^bits-1], the result is
 // unspecified.
 func (l Float) ToUBV(bits int) BV {
-	// Generated from float.go:625.
+	// Generated from float.go:751.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_to_ubv(ctx.c, rm.c, l.c, C.unsigned(bits))
+		return C.Z3_mk_fpa_to_ubv(ctx.c, rm.c, l.cref(), C.unsigned(bits))
 	})
 	runtime.KeepAlive(l)
 	return BV(val)
@@ -370,11 +486,11 @@ func (l Float) ToUBV(bits int) BV {
 // If the result is not in the range [-2^(bits-1), 2^(bits-1)-1], the
 // result is unspecified.
 func (l Float) ToSBV(bits int) BV {
-	// Generated from float.go:633.
+	// Generated from float.go:759.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_to_sbv(ctx.c, rm.c, l.c, C.unsigned(bits))
+		return C.Z3_mk_fpa_to_sbv(ctx.c, rm.c, l.cref(), C.unsigned(bits))
 	})
 	runtime.KeepAlive(l)
 	return BV(val)
@@ -384,10 +500,10 @@ func (l Float) ToSBV(bits int) BV {
 //
 // If l is ±inf, or NaN, the result is unspecified.
 func (l Float) ToReal() Real {
-	// Generated from float.go:639.
+	// Generated from float.go:765.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_to_real(ctx.c, l.c)
+		return C.Z3_mk_fpa_to_real(ctx.c, l.cref())
 	})
 	runtime.KeepAlive(l)
 	return Real(val)
@@ -398,10 +514,10 @@ func (l Float) ToReal() Real {
 // Note that NaN has many possible representations. This conversion
 // always uses the same representation.
 func (l Float) ToIEEEBV() BV {
-	// Generated from float.go:646.
+	// Generated from float.go:772.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_to_ieee_bv(ctx.c, l.c)
+		return C.Z3_mk_fpa_to_ieee_bv(ctx.c, l.cref())
 	})
 	runtime.KeepAlive(l)
 	return BV(val)
@@ -118,3 +118,308 @@ func TestFloatAsBigFloat(t *testing.T) {
 		}
 	}
 }
+
+func TestFloatConst(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.FloatConst("x", 8, 24)
+	if ebits, sbits := x.Sort().FloatSize(); ebits != 8 || sbits != 24 {
+		t.Fatalf("x has size %d, %d; want 8, 24", ebits, sbits)
+	}
+
+	// Find an x such that x+1 == x. x must be large or infinite.
+	s := NewSolver(ctx)
+	one := ctx.FromFloat32(1, x.Sort())
+	s.Assert(x.Add(one).IEEEEq(x))
+	s.Assert(x.IsInfinite().Not())
+	if sat, err := s.Check(); !sat {
+		t.Fatalf("%s not satisfiable: %v", s, err)
+	}
+	xv, _ := s.Model().Eval(x, true).(Float).AsBigFloat()
+	if f, _ := xv.Float32(); f+1 != f {
+		t.Errorf("want x+1 == x, got x = %v", f)
+	}
+}
+
+func TestRoundingModeValue(t *testing.T) {
+	ctx := NewContext(nil)
+	for rm := RoundingMode(0); rm < roundingModesNum; rm++ {
+		lit := ctx.FromRoundingMode(rm)
+		if got, ok := lit.AsRoundingMode(); got != rm || !ok {
+			t.Errorf("FromRoundingMode(%d).AsRoundingMode() = %d, %v; want %d, true", rm, got, ok, rm)
+		}
+	}
+
+	r, ok := ctx.Const("r", ctx.RoundingModeSort()).(RM)
+	if !ok {
+		t.Fatalf("constant of RoundingModeSort is not an RM")
+	}
+	if _, ok := r.AsRoundingMode(); ok {
+		t.Errorf("constant r is a literal")
+	}
+	s := NewSolver(ctx)
+	s.Assert(r.NE(ctx.FromRoundingMode(RoundToNearestEven)))
+	s.Assert(r.NE(ctx.FromRoundingMode(RoundToNearestAway)))
+	s.Assert(r.NE(ctx.FromRoundingMode(RoundToPositive)))
+	s.Assert(r.NE(ctx.FromRoundingMode(RoundToNegative)))
+	if sat, err := s.Check(); !sat {
+		t.Fatalf("%s not satisfiable: %v", s, err)
+	}
+	if rm, _ := s.Model().Eval(r, true).(RM).AsRoundingMode(); rm != RoundToZero {
+		t.Errorf("want r = RoundToZero, got %d", rm)
+	}
+}
+
+func TestFloatArithRM(t *testing.T) {
+	ctx := NewContext(nil)
+	s := ctx.FloatSort(11, 53)
+	one, three := ctx.FromFloat64(1, s), ctx.FromFloat64(3, s)
+	for rm := RoundingMode(0); rm < roundingModesNum; rm++ {
+		// AddRM and friends must agree with the implicit
+		// rounding mode operations.
+		ctx.SetRoundingMode(rm)
+		rmv := ctx.FromRoundingMode(rm)
+		for _, test := range []struct{ got, want Float }{
+			{one.AddRM(three, rmv), one.Add(three)},
+			{one.SubRM(three, rmv), one.Sub(three)},
+			{one.MulRM(three, rmv), one.Mul(three)},
+			{one.DivRM(three, rmv), one.Div(three)},
+			{one.MulAddRM(three, one, rmv), one.MulAdd(three, one)},
+			{three.SqrtRM(rmv), three.Sqrt()},
+			{three.DivRM(ctx.FromFloat64(2, s), rmv).RoundRM(rmv), three.Div(ctx.FromFloat64(2, s)).Round(rm)},
+		} {
+			if !simplifyBool(t, ctx, test.got.Eq(test.want)) {
+				t.Errorf("%s != %s", test.got, test.want)
+			}
+		}
+	}
+	ctx.SetRoundingMode(RoundToNearestEven)
+
+	// 1/3 rounds differently toward positive and negative.
+	up := one.DivRM(three, ctx.FromRoundingMode(RoundToPositive))
+	down := one.DivRM(three, ctx.FromRoundingMode(RoundToNegative))
+	if !simplifyBool(t, ctx, down.LT(up)) {
+		t.Errorf("want %s < %s", ctx.Simplify(down, nil), ctx.Simplify(up, nil))
+	}
+}
+
+func TestFloatClassify(t *testing.T) {
+	ctx := NewContext(nil)
+	s := ctx.FloatSort(11, 53)
+	type class struct {
+		normal, subnormal, zero, inf, nan, neg, pos bool
+	}
+	for _, test := range []struct {
+		val  float64
+		want class
+	}{
+		{1, class{normal: true, pos: true}},
+		{-math.MaxFloat64, class{normal: true, neg: true}},
+		{math.SmallestNonzeroFloat64, class{subnormal: true, pos: true}},
+		{0, class{zero: true, pos: true}},
+		{math.Copysign(0, -1), class{zero: true, neg: true}},
+		{math.Inf(1), class{inf: true, pos: true}},
+		{math.Inf(-1), class{inf: true, neg: true}},
+		{math.NaN(), class{nan: true}},
+	} {
+		f := ctx.FromFloat64(test.val, s)
+		got := class{
+			simplifyBool(t, ctx, f.IsNormal()),
+			simplifyBool(t, ctx, f.IsSubnormal()),
+			simplifyBool(t, ctx, f.IsZero()),
+			simplifyBool(t, ctx, f.IsInfinite()),
+			simplifyBool(t, ctx, f.IsNaN()),
+			simplifyBool(t, ctx, f.IsNegative()),
+			simplifyBool(t, ctx, f.IsPositive()),
+		}
+		if got != test.want {
+			t.Errorf("classifying %v: got %+v, want %+v", test.val, got, test.want)
+		}
+	}
+}
+
+func TestFloatConversions(t *testing.T) {
+	ctx := NewContext(nil)
+	f32, f64 := ctx.FloatSort(8, 24), ctx.FloatSort(11, 53)
+	bv32 := ctx.BVSort(32)
+
+	for _, x := range []int64{0, 1, -1, 1 << 24, -(1 << 24) - 1, math.MaxInt32} {
+		// Signed and unsigned BV conversions.
+		bv := ctx.FromInt(x, bv32).(BV)
+		want := ctx.FromFloat64(float64(int32(x)), f64)
+		if got := bv.SToFloat(f64); !simplifyBool(t, ctx, got.Eq(want)) {
+			t.Errorf("%d.SToFloat() = %s, want %s", x, ctx.Simplify(got, nil), want)
+		}
+		want = ctx.FromFloat64(float64(uint32(x)), f64)
+		if got := bv.UToFloat(f64); !simplifyBool(t, ctx, got.Eq(want)) {
+			t.Errorf("%d.UToFloat() = %s, want %s", x, ctx.Simplify(got, nil), want)
+		}
+		if got := bv.SToFloat(f64).ToSBV(32); !simplifyBool(t, ctx, got.Eq(bv)) {
+			t.Errorf("%d.SToFloat().ToSBV() = %s", x, ctx.Simplify(got, nil))
+		}
+
+		// Int and Real conversions, including rounding to
+		// float32.
+		want = ctx.FromFloat32(float32(x), f32)
+		if got := ctx.FromInt(x, ctx.IntSort()).(Int).ToFloat(f32); !simplifyBool(t, ctx, got.Eq(want)) {
+			t.Errorf("%d.ToFloat() = %s, want %s", x, ctx.Simplify(got, nil), want)
+		}
+		if got := want.ToReal().ToFloat(f32); !simplifyBool(t, ctx, got.Eq(want)) {
+			t.Errorf("%s.ToReal().ToFloat() = %s", want, ctx.Simplify(got, nil))
+		}
+
+		// Float to float conversions.
+		if got := want.ToFloat(f64).ToFloat(f32); !simplifyBool(t, ctx, got.Eq(want)) {
+			t.Errorf("%s.ToFloat(f64).ToFloat(f32) = %s", want, ctx.Simplify(got, nil))
+		}
+	}
+}
+
+func TestFloatAsFloat64(t *testing.T) {
+	ctx := NewContext(nil)
+	f32, f64 := ctx.FloatSort(8, 24), ctx.FloatSort(11, 53)
+	for _, test := range []float64{0, math.Copysign(0, -1), 1.5, -42,
+		math.Inf(1), math.Inf(-1), math.MaxFloat64,
+		math.SmallestNonzeroFloat64, math.NaN()} {
+		v, isLit, ok := ctx.FromFloat64(test, f64).AsFloat64()
+		if !isLit || !ok || math.Float64bits(v) != math.Float64bits(test) && !(math.IsNaN(v) && math.IsNaN(test)) {
+			t.Errorf("%v.AsFloat64() = %v, %v, %v", test, v, isLit, ok)
+		}
+	}
+
+	// 0.1 is not exact as a float32, and float32 0.1 is exact as a
+	// float64.
+	v32, isLit, ok := ctx.FromFloat64(0.1, f64).AsFloat32()
+	if v32 != 0.1 || !isLit || ok {
+		t.Errorf("float64 0.1.AsFloat32() = %v, %v, %v; want 0.1, true, false", v32, isLit, ok)
+	}
+	v64, isLit, ok := ctx.FromFloat32(0.1, f32).AsFloat64()
+	if v64 != float64(float32(0.1)) || !isLit || !ok {
+		t.Errorf("float32 0.1.AsFloat64() = %v, %v, %v", v64, isLit, ok)
+	}
+
+	if _, isLit, _ := ctx.FloatConst("x", 11, 53).AsFloat64(); isLit {
+		t.Errorf("constant x is a literal")
+	}
+}
+
+func TestFloatIEEEBV(t *testing.T) {
+	ctx := NewContext(nil)
+	f64, bv64 := ctx.FloatSort(11, 53), ctx.BVSort(64)
+	for _, test := range []float64{0, math.Copysign(0, -1), 1, -2.5,
+		math.Inf(1), math.Inf(-1), math.MaxFloat64,
+		math.SmallestNonzeroFloat64} {
+		f := ctx.FromFloat64(test, f64)
+		bits := ctx.Simplify(f.ToIEEEBV(), nil).(BV)
+		got, _, _ := bits.AsUint64()
+		if want := math.Float64bits(test); got != want {
+			t.Errorf("%v.ToIEEEBV() = %#x, want %#x", test, got, want)
+		}
+
+		back := ctx.FromBigInt(new(big.Int).SetUint64(math.Float64bits(test)), bv64).(BV).IEEEToFloat(f64)
+		if !simplifyBool(t, ctx, back.Eq(f)) {
+			t.Errorf("IEEEToFloat(%#x) = %s, want %v", math.Float64bits(test), ctx.Simplify(back, nil), test)
+		}
+	}
+
+	// The simplifier may leave NaN conversions alone, so check
+	// the representation the solver picks.
+	nan := ctx.FromFloat64(math.NaN(), f64)
+	x := ctx.BVConst("x", 64)
+	s := NewSolver(ctx)
+	s.Assert(x.Eq(nan.ToIEEEBV()))
+	if sat, err := s.Check(); !sat {
+		t.Fatalf("%s not satisfiable: %v", s, err)
+	}
+	bits, _, _ := s.Model().Eval(x, true).(BV).AsUint64()
+	if !math.IsNaN(math.Float64frombits(bits)) {
+		t.Errorf("NaN.ToIEEEBV() = %#x, which is not a NaN", bits)
+	}
+}
+
+func TestFloatGoSorts(t *testing.T) {
+	ctx := NewContext(nil)
+	if e, s := ctx.Float32Sort().FloatSize(); e != 8 || s != 24 {
+		t.Errorf("Float32Sort() has size (%d, %d), want (8, 24)", e, s)
+	}
+	if e, s := ctx.Float64Sort().FloatSize(); e != 11 || s != 53 {
+		t.Errorf("Float64Sort() has size (%d, %d), want (11, 53)", e, s)
+	}
+
+	x := ctx.FromFloat64(0.1, ctx.Float64Sort())
+	if got, _, _ := x.AsFloat64(); got != 0.1 {
+		t.Errorf("FromFloat64(0.1) = %v", got)
+	}
+	y := ctx.FromFloat32(0.1, ctx.Float32Sort())
+	if got, _, _ := y.AsFloat32(); got != float32(0.1) {
+		t.Errorf("FromFloat32(0.1) = %v", got)
+	}
+}
+
+func TestFloatMath(t *testing.T) {
+	ctx := NewContext(nil)
+	s := ctx.Float64Sort()
+	lit := func(x float64) Float { return ctx.FromFloat64(x, s) }
+	eval := func(x Float) float64 {
+		t.Helper()
+		val, isLit, _ := ctx.Simplify(x, nil).(Float).AsFloat64()
+		if !isLit {
+			t.Fatalf("%s did not simplify to a literal", x)
+		}
+		return val
+	}
+
+	// Choose a, b, c so fma(a, b, c) != a*b+c in float64.
+	a, b, c := 1+0x1p-30, 1-0x1p-30, -1.0
+	if got, want := eval(lit(a).MulAdd(lit(b), lit(c))), math.FMA(a, b, c); got != want {
+		t.Errorf("MulAdd(%v, %v, %v) = %v, want %v", a, b, c, got, want)
+	}
+	for _, x := range []float64{2, 0.5, 1e300} {
+		if got, want := eval(lit(x).Sqrt()), math.Sqrt(x); got != want {
+			t.Errorf("Sqrt(%v) = %v, want %v", x, got, want)
+		}
+	}
+	if !math.IsNaN(eval(lit(-1).Sqrt())) {
+		t.Errorf("Sqrt(-1) is not NaN")
+	}
+	for _, x := range [][2]float64{{5, 3}, {7, 2}, {5, 2}, {-7.5, 2}, {1e300, 3}} {
+		if got, want := eval(lit(x[0]).Rem(lit(x[1]))), math.Remainder(x[0], x[1]); got != want {
+			t.Errorf("Rem(%v, %v) = %v, want %v", x[0], x[1], got, want)
+		}
+	}
+}
+
+func TestFloatAsFloatBits(t *testing.T) {
+	ctx := NewContext(nil)
+	s := NewSolver(ctx)
+	x, y := ctx.Const("x", ctx.Float64Sort()).(Float), ctx.Const("y", ctx.Float32Sort()).(Float)
+	for _, want := range []uint64{0, 1 << 63, 1, 0x000fffffffffffff,
+		0x7ff0000000000000, 0xfff0000000000000, 0x3fb999999999999a} {
+		// Decode values produced by the solver, not just
+		// literals we constructed.
+		s.Push()
+		s.Assert(x.ToIEEEBV().Eq(ctx.FromBigInt(new(big.Int).SetUint64(want), ctx.BVSort(64)).(BV)))
+		s.Assert(y.Eq(x.ToFloat(ctx.Float32Sort())))
+		if sat, err := s.Check(); !sat {
+			t.Fatalf("%s not satisfiable: %v", s, err)
+		}
+		m := s.Model()
+		bits, isLit, ok := m.Eval(x, true).(Float).AsFloat64Bits()
+		if bits != want || !isLit || !ok {
+			t.Errorf("AsFloat64Bits() = %#x, %v, %v; want %#x, true, true", bits, isLit, ok, want)
+		}
+		want32 := math.Float32bits(float32(math.Float64frombits(want)))
+		bits32, isLit, ok := m.Eval(y, true).(Float).AsFloat32Bits()
+		if bits32 != want32 || !isLit || !ok {
+			t.Errorf("AsFloat32Bits() = %#x, %v, %v; want %#x, true, true", bits32, isLit, ok, want32)
+		}
+		s.Pop()
+	}
+
+	bits, _, _ := ctx.FromFloat64(math.NaN(), ctx.Float64Sort()).AsFloat64Bits()
+	if bits != math.Float64bits(math.NaN()) {
+		t.Errorf("NaN.AsFloat64Bits() = %#x, want %#x", bits, math.Float64bits(math.NaN()))
+	}
+	if _, isLit, ok := ctx.FromFloat64(1, ctx.Float32Sort()).AsFloat64Bits(); !isLit || ok {
+		t.Errorf("float32 AsFloat64Bits() = _, %v, %v; want true, false", isLit, ok)
+	}
+}
@@ -39,7 +39,7 @@ func wrapFuncDecl(ctx *Context, c C.Z3_func_decl) FuncDecl {
 	impl := &funcDeclImpl{ctx, c}
 	C.Z3_inc_ref(ctx.c, C.Z3_func_decl_to_ast(ctx.c, c))
 	runtime.SetFinalizer(impl, func(impl *funcDeclImpl) {
-		impl.ctx.do(func() {
+		impl.ctx.releaseAST(func() {
 			C.Z3_dec_ref(impl.ctx.c, C.Z3_func_decl_to_ast(impl.ctx.c, impl.c))
 		})
 	})
@@ -111,6 +111,18 @@ func (f FuncDecl) String() string {
 	return res
 }
 
+// Name returns the name of f, such as "+" for an interpreted function
+// or the name passed to Context.FuncDecl for an uninterpreted one.
+func (f FuncDecl) Name() string {
+	var res string
+	f.ctx.do(func() {
+		sym := C.Z3_get_decl_name(f.ctx.c, f.c)
+		res = C.GoString(C.Z3_get_symbol_string(f.ctx.c, sym))
+	})
+	runtime.KeepAlive(f)
+	return res
+}
+
 // AsAST returns the AST representation of f.
 func (f FuncDecl) AsAST() AST {
 	var ast AST
@@ -22,3 +22,23 @@ func TestFuncDecl(t *testing.T) {
 		t.Errorf("%s satisfiable: %s", s, err)
 	}
 }
+
+func TestFuncDeclName(t *testing.T) {
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	x := ctx.IntConst("x")
+	fn := ctx.FuncDecl("f", []Sort{ints}, ints)
+	for _, test := range []struct {
+		decl FuncDecl
+		want string
+	}{
+		{fn, "f"},
+		{x.Decl(), "x"},
+		{x.Add(x).Decl(), "+"},
+		{ctx.BVConst("b", 8).Extract(3, 0).Decl(), "extract"},
+	} {
+		if got := test.decl.Name(); got != test.want {
+			t.Errorf("%v.Name() = %q, want %q", test.decl, got, test.want)
+		}
+	}
+}
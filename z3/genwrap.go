@@ -102,6 +102,12 @@ func (l %s) NE(r %s) Bool {
 }
 
 `, *flagType, *flagType)
+
+	fmt.Fprintln(w, "// If returns a Value equal to l if cond is true, otherwise r.")
+	fmt.Fprintln(w, "//")
+	fmt.Fprintln(w, "// This is a typed form of Bool.IfThenElse.")
+	dir = parseDirective(strings.Fields("//wrap:expr If l cond:Bool r : Z3_mk_ite cond l r"))
+	genMethod(w, dir, "")
 }
 
 type directive struct {
@@ -193,12 +199,14 @@ func parseDirective(parts []string) *directive {
 			os.Exit(1)
 		}
 		if cTyp == "" && arg.goTyp == "Value" {
-			arg.cExpr = "%s.impl().c" // Value interface
+			arg.cExpr = "%s.impl().cref()" // Value interface
 		} else if cTyp == "" && arg.goTyp == "RoundingMode" {
 			arg.setup = "rmc := " + arg.name + ".ast(ctx)"
 			arg.cCode = "rmc.c"
+		} else if cTyp == "" && arg.goTyp == "Sort" {
+			arg.cExpr = "%s.c"
 		} else if cTyp == "" {
-			arg.cExpr = "%s.c" // expr wrapper
+			arg.cExpr = "%s.cref()" // expr wrapper
 		} else {
 			arg.cExpr = "C." + cTyp + "(%s)" // basic type
 		}
@@ -213,17 +221,14 @@ func parseDirective(parts []string) *directive {
 }
 
 func process(w *bytes.Buffer, line []byte, doc [][]byte, label string) {
-	if !bytes.Contains(line, []byte("//wrap:expr")) {
+	if !bytes.Contains(line, []byte("//wrap:")) {
 		return
 	}
 	parts := strings.Fields(string(line))
-	if parts[0] != "//wrap:expr" {
+	if parts[0] != "//wrap:expr" && parts[0] != "//wrap:match" {
 		return
 	}
 
-	// Found wrap directive.
-	dir := parseDirective(parts)
-
 	// Function documentation.
 	if len(doc) > 0 && string(doc[len(doc)-1]) == "//" {
 		doc = doc[:len(doc)-1]
@@ -232,9 +237,77 @@ func process(w *bytes.Buffer, line []byte, doc [][]byte, label string) {
 		fmt.Fprintf(w, "%s\n", line)
 	}
 
+	if parts[0] == "//wrap:match" {
+		genMatch(w, parts, label)
+		return
+	}
+
+	// Found wrap directive.
+	dir := parseDirective(parts)
 	genMethod(w, dir, label)
 }
 
+// genMatch generates a function that destructures an application of
+// a Z3 operator. The directive has the form
+//
+//	//wrap:match Name Z3_OP_X arg1[:type] arg2[:type]...
+//
+// Arguments default to the type given by -t. If the last argument
+// ends in "...", it matches any remaining arguments as a slice.
+func genMatch(w *bytes.Buffer, parts []string, label string) {
+	name, op, args := parts[1], parts[2], parts[3:]
+	ddd := ""
+	type res struct{ name, typ string }
+	var results []res
+	for _, a := range args {
+		n, typ := split(a, *flagType)
+		if strings.HasSuffix(n, "...") {
+			n, ddd = n[:len(n)-3], typ
+			typ = "[]" + typ
+		}
+		results = append(results, res{n, typ})
+	}
+
+	fmt.Fprintf(w, "func %s(x Value) (", name)
+	for _, r := range results {
+		fmt.Fprintf(w, "%s %s, ", r.name, r.typ)
+	}
+	fmt.Fprintf(w, "ok bool) {\n")
+	fmt.Fprintf(w, " // Generated from %s.\n", label)
+	fixed := len(results)
+	cmp := "!="
+	if ddd != "" {
+		fixed--
+		cmp = "<"
+	}
+	if ddd != "" && fixed == 0 {
+		fmt.Fprintf(w, " if !x.impl().isAppOf(C.%s) {\n", op)
+	} else {
+		fmt.Fprintf(w, " if !x.impl().isAppOf(C.%s) || x.NumArgs() %s %d {\n", op, cmp, fixed)
+	}
+	fmt.Fprintf(w, "  return\n")
+	fmt.Fprintf(w, " }\n")
+	// Arg already returns a Value, so only assert concrete types.
+	assert := func(typ string) string {
+		if typ == "Value" {
+			return ""
+		}
+		return ".(" + typ + ")"
+	}
+	for i, r := range results[:fixed] {
+		fmt.Fprintf(w, " %s = x.Arg(%d)%s\n", r.name, i, assert(r.typ))
+	}
+	if ddd != "" {
+		r := results[fixed]
+		fmt.Fprintf(w, " for i, n := %d, x.NumArgs(); i < n; i++ {\n", fixed)
+		fmt.Fprintf(w, "  %s = append(%s, x.Arg(i)%s)\n", r.name, r.name, assert(ddd))
+		fmt.Fprintf(w, " }\n")
+	}
+	fmt.Fprintf(w, " ok = true\n")
+	fmt.Fprintf(w, " return\n")
+	fmt.Fprintf(w, "}\n\n")
+}
+
 func genMethod(w *bytes.Buffer, dir *directive, label string) {
 	// Function declaration.
 	fmt.Fprintf(w, "func (%s %s) %s(", dir.goArgs[0].name, dir.goArgs[0].goTyp, dir.goFn)
@@ -260,10 +333,6 @@ func genMethod(w *bytes.Buffer, dir *directive, label string) {
 		ddd := arg.name
 		ddd = ddd[:len(ddd)-3]
 		fmt.Fprintf(w, " cargs := make([]C.Z3_ast, len(%s)+%d)\n", ddd, len(dir.cArgs)-1)
-		for i, arg := range dir.cArgs[:len(dir.cArgs)-1] {
-			fmt.Fprintf(w, " cargs[%d] = %s\n", i, arg.c(arg.name))
-		}
-		fmt.Fprintf(w, " for i, arg := range %s { cargs[i+%d] = %s }\n", ddd, len(dir.cArgs)-1, arg.c("arg"))
 	}
 
 	if dir.hasRM {
@@ -280,6 +349,17 @@ func genMethod(w *bytes.Buffer, dir *directive, label string) {
 
 	// Construct the AST.
 	fmt.Fprintf(w, " val := wrapValue(ctx, func() C.Z3_ast {\n")
+	if dir.isDDD {
+		// Fill the C array with the lock held so released
+		// arguments are caught.
+		arg := dir.cArgs[len(dir.cArgs)-1]
+		ddd := arg.name
+		ddd = ddd[:len(ddd)-3]
+		for i, arg := range dir.cArgs[:len(dir.cArgs)-1] {
+			fmt.Fprintf(w, "  cargs[%d] = %s\n", i, arg.c(arg.name))
+		}
+		fmt.Fprintf(w, "  for i, arg := range %s { cargs[i+%d] = %s }\n", ddd, len(dir.cArgs)-1, arg.c("arg"))
+	}
 	fmt.Fprintf(w, "  return C.%s(ctx.c", dir.cFn)
 	if !dir.isDDD {
 		for _, a := range dir.cArgs {
@@ -10,7 +10,11 @@ package z3
 #include <stdlib.h>
 */
 import "C"
-import "math/big"
+
+import (
+	"math/big"
+	"runtime"
+)
 
 // Int is a symbolic value representing an integer with infinite precision.
 //
@@ -27,7 +31,9 @@ func init() {
 func (ctx *Context) IntSort() Sort {
 	var sort Sort
 	ctx.do(func() {
-		sort = wrapSort(ctx, C.Z3_mk_int_sort(ctx.c), KindInt)
+		sort = ctx.cachedSort(&ctx.cache.intSort, KindInt, func() C.Z3_sort {
+			return C.Z3_mk_int_sort(ctx.c)
+		})
 	})
 	return sort
 }
@@ -56,6 +62,37 @@ func (lit Int) AsBigInt() (val *big.Int, isConst bool) {
 	return lit.asBigInt()
 }
 
+// ToFloat converts l into a floating-point number of sort s.
+//
+// If necessary, the result will be rounded according to the current
+// rounding mode.
+func (l Int) ToFloat(s Sort) Float {
+	return l.ToReal().ToFloat(s)
+}
+
+// Exp returns l raised to the power r.
+//
+// If r is negative, or l and r are both 0, the result is
+// unspecified.
+func (l Int) Exp(r Int) Int {
+	val := wrapValue(l.ctx, func() C.Z3_ast {
+		return C.Z3_mk_power(l.ctx.c, l.c, r.c)
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	// Some versions of Z3 give integer powers sort Real.
+	if val.Sort().Kind() == KindReal {
+		return Real(val).ToInt()
+	}
+	return Int(val)
+}
+
+// Abs returns the absolute value of l.
+func (l Int) Abs() Int {
+	zero := l.ctx.FromInt(0, l.Sort()).(Int)
+	return l.If(l.GE(zero), l.Neg())
+}
+
 //go:generate go run genwrap.go -t Int $GOFILE intreal.go
 
 // Div returns the floor of l / r.
@@ -82,10 +119,33 @@ func (lit Int) AsBigInt() (val *big.Int, isConst bool) {
 //
 //wrap:expr Rem Z3_mk_rem l r
 
+// Divides returns a Bool that is true if l evenly divides r.
+//
+// l must be a positive integer literal. This is the SMT-LIB
+// (_ divisible l) predicate.
+//
+//wrap:expr Divides:Bool Z3_mk_divides l r
+
 // ToReal converts l to sort Real.
 //
 //wrap:expr ToReal:Real Z3_mk_int2real l
 
 // ToBV converts l to a bit-vector of width bits.
 //
+// The result is l modulo 2^bits, so negative values of l are
+// represented in two's complement. This is the inverse of BV.UToInt
+// and BV.SToInt for values of l that fit in bits bits.
+//
 //wrap:expr ToBV:BV l bits:int : Z3_mk_int2bv bits:unsigned l
+
+// ToString converts l to its decimal string representation.
+//
+// If l is negative, the result is the empty string.
+//
+//wrap:expr ToString:String Z3_mk_int_to_str l
+
+// CodeToString returns the single character string whose code point
+// is l. If l is not a valid code point, the result is the empty
+// string.
+//
+//wrap:expr CodeToString:String Z3_mk_string_from_code l
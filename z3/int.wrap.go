@@ -15,7 +15,7 @@ import "C"
 func (l Int) Eq(r Int) Bool {
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_eq(ctx.c, l.c, r.c)
+		return C.Z3_mk_eq(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -27,6 +27,20 @@ func (l Int) NE(r Int) Bool {
 	return l.ctx.Distinct(l, r)
 }
 
+// If returns a Value equal to l if cond is true, otherwise r.
+//
+// This is a typed form of Bool.IfThenElse.
+func (l Int) If(cond Bool, r Int) Int {
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_ite(ctx.c, cond.cref(), l.cref(), r.cref())
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(cond)
+	runtime.KeepAlive(r)
+	return Int(val)
+}
+
 // Div returns the floor of l / r.
 //
 // If r is 0, the result is unconstrained.
@@ -34,10 +48,10 @@ func (l Int) NE(r Int) Bool {
 // Note that this differs from Go division: Go rounds toward zero
 // (truncated division), whereas this rounds toward -inf.
 func (l Int) Div(r Int) Int {
-	// Generated from int.go:68.
+	// Generated from int.go:105.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_div(ctx.c, l.c, r.c)
+		return C.Z3_mk_div(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -48,10 +62,10 @@ func (l Int) Div(r Int) Int {
 //
 // The sign of the result follows the sign of r.
 func (l Int) Mod(r Int) Int {
-	// Generated from int.go:74.
+	// Generated from int.go:111.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_mod(ctx.c, l.c, r.c)
+		return C.Z3_mk_mod(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -65,48 +79,93 @@ func (l Int) Mod(r Int) Int {
 // Note that this differs subtly from Go's remainder operator because
 // this is based floored division rather than truncated division.
 func (l Int) Rem(r Int) Int {
-	// Generated from int.go:83.
+	// Generated from int.go:120.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_rem(ctx.c, l.c, r.c)
+		return C.Z3_mk_rem(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
 	return Int(val)
 }
 
+// Divides returns a Bool that is true if l evenly divides r.
+//
+// l must be a positive integer literal. This is the SMT-LIB
+// (_ divisible l) predicate.
+func (l Int) Divides(r Int) Bool {
+	// Generated from int.go:127.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_divides(ctx.c, l.cref(), r.cref())
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return Bool(val)
+}
+
 // ToReal converts l to sort Real.
 func (l Int) ToReal() Real {
-	// Generated from int.go:87.
+	// Generated from int.go:131.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_int2real(ctx.c, l.c)
+		return C.Z3_mk_int2real(ctx.c, l.cref())
 	})
 	runtime.KeepAlive(l)
 	return Real(val)
 }
 
 // ToBV converts l to a bit-vector of width bits.
+//
+// The result is l modulo 2^bits, so negative values of l are
+// represented in two's complement. This is the inverse of BV.UToInt
+// and BV.SToInt for values of l that fit in bits bits.
 func (l Int) ToBV(bits int) BV {
-	// Generated from int.go:91.
+	// Generated from int.go:139.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_int2bv(ctx.c, C.unsigned(bits), l.c)
+		return C.Z3_mk_int2bv(ctx.c, C.unsigned(bits), l.cref())
 	})
 	runtime.KeepAlive(l)
 	return BV(val)
 }
 
+// ToString converts l to its decimal string representation.
+//
+// If l is negative, the result is the empty string.
+func (l Int) ToString() String {
+	// Generated from int.go:145.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_int_to_str(ctx.c, l.cref())
+	})
+	runtime.KeepAlive(l)
+	return String(val)
+}
+
+// CodeToString returns the single character string whose code point
+// is l. If l is not a valid code point, the result is the empty
+// string.
+func (l Int) CodeToString() String {
+	// Generated from int.go:151.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_string_from_code(ctx.c, l.cref())
+	})
+	runtime.KeepAlive(l)
+	return String(val)
+}
+
 // Add returns the sum l + r[0] + r[1] + ...
 func (l Int) Add(r ...Int) Int {
 	// Generated from intreal.go:12.
 	ctx := l.ctx
 	cargs := make([]C.Z3_ast, len(r)+1)
-	cargs[0] = l.c
-	for i, arg := range r {
-		cargs[i+1] = arg.c
-	}
 	val := wrapValue(ctx, func() C.Z3_ast {
+		cargs[0] = l.cref()
+		for i, arg := range r {
+			cargs[i+1] = arg.cref()
+		}
 		return C.Z3_mk_add(ctx.c, C.uint(len(cargs)), &cargs[0])
 	})
 	runtime.KeepAlive(&cargs[0])
@@ -118,11 +177,11 @@ func (l Int) Mul(r ...Int) Int {
 	// Generated from intreal.go:16.
 	ctx := l.ctx
 	cargs := make([]C.Z3_ast, len(r)+1)
-	cargs[0] = l.c
-	for i, arg := range r {
-		cargs[i+1] = arg.c
-	}
 	val := wrapValue(ctx, func() C.Z3_ast {
+		cargs[0] = l.cref()
+		for i, arg := range r {
+			cargs[i+1] = arg.cref()
+		}
 		return C.Z3_mk_mul(ctx.c, C.uint(len(cargs)), &cargs[0])
 	})
 	runtime.KeepAlive(&cargs[0])
@@ -134,11 +193,11 @@ func (l Int) Sub(r ...Int) Int {
 	// Generated from intreal.go:20.
 	ctx := l.ctx
 	cargs := make([]C.Z3_ast, len(r)+1)
-	cargs[0] = l.c
-	for i, arg := range r {
-		cargs[i+1] = arg.c
-	}
 	val := wrapValue(ctx, func() C.Z3_ast {
+		cargs[0] = l.cref()
+		for i, arg := range r {
+			cargs[i+1] = arg.cref()
+		}
 		return C.Z3_mk_sub(ctx.c, C.uint(len(cargs)), &cargs[0])
 	})
 	runtime.KeepAlive(&cargs[0])
@@ -150,30 +209,18 @@ func (l Int) Neg() Int {
 	// Generated from intreal.go:24.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_unary_minus(ctx.c, l.c)
-	})
-	runtime.KeepAlive(l)
-	return Int(val)
-}
-
-// Exp returns lᶠ.
-func (l Int) Exp(r Int) Int {
-	// Generated from intreal.go:28.
-	ctx := l.ctx
-	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_power(ctx.c, l.c, r.c)
+		return C.Z3_mk_unary_minus(ctx.c, l.cref())
 	})
 	runtime.KeepAlive(l)
-	runtime.KeepAlive(r)
 	return Int(val)
 }
 
 // LT returns l < r.
 func (l Int) LT(r Int) Bool {
-	// Generated from intreal.go:32.
+	// Generated from intreal.go:28.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_lt(ctx.c, l.c, r.c)
+		return C.Z3_mk_lt(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -182,10 +229,10 @@ func (l Int) LT(r Int) Bool {
 
 // LE returns l <= r.
 func (l Int) LE(r Int) Bool {
-	// Generated from intreal.go:36.
+	// Generated from intreal.go:32.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_le(ctx.c, l.c, r.c)
+		return C.Z3_mk_le(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -194,10 +241,10 @@ func (l Int) LE(r Int) Bool {
 
 // GT returns l > r.
 func (l Int) GT(r Int) Bool {
-	// Generated from intreal.go:40.
+	// Generated from intreal.go:36.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_gt(ctx.c, l.c, r.c)
+		return C.Z3_mk_gt(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -206,10 +253,10 @@ func (l Int) GT(r Int) Bool {
 
 // GE returns l >= r.
 func (l Int) GE(r Int) Bool {
-	// Generated from intreal.go:44.
+	// Generated from intreal.go:40.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_ge(ctx.c, l.c, r.c)
+		return C.Z3_mk_ge(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -0,0 +1,102 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestIntDivides(t *testing.T) {
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	three := ctx.FromInt(3, ints).(Int)
+	for _, test := range []struct {
+		val  int64
+		want bool
+	}{
+		{12, true}, {13, false}, {0, true}, {-9, true}, {-10, false},
+	} {
+		got := simplifyBool(t, ctx, three.Divides(ctx.FromInt(test.val, ints).(Int)))
+		if got != test.want {
+			t.Errorf("3 divides %d: got %v, want %v", test.val, got, test.want)
+		}
+	}
+
+	// x divisible by 3 and by 4, but not by 12, is unsatisfiable.
+	x := ctx.IntConst("x")
+	s := NewSolver(ctx)
+	s.Assert(three.Divides(x))
+	s.Assert(ctx.FromInt(4, ints).(Int).Divides(x))
+	s.Assert(ctx.FromInt(12, ints).(Int).Divides(x).Not())
+	if sat, err := s.Check(); sat || err != nil {
+		t.Fatalf("%s satisfiable: %v", s, err)
+	}
+}
+
+func TestIntToBV(t *testing.T) {
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	for _, test := range []struct {
+		val    int64
+		u64    uint64
+		signed int64
+	}{
+		{5, 5, 5}, {255, 255, -1}, {256, 0, 0}, {-1, 255, -1}, {-128, 128, -128},
+	} {
+		bv := ctx.FromInt(test.val, ints).(Int).ToBV(8)
+		if got, _, _ := ctx.Simplify(bv, nil).(BV).AsUint64(); got != test.u64 {
+			t.Errorf("%d.ToBV(8) = %d, want %d", test.val, got, test.u64)
+		}
+		if got, _, _ := ctx.Simplify(bv.SToInt(), nil).(Int).AsInt64(); got != test.signed {
+			t.Errorf("%d.ToBV(8).SToInt() = %d, want %d", test.val, got, test.signed)
+		}
+	}
+
+	// ToBV and UToInt round trip in range.
+	x := ctx.IntConst("x")
+	s := NewSolver(ctx)
+	s.Assert(x.GE(ctx.FromInt(0, ints).(Int)))
+	s.Assert(x.LT(ctx.FromInt(256, ints).(Int)))
+	s.Assert(x.ToBV(8).UToInt().NE(x))
+	if sat, err := s.Check(); sat || err != nil {
+		t.Fatalf("%s satisfiable: %v", s, err)
+	}
+}
+
+func TestIntExp(t *testing.T) {
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	two := ctx.FromInt(2, ints).(Int)
+	e := two.Exp(ctx.FromInt(10, ints).(Int))
+	if e.Sort().Kind() != KindInt {
+		t.Fatalf("2^10 has sort %s, want Int", e.Sort())
+	}
+	if got, _, _ := ctx.Simplify(e, nil).(Int).AsInt64(); got != 1024 {
+		t.Errorf("2^10 = %d, want 1024", got)
+	}
+
+	x := ctx.IntConst("x")
+	s := NewSolver(ctx)
+	s.Assert(x.Exp(two).Eq(ctx.FromInt(49, ints).(Int)))
+	s.Assert(x.GT(ctx.FromInt(0, ints).(Int)))
+	if sat, err := s.Check(); !sat {
+		t.Fatalf("%s not satisfiable: %v", s, err)
+	}
+	if got, _, _ := s.Model().Eval(x, true).(Int).AsInt64(); got != 7 {
+		t.Errorf("x^2 = 49: got x = %d, want 7", got)
+	}
+}
+
+func TestIntAbs(t *testing.T) {
+	ctx := NewContext(nil)
+	for _, v := range []int64{-7, 0, 7} {
+		x := ctx.FromInt(v, ctx.IntSort()).(Int)
+		want := v
+		if want < 0 {
+			want = -want
+		}
+		if got, _, _ := ctx.Simplify(x.Abs(), nil).(Int).AsInt64(); got != want {
+			t.Errorf("Abs(%d) = %d, want %d", v, got, want)
+		}
+	}
+}
@@ -23,10 +23,6 @@ package z3
 //
 //wrap:expr Neg Z3_mk_unary_minus l
 
-// Exp returns lᶠ.
-//
-//wrap:expr Exp Z3_mk_power l r
-
 // LT returns l < r.
 //
 //wrap:expr LT:Bool Z3_mk_lt l r
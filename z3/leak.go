@@ -0,0 +1,195 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// leakStackDepth is the maximum number of frames recorded for the
+// creation of each tracked AST.
+const leakStackDepth = 32
+
+type leakStack [leakStackDepth]uintptr
+
+// leakTracker records the creation site of each live AST in a
+// Context. ASTs may be released without the Context's lock (see
+// Context.releaseAST), so leakTracker has its own lock.
+type leakTracker struct {
+	sync.Mutex
+
+	// live maps the address of each tracked astImpl to its
+	// creation site. This must not hold a pointer to the astImpl,
+	// or it would never be finalized.
+	live  map[uintptr]*leakSite
+	sites map[leakStack]*leakSite
+}
+
+type leakSite struct {
+	stack leakStack
+	count int
+}
+
+func (t *leakTracker) add(impl *astImpl) {
+	var stack leakStack
+	// Skip runtime.Callers, add, and wrapAST.
+	runtime.Callers(3, stack[:])
+	t.Lock()
+	defer t.Unlock()
+	site := t.sites[stack]
+	if site == nil {
+		site = &leakSite{stack: stack}
+		t.sites[stack] = site
+	}
+	site.count++
+	t.live[uintptr(unsafe.Pointer(impl))] = site
+}
+
+func (t *leakTracker) remove(impl *astImpl) {
+	key := uintptr(unsafe.Pointer(impl))
+	t.Lock()
+	defer t.Unlock()
+	site := t.live[key]
+	if site == nil {
+		// Created before tracking was enabled.
+		return
+	}
+	delete(t.live, key)
+	if site.count--; site.count == 0 {
+		delete(t.sites, site.stack)
+	}
+}
+
+// TrackASTs enables or disables tracking of live ASTs in ctx. While
+// tracking is enabled, ctx records the call stack that created each
+// AST (including each Value) until that AST is released. LeakSites
+// reports where the ASTs that are still live were created, which
+// helps find code that keeps expressions alive by accident.
+//
+// Tracking is expensive and is intended for debugging. Only ASTs
+// created while tracking is enabled are tracked, and disabling it
+// discards all records.
+func (ctx *Context) TrackASTs(enable bool) {
+	ctx.checkNotInCallback("TrackASTs")
+	ctx.lock.Lock()
+	defer ctx.lock.Unlock()
+	ctx.interruptLock.Lock()
+	defer ctx.interruptLock.Unlock()
+	switch {
+	case !enable:
+		ctx.leaks = nil
+	case ctx.leaks == nil && !ctx.closed:
+		ctx.leaks = &leakTracker{
+			live:  make(map[uintptr]*leakSite),
+			sites: make(map[leakStack]*leakSite),
+		}
+	}
+}
+
+// A LeakSite is a place in the program that created ASTs that are
+// still live.
+type LeakSite struct {
+	// Count is the number of live ASTs created at this site.
+	Count int
+
+	// Stack is the call stack that created the ASTs, innermost
+	// frame first. Frames inside this package are omitted.
+	Stack []runtime.Frame
+}
+
+// String returns the site's count followed by its stack, one frame
+// per line, in the style of a goroutine traceback.
+func (s LeakSite) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d live ASTs created at:\n", s.Count)
+	for _, f := range s.Stack {
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", f.Function, f.File, f.Line)
+	}
+	return b.String()
+}
+
+// LeakSites returns up to n sites that created the most live ASTs
+// tracked by ctx, in decreasing order of count. If n <= 0, it returns
+// all sites. It returns nil if tracking is not enabled.
+//
+// ASTs are released by finalizers, which run some time after the
+// ASTs become unreachable. Hence, counts may include ASTs that are
+// garbage but have not yet been finalized. Calling runtime.GC first
+// reduces this noise.
+func (ctx *Context) LeakSites(n int) []LeakSite {
+	var t *leakTracker
+	ctx.withLock(func() {
+		t = ctx.leaks
+	})
+	var sites []*leakSite
+	if t != nil {
+		t.Lock()
+		for _, site := range t.sites {
+			sites = append(sites, &leakSite{site.stack, site.count})
+		}
+		t.Unlock()
+	}
+
+	sort.Slice(sites, func(i, j int) bool {
+		return sites[i].count > sites[j].count
+	})
+	if n > 0 && len(sites) > n {
+		sites = sites[:n]
+	}
+	var res []LeakSite
+	for _, site := range sites {
+		res = append(res, LeakSite{site.count, leakFrames(site.stack)})
+	}
+	return res
+}
+
+// DumpLeaks writes the top n sites returned by LeakSites to w.
+func (ctx *Context) DumpLeaks(w io.Writer, n int) error {
+	for _, site := range ctx.LeakSites(n) {
+		if _, err := fmt.Fprintf(w, "%s\n", site); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pkgPath is the import path of this package.
+var pkgPath = reflect.TypeOf(AST{}).PkgPath()
+
+// leakFrames symbolizes stack, omitting the leading frames inside
+// this package, other than its tests.
+func leakFrames(stack leakStack) []runtime.Frame {
+	pcs := stack[:]
+	for i, pc := range pcs {
+		if pc == 0 {
+			pcs = pcs[:i]
+			break
+		}
+	}
+	var res []runtime.Frame
+	frames := runtime.CallersFrames(pcs)
+	internal := true
+	for {
+		f, more := frames.Next()
+		inPkg := strings.HasPrefix(f.Function, pkgPath+".") && !strings.HasSuffix(f.File, "_test.go")
+		if !inPkg {
+			internal = false
+		}
+		if !internal {
+			res = append(res, f)
+		}
+		if !more {
+			break
+		}
+	}
+	return res
+}
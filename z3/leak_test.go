@@ -0,0 +1,59 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func leakyConsts(ctx *Context, n int) []Int {
+	var xs []Int
+	for i := 0; i < n; i++ {
+		xs = append(xs, ctx.IntConst(fmt.Sprint("x", i)))
+	}
+	return xs
+}
+
+func TestLeakSites(t *testing.T) {
+	ctx := NewContext(nil)
+	if sites := ctx.LeakSites(0); sites != nil {
+		t.Fatalf("sites without tracking: %v", sites)
+	}
+
+	ctx.TrackASTs(true)
+	xs := leakyConsts(ctx, 10)
+	y := ctx.IntConst("y")
+	sites := ctx.LeakSites(1)
+	if len(sites) != 1 || sites[0].Count != 10 {
+		t.Fatalf("want one site with 10 ASTs, got %v", sites)
+	}
+	if fn := sites[0].Stack[0].Function; !strings.HasSuffix(fn, ".leakyConsts") {
+		t.Errorf("innermost frame is %s, want leakyConsts", fn)
+	}
+
+	for _, x := range xs[:4] {
+		x.Release()
+	}
+	y.Release()
+	if sites := ctx.LeakSites(0); len(sites) != 1 || sites[0].Count != 6 {
+		t.Errorf("after release, want one site with 6 ASTs, got %v", sites)
+	}
+
+	var buf bytes.Buffer
+	if err := ctx.DumpLeaks(&buf, 0); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), "6 live ASTs created at:\n") || !strings.Contains(buf.String(), "leak_test.go") {
+		t.Errorf("unexpected dump:\n%s", buf.String())
+	}
+
+	ctx.TrackASTs(false)
+	if sites := ctx.LeakSites(0); sites != nil {
+		t.Errorf("sites after disabling tracking: %v", sites)
+	}
+}
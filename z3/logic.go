@@ -27,7 +27,9 @@ func init() {
 func (ctx *Context) BoolSort() Sort {
 	var sort Sort
 	ctx.do(func() {
-		sort = wrapSort(ctx, C.Z3_mk_bool_sort(ctx.c), KindBool)
+		sort = ctx.cachedSort(&ctx.cache.boolSort, KindBool, func() C.Z3_sort {
+			return C.Z3_mk_bool_sort(ctx.c)
+		})
 	})
 	return sort
 }
@@ -59,6 +61,49 @@ func (l Bool) AsBool() (val bool, isLiteral bool) {
 	return res == C.Z3_L_TRUE, res != C.Z3_L_UNDEF
 }
 
+// ToBV returns a bit-vector of the given width that is 1 if l is true
+// and 0 otherwise. See also BV.ToBool.
+func (l Bool) ToBV(width int) BV {
+	sort := l.ctx.BVSort(width)
+	one := l.ctx.FromInt(1, sort).(BV)
+	return one.If(l, l.ctx.FromInt(0, sort).(BV))
+}
+
+// Forall returns a Bool that is true if body is true for all values
+// of vars.
+//
+// Each of vars must be a constant, such as those returned by
+// Context.Const. These constants are bound in body.
+func (ctx *Context) Forall(vars []Value, body Bool) Bool {
+	return ctx.quantifier(true, vars, body)
+}
+
+// Exists returns a Bool that is true if body is true for some values
+// of vars.
+//
+// Each of vars must be a constant, such as those returned by
+// Context.Const. These constants are bound in body.
+func (ctx *Context) Exists(vars []Value, body Bool) Bool {
+	return ctx.quantifier(false, vars, body)
+}
+
+func (ctx *Context) quantifier(forall bool, vars []Value, body Bool) Bool {
+	cvars := make([]C.Z3_app, len(vars))
+	res := Bool(wrapValue(ctx, func() C.Z3_ast {
+		for i, v := range vars {
+			cvars[i] = C.Z3_to_app(ctx.c, v.impl().c)
+		}
+		var cvp *C.Z3_app
+		if len(cvars) > 0 {
+			cvp = &cvars[0]
+		}
+		return C.Z3_mk_quantifier_const(ctx.c, boolToZ3(forall), 0, C.uint(len(cvars)), cvp, 0, nil, body.c)
+	}))
+	runtime.KeepAlive(vars)
+	runtime.KeepAlive(body)
+	return res
+}
+
 //go:generate go run genwrap.go -t Bool $GOFILE
 
 // Distinct returns a Value that is true if no two vals are equal.
@@ -75,7 +120,9 @@ func (l Bool) AsBool() (val bool, isLiteral bool) {
 // alt.
 //
 // cons and alt must have the same sort. The result will have the same
-// sort as cons and alt.
+// sort as cons and alt. To get a result of the same type as cons
+// without a type assertion, use the If method of cons, such as
+// Int.If.
 //
 //wrap:expr IfThenElse:Value cond cons:Value alt:Value : Z3_mk_ite cond cons alt
 
@@ -99,3 +146,40 @@ func (l Bool) AsBool() (val bool, isLiteral bool) {
 // Or returns a Value that is true if l or any argument is true.
 //
 //wrap:expr Or Z3_mk_or l r...
+
+// The following functions destructure Values built by the functions
+// above. Each returns ok == false if x is not an application of the
+// corresponding operator.
+
+// MatchEq returns l and r if x is l.Eq(r).
+//
+//wrap:match MatchEq Z3_OP_EQ l:Value r:Value
+
+// MatchDistinct returns vals if x is ctx.Distinct(vals...).
+//
+//wrap:match MatchDistinct Z3_OP_DISTINCT vals...:Value
+
+// MatchNot returns l if x is l.Not().
+//
+//wrap:match MatchNot Z3_OP_NOT l
+
+// MatchITE returns cond, cons, and alt if x is
+// cond.IfThenElse(cons, alt).
+//
+//wrap:match MatchITE Z3_OP_ITE cond cons:Value alt:Value
+
+// MatchImplies returns l and r if x is l.Implies(r).
+//
+//wrap:match MatchImplies Z3_OP_IMPLIES l r
+
+// MatchXor returns l and r if x is l.Xor(r).
+//
+//wrap:match MatchXor Z3_OP_XOR l r
+
+// MatchAnd returns the conjuncts of x if x is an And.
+//
+//wrap:match MatchAnd Z3_OP_AND args...
+
+// MatchOr returns the disjuncts of x if x is an Or.
+//
+//wrap:match MatchOr Z3_OP_OR args...
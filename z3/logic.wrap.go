@@ -15,7 +15,7 @@ import "C"
 func (l Bool) Eq(r Bool) Bool {
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_eq(ctx.c, l.c, r.c)
+		return C.Z3_mk_eq(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -27,16 +27,30 @@ func (l Bool) NE(r Bool) Bool {
 	return l.ctx.Distinct(l, r)
 }
 
+// If returns a Value equal to l if cond is true, otherwise r.
+//
+// This is a typed form of Bool.IfThenElse.
+func (l Bool) If(cond Bool, r Bool) Bool {
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_ite(ctx.c, cond.cref(), l.cref(), r.cref())
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(cond)
+	runtime.KeepAlive(r)
+	return Bool(val)
+}
+
 // Distinct returns a Value that is true if no two vals are equal.
 //
 // All Values must have the same sort.
 func (ctx *Context) Distinct(vals ...Value) Bool {
-	// Generated from logic.go:68.
+	// Generated from logic.go:113.
 	cargs := make([]C.Z3_ast, len(vals)+0)
-	for i, arg := range vals {
-		cargs[i+0] = arg.impl().c
-	}
 	val := wrapValue(ctx, func() C.Z3_ast {
+		for i, arg := range vals {
+			cargs[i+0] = arg.impl().cref()
+		}
 		return C.Z3_mk_distinct(ctx.c, C.uint(len(cargs)), &cargs[0])
 	})
 	runtime.KeepAlive(&cargs[0])
@@ -45,10 +59,10 @@ func (ctx *Context) Distinct(vals ...Value) Bool {
 
 // Not returns the boolean negation of l.
 func (l Bool) Not() Bool {
-	// Generated from logic.go:72.
+	// Generated from logic.go:117.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_not(ctx.c, l.c)
+		return C.Z3_mk_not(ctx.c, l.cref())
 	})
 	runtime.KeepAlive(l)
 	return Bool(val)
@@ -58,12 +72,14 @@ func (l Bool) Not() Bool {
 // alt.
 //
 // cons and alt must have the same sort. The result will have the same
-// sort as cons and alt.
+// sort as cons and alt. To get a result of the same type as cons
+// without a type assertion, use the If method of cons, such as
+// Int.If.
 func (cond Bool) IfThenElse(cons Value, alt Value) Value {
-	// Generated from logic.go:80.
+	// Generated from logic.go:127.
 	ctx := cond.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_ite(ctx.c, cond.c, cons.impl().c, alt.impl().c)
+		return C.Z3_mk_ite(ctx.c, cond.cref(), cons.impl().cref(), alt.impl().cref())
 	})
 	runtime.KeepAlive(cond)
 	runtime.KeepAlive(cons)
@@ -74,10 +90,10 @@ func (cond Bool) IfThenElse(cons Value, alt Value) Value {
 // Iff returns a Value that is true if l and r are equal (l
 // if-and-only-if r).
 func (l Bool) Iff(r Bool) Bool {
-	// Generated from logic.go:85.
+	// Generated from logic.go:132.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_iff(ctx.c, l.c, r.c)
+		return C.Z3_mk_iff(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -86,10 +102,10 @@ func (l Bool) Iff(r Bool) Bool {
 
 // Implies returns a Value that is true if l implies r.
 func (l Bool) Implies(r Bool) Bool {
-	// Generated from logic.go:89.
+	// Generated from logic.go:136.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_implies(ctx.c, l.c, r.c)
+		return C.Z3_mk_implies(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -98,10 +114,10 @@ func (l Bool) Implies(r Bool) Bool {
 
 // Xor returns a Value that is true if l xor r.
 func (l Bool) Xor(r Bool) Bool {
-	// Generated from logic.go:93.
+	// Generated from logic.go:140.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_xor(ctx.c, l.c, r.c)
+		return C.Z3_mk_xor(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -110,14 +126,14 @@ func (l Bool) Xor(r Bool) Bool {
 
 // And returns a Value that is true if l and all arguments are true.
 func (l Bool) And(r ...Bool) Bool {
-	// Generated from logic.go:97.
+	// Generated from logic.go:144.
 	ctx := l.ctx
 	cargs := make([]C.Z3_ast, len(r)+1)
-	cargs[0] = l.c
-	for i, arg := range r {
-		cargs[i+1] = arg.c
-	}
 	val := wrapValue(ctx, func() C.Z3_ast {
+		cargs[0] = l.cref()
+		for i, arg := range r {
+			cargs[i+1] = arg.cref()
+		}
 		return C.Z3_mk_and(ctx.c, C.uint(len(cargs)), &cargs[0])
 	})
 	runtime.KeepAlive(&cargs[0])
@@ -126,16 +142,116 @@ func (l Bool) And(r ...Bool) Bool {
 
 // Or returns a Value that is true if l or any argument is true.
 func (l Bool) Or(r ...Bool) Bool {
-	// Generated from logic.go:101.
+	// Generated from logic.go:148.
 	ctx := l.ctx
 	cargs := make([]C.Z3_ast, len(r)+1)
-	cargs[0] = l.c
-	for i, arg := range r {
-		cargs[i+1] = arg.c
-	}
 	val := wrapValue(ctx, func() C.Z3_ast {
+		cargs[0] = l.cref()
+		for i, arg := range r {
+			cargs[i+1] = arg.cref()
+		}
 		return C.Z3_mk_or(ctx.c, C.uint(len(cargs)), &cargs[0])
 	})
 	runtime.KeepAlive(&cargs[0])
 	return Bool(val)
 }
+
+// MatchEq returns l and r if x is l.Eq(r).
+func MatchEq(x Value) (l Value, r Value, ok bool) {
+	// Generated from logic.go:156.
+	if !x.impl().isAppOf(C.Z3_OP_EQ) || x.NumArgs() != 2 {
+		return
+	}
+	l = x.Arg(0)
+	r = x.Arg(1)
+	ok = true
+	return
+}
+
+// MatchDistinct returns vals if x is ctx.Distinct(vals...).
+func MatchDistinct(x Value) (vals []Value, ok bool) {
+	// Generated from logic.go:160.
+	if !x.impl().isAppOf(C.Z3_OP_DISTINCT) {
+		return
+	}
+	for i, n := 0, x.NumArgs(); i < n; i++ {
+		vals = append(vals, x.Arg(i))
+	}
+	ok = true
+	return
+}
+
+// MatchNot returns l if x is l.Not().
+func MatchNot(x Value) (l Bool, ok bool) {
+	// Generated from logic.go:164.
+	if !x.impl().isAppOf(C.Z3_OP_NOT) || x.NumArgs() != 1 {
+		return
+	}
+	l = x.Arg(0).(Bool)
+	ok = true
+	return
+}
+
+// MatchITE returns cond, cons, and alt if x is
+// cond.IfThenElse(cons, alt).
+func MatchITE(x Value) (cond Bool, cons Value, alt Value, ok bool) {
+	// Generated from logic.go:169.
+	if !x.impl().isAppOf(C.Z3_OP_ITE) || x.NumArgs() != 3 {
+		return
+	}
+	cond = x.Arg(0).(Bool)
+	cons = x.Arg(1)
+	alt = x.Arg(2)
+	ok = true
+	return
+}
+
+// MatchImplies returns l and r if x is l.Implies(r).
+func MatchImplies(x Value) (l Bool, r Bool, ok bool) {
+	// Generated from logic.go:173.
+	if !x.impl().isAppOf(C.Z3_OP_IMPLIES) || x.NumArgs() != 2 {
+		return
+	}
+	l = x.Arg(0).(Bool)
+	r = x.Arg(1).(Bool)
+	ok = true
+	return
+}
+
+// MatchXor returns l and r if x is l.Xor(r).
+func MatchXor(x Value) (l Bool, r Bool, ok bool) {
+	// Generated from logic.go:177.
+	if !x.impl().isAppOf(C.Z3_OP_XOR) || x.NumArgs() != 2 {
+		return
+	}
+	l = x.Arg(0).(Bool)
+	r = x.Arg(1).(Bool)
+	ok = true
+	return
+}
+
+// MatchAnd returns the conjuncts of x if x is an And.
+func MatchAnd(x Value) (args []Bool, ok bool) {
+	// Generated from logic.go:181.
+	if !x.impl().isAppOf(C.Z3_OP_AND) {
+		return
+	}
+	for i, n := 0, x.NumArgs(); i < n; i++ {
+		args = append(args, x.Arg(i).(Bool))
+	}
+	ok = true
+	return
+}
+
+// MatchOr returns the disjuncts of x if x is an Or.
+func MatchOr(x Value) (args []Bool, ok bool) {
+	// Generated from logic.go:185.
+	if !x.impl().isAppOf(C.Z3_OP_OR) {
+		return
+	}
+	for i, n := 0, x.NumArgs(); i < n; i++ {
+		args = append(args, x.Arg(i).(Bool))
+	}
+	ok = true
+	return
+}
@@ -0,0 +1,61 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestMatchLogic(t *testing.T) {
+	ctx := NewContext(nil)
+	a, b, c := ctx.BoolConst("a"), ctx.BoolConst("b"), ctx.BoolConst("c")
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	eq := func(v, w Value) bool { return v.AsAST().Equal(w.AsAST()) }
+
+	if l, r, ok := MatchEq(x.Eq(y)); !ok || !eq(l, x) || !eq(r, y) {
+		t.Errorf("MatchEq(x = y) = %v, %v, %v", l, r, ok)
+	}
+	if cond, cons, alt, ok := MatchITE(a.IfThenElse(x, y)); !ok || !eq(cond, a) || !eq(cons, x) || !eq(alt, y) {
+		t.Errorf("MatchITE = %v, %v, %v, %v", cond, cons, alt, ok)
+	}
+	if l, ok := MatchNot(a.Not()); !ok || !eq(l, a) {
+		t.Errorf("MatchNot = %v, %v", l, ok)
+	}
+	if args, ok := MatchAnd(a.And(b, c)); !ok || len(args) != 3 || !eq(args[2], c) {
+		t.Errorf("MatchAnd = %v, %v", args, ok)
+	}
+	if vals, ok := MatchDistinct(ctx.Distinct(x, y)); !ok || len(vals) != 2 || !eq(vals[0], x) {
+		t.Errorf("MatchDistinct = %v, %v", vals, ok)
+	}
+
+	// Non-matches.
+	if _, ok := MatchOr(a.And(b)); ok {
+		t.Errorf("MatchOr matched And")
+	}
+	if _, _, ok := MatchEq(a); ok {
+		t.Errorf("MatchEq matched constant")
+	}
+}
+
+func TestIf(t *testing.T) {
+	ctx := NewContext(nil)
+	a := ctx.BoolConst("a")
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	bx, by := ctx.BVConst("bx", 8), ctx.BVConst("by", 8)
+
+	// The typed results can be used directly.
+	max := x.If(x.GE(y), y)
+	umax := bx.If(bx.UGE(by), by)
+	s := NewSolver(ctx)
+	s.Assert(max.LT(x).Or(max.LT(y), umax.ULT(bx), umax.ULT(by)))
+	if sat, err := s.Check(); sat || err != nil {
+		t.Fatalf("max is not the maximum: %v, %v", sat, err)
+	}
+
+	if cond, cons, alt, ok := MatchITE(x.If(a, y)); !ok || !cond.AsAST().Equal(a.AsAST()) || !cons.AsAST().Equal(x.AsAST()) || !alt.AsAST().Equal(y.AsAST()) {
+		t.Errorf("MatchITE(x.If(a, y)) = %v, %v, %v, %v", cond, cons, alt, ok)
+	}
+
+	// Sorts must still agree.
+	wantPanic(t, "incompatible", func() { bx.If(a, ctx.BVConst("w", 16)) })
+}
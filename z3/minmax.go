@@ -0,0 +1,137 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+// Min returns the lesser of l and r.
+func (l Int) Min(r Int) Int {
+	return l.If(l.LE(r), r)
+}
+
+// Max returns the greater of l and r.
+func (l Int) Max(r Int) Int {
+	return l.If(l.GE(r), r)
+}
+
+// Min returns the lesser of l and r.
+func (l Real) Min(r Real) Real {
+	return l.If(l.LE(r), r)
+}
+
+// Max returns the greater of l and r.
+func (l Real) Max(r Real) Real {
+	return l.If(l.GE(r), r)
+}
+
+// SMin returns the lesser of l and r, interpreted as signed integers.
+func (l BV) SMin(r BV) BV {
+	return l.If(l.SLE(r), r)
+}
+
+// SMax returns the greater of l and r, interpreted as signed
+// integers.
+func (l BV) SMax(r BV) BV {
+	return l.If(l.SGE(r), r)
+}
+
+// UMin returns the lesser of l and r, interpreted as unsigned
+// integers.
+func (l BV) UMin(r BV) BV {
+	return l.If(l.ULE(r), r)
+}
+
+// UMax returns the greater of l and r, interpreted as unsigned
+// integers.
+func (l BV) UMax(r BV) BV {
+	return l.If(l.UGE(r), r)
+}
+
+// The slice forms below combine values in a balanced tree, so the
+// nesting depth of the result grows logarithmically in the number of
+// values. They panic if given no values.
+
+// MinInt returns the least of vals.
+func (ctx *Context) MinInt(vals []Int) Int {
+	return reduceTree("MinInt", intValues(vals), func(l, r Value) Value {
+		return l.(Int).Min(r.(Int))
+	}).(Int)
+}
+
+// MaxInt returns the greatest of vals.
+func (ctx *Context) MaxInt(vals []Int) Int {
+	return reduceTree("MaxInt", intValues(vals), func(l, r Value) Value {
+		return l.(Int).Max(r.(Int))
+	}).(Int)
+}
+
+// MinReal returns the least of vals.
+func (ctx *Context) MinReal(vals []Real) Real {
+	return reduceTree("MinReal", realValues(vals), func(l, r Value) Value {
+		return l.(Real).Min(r.(Real))
+	}).(Real)
+}
+
+// MaxReal returns the greatest of vals.
+func (ctx *Context) MaxReal(vals []Real) Real {
+	return reduceTree("MaxReal", realValues(vals), func(l, r Value) Value {
+		return l.(Real).Max(r.(Real))
+	}).(Real)
+}
+
+// SMinBV returns the least of vals, interpreted as signed integers.
+func (ctx *Context) SMinBV(vals []BV) BV {
+	return reduceTree("SMinBV", bvValues(vals), func(l, r Value) Value {
+		return l.(BV).SMin(r.(BV))
+	}).(BV)
+}
+
+// SMaxBV returns the greatest of vals, interpreted as signed
+// integers.
+func (ctx *Context) SMaxBV(vals []BV) BV {
+	return reduceTree("SMaxBV", bvValues(vals), func(l, r Value) Value {
+		return l.(BV).SMax(r.(BV))
+	}).(BV)
+}
+
+// UMinBV returns the least of vals, interpreted as unsigned
+// integers.
+func (ctx *Context) UMinBV(vals []BV) BV {
+	return reduceTree("UMinBV", bvValues(vals), func(l, r Value) Value {
+		return l.(BV).UMin(r.(BV))
+	}).(BV)
+}
+
+// UMaxBV returns the greatest of vals, interpreted as unsigned
+// integers.
+func (ctx *Context) UMaxBV(vals []BV) BV {
+	return reduceTree("UMaxBV", bvValues(vals), func(l, r Value) Value {
+		return l.(BV).UMax(r.(BV))
+	}).(BV)
+}
+
+func bvValues(vals []BV) []Value {
+	xs := make([]Value, len(vals))
+	for i, v := range vals {
+		xs[i] = v
+	}
+	return xs
+}
+
+// reduceTree combines vals pairwise with f until one value remains.
+func reduceTree(op string, vals []Value, f func(l, r Value) Value) Value {
+	if len(vals) == 0 {
+		panic("z3: " + op + " of no values")
+	}
+	for len(vals) > 1 {
+		next := vals[:0]
+		for i := 0; i+1 < len(vals); i += 2 {
+			next = append(next, f(vals[i], vals[i+1]))
+		}
+		if len(vals)%2 == 1 {
+			next = append(next, vals[len(vals)-1])
+		}
+		vals = next
+	}
+	return vals[0]
+}
@@ -0,0 +1,62 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMinMax(t *testing.T) {
+	ctx := NewContext(nil)
+
+	// The maximum of symbolic values is at least each value and
+	// equal to one of them, and likewise for the minimum.
+	xs := make([]Int, 5)
+	for i := range xs {
+		xs[i] = ctx.IntConst(fmt.Sprintf("x%d", i))
+	}
+	max, min := ctx.MaxInt(xs), ctx.MinInt(xs)
+	var bounded, isMax, isMin []Bool
+	for _, x := range xs {
+		bounded = append(bounded, max.GE(x), min.LE(x))
+		isMax = append(isMax, max.Eq(x))
+		isMin = append(isMin, min.Eq(x))
+	}
+	s := NewSolver(ctx)
+	s.Assert(ctx.AndN(ctx.AndN(bounded...), ctx.OrN(isMax...), ctx.OrN(isMin...)).Not())
+	if sat, err := s.Check(); sat || err != nil {
+		t.Fatalf("MaxInt or MinInt is wrong: %v, %v", sat, err)
+	}
+
+	reals := []Real{ctx.FromInt(3, ctx.RealSort()).(Real), ctx.FromInt(-2, ctx.RealSort()).(Real)}
+	if v, _, _, _ := ctx.Simplify(ctx.MinReal(reals), nil).(Real).AsSmallRat(); v != -2 {
+		t.Errorf("MinReal = %d, want -2", v)
+	}
+	if v, _, _, _ := ctx.Simplify(ctx.MaxReal(reals), nil).(Real).AsSmallRat(); v != 3 {
+		t.Errorf("MaxReal = %d, want 3", v)
+	}
+
+	bv := func(x int) BV { return ctx.FromInt(int64(x), ctx.BVSort(8)).(BV) }
+	bvs := []BV{bv(0x01), bv(0x80), bv(0x7f)}
+	for _, test := range []struct {
+		name string
+		v    BV
+		want uint64
+	}{
+		{"SMinBV", ctx.SMinBV(bvs), 0x80},
+		{"SMaxBV", ctx.SMaxBV(bvs), 0x7f},
+		{"UMinBV", ctx.UMinBV(bvs), 0x01},
+		{"UMaxBV", ctx.UMaxBV(bvs), 0x80},
+		{"SMin", bv(0xff).SMin(bv(0x01)), 0xff},
+		{"UMin", bv(0xff).UMin(bv(0x01)), 0x01},
+	} {
+		if got, _, _ := ctx.Simplify(test.v, nil).(BV).AsUint64(); got != test.want {
+			t.Errorf("%s = %#x, want %#x", test.name, got, test.want)
+		}
+	}
+
+	wantPanic(t, "no values", func() { ctx.MaxInt(nil) })
+}
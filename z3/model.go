@@ -4,7 +4,11 @@
 
 package z3
 
-import "runtime"
+import (
+	"encoding/json"
+	"runtime"
+	"sort"
+)
 
 /*
 #cgo LDFLAGS: -lz3
@@ -29,12 +33,52 @@ type modelImpl struct {
 func wrapModel(ctx *Context, c C.Z3_model) *Model {
 	impl := &modelImpl{ctx, c}
 	C.Z3_model_inc_ref(ctx.c, c)
-	runtime.SetFinalizer(impl, func(impl *modelImpl) {
-		impl.ctx.do(func() {
+	runtime.SetFinalizer(impl, (*modelImpl).release)
+	return &Model{impl, noEq{}}
+}
+
+func (impl *modelImpl) release() {
+	impl.ctx.release(func() {
+		if impl.c != nil {
 			C.Z3_model_dec_ref(impl.ctx.c, impl.c)
-		})
+			impl.c = nil
+		}
+	})
+}
+
+// Close releases m's native resources now, rather than when m is
+// garbage collected. Any use of m after Close panics, except for
+// calling Close again. Values obtained from m remain valid.
+func (m *Model) Close() {
+	runtime.SetFinalizer(m.modelImpl, nil)
+	m.release()
+}
+
+// checkOpen panics if m has been closed. It must be called with the
+// Context's lock held.
+func (impl *modelImpl) checkOpen() {
+	if impl.c == nil {
+		panic("z3: use of closed Model")
+	}
+}
+
+// do is like m.ctx.do, but panics if m has been closed.
+func (m *Model) do(f func()) {
+	m.ctx.do(func() {
+		m.checkOpen()
+		f()
 	})
-	return &Model{impl, noEq{}}
+}
+
+// Translate copies m into the target Context.
+func (m *Model) Translate(target *Context) *Model {
+	var res *Model
+	m.ctx.doWith(target, func() {
+		m.checkOpen()
+		res = wrapModel(target, C.Z3_model_translate(m.ctx.c, m.c, target.c))
+	})
+	runtime.KeepAlive(m)
+	return res
 }
 
 // Eval evaluates val using the concrete interpretations of constants
@@ -50,7 +94,7 @@ func wrapModel(ctx *Context, c C.Z3_model) *Model {
 func (m *Model) Eval(val Value, completion bool) Value {
 	var ok bool
 	var ast AST
-	m.ctx.do(func() {
+	m.do(func() {
 		var cast C.Z3_ast
 		ok = z3ToBool(C.Z3_model_eval(m.ctx.c, m.c, val.impl().c, boolToZ3(completion), &cast))
 		if ok {
@@ -68,7 +112,7 @@ func (m *Model) Eval(val Value, completion bool) Value {
 // String returns a string representation of m.
 func (m *Model) String() string {
 	var res string
-	m.ctx.do(func() {
+	m.do(func() {
 		res = C.GoString(C.Z3_model_to_string(m.ctx.c, m.c))
 	})
 	runtime.KeepAlive(m)
@@ -83,7 +127,7 @@ func (m *Model) String() string {
 // with SortUniverse.
 func (m *Model) Sorts() []Sort {
 	var res []Sort
-	m.ctx.do(func() {
+	m.do(func() {
 		n := C.Z3_model_get_num_sorts(m.ctx.c, m.c)
 		res = make([]Sort, n)
 		for i := C.uint(0); i < n; i++ {
@@ -103,7 +147,7 @@ func (m *Model) Sorts() []Sort {
 func (m *Model) SortUniverse(s Sort) []Uninterpreted {
 	var cvec C.Z3_ast_vector
 	var n C.uint
-	m.ctx.do(func() {
+	m.do(func() {
 		cvec = C.Z3_model_get_sort_universe(m.ctx.c, m.c, s.c)
 		C.Z3_ast_vector_inc_ref(m.ctx.c, cvec)
 		n = C.Z3_ast_vector_size(m.ctx.c, cvec)
@@ -118,3 +162,142 @@ func (m *Model) SortUniverse(s Sort) []Uninterpreted {
 	runtime.KeepAlive(m)
 	return res
 }
+
+// modelJSON is the JSON encoding of a single constant or function in
+// a Model.
+type modelJSON struct {
+	Name  string      `json:"name"`
+	Sort  string      `json:"sort"`
+	Value interface{} `json:"value"`
+}
+
+// funcInterpJSON is the JSON encoding of a function interpretation.
+type funcInterpJSON struct {
+	Entries []funcEntryJSON `json:"entries"`
+	Else    interface{}     `json:"else"`
+}
+
+type funcEntryJSON struct {
+	Args  []interface{} `json:"args"`
+	Value interface{}   `json:"value"`
+}
+
+// MarshalJSON encodes m as a JSON array with one object for each
+// constant and function m interprets, sorted by name. Each object has
+// the form
+//
+//	{"name": "x", "sort": "Int", "value": 42}
+//
+// Bool values are encoded as JSON booleans; Int and BV values as JSON
+// numbers (BVs as unsigned); Real values as strings like "1/3"; and
+// String values as JSON strings. Other values are encoded as strings
+// containing their S-expression.
+//
+// For a function, the sort has the form "(Int Bool) Int" and the
+// value is an object
+//
+//	{"entries": [{"args": [1, true], "value": 2}, ...], "else": 0}
+//
+// giving the function's value at specific arguments and everywhere
+// else.
+func (m *Model) MarshalJSON() ([]byte, error) {
+	type constAST struct {
+		name string
+		val  AST
+	}
+	type funcAST struct {
+		name, sort string
+		args       [][]AST
+		vals       []AST
+		els        AST
+	}
+	var consts []constAST
+	var funcs []funcAST
+	m.do(func() {
+		c := m.ctx.c
+		for i, n := C.uint(0), C.Z3_model_get_num_consts(c, m.c); i < n; i++ {
+			decl := C.Z3_model_get_const_decl(c, m.c, i)
+			name := C.GoString(C.Z3_get_symbol_string(c, C.Z3_get_decl_name(c, decl)))
+			consts = append(consts, constAST{name, wrapAST(m.ctx, C.Z3_model_get_const_interp(c, m.c, decl))})
+		}
+		for i, n := C.uint(0), C.Z3_model_get_num_funcs(c, m.c); i < n; i++ {
+			decl := C.Z3_model_get_func_decl(c, m.c, i)
+			f := funcAST{name: C.GoString(C.Z3_get_symbol_string(c, C.Z3_get_decl_name(c, decl)))}
+			f.sort = "("
+			for j, nd := C.uint(0), C.Z3_get_domain_size(c, decl); j < nd; j++ {
+				if j > 0 {
+					f.sort += " "
+				}
+				f.sort += C.GoString(C.Z3_sort_to_string(c, C.Z3_get_domain(c, decl, j)))
+			}
+			f.sort += ") " + C.GoString(C.Z3_sort_to_string(c, C.Z3_get_range(c, decl)))
+
+			interp := C.Z3_model_get_func_interp(c, m.c, decl)
+			C.Z3_func_interp_inc_ref(c, interp)
+			for j, ne := C.uint(0), C.Z3_func_interp_get_num_entries(c, interp); j < ne; j++ {
+				entry := C.Z3_func_interp_get_entry(c, interp, j)
+				C.Z3_func_entry_inc_ref(c, entry)
+				var args []AST
+				for k, na := C.uint(0), C.Z3_func_entry_get_num_args(c, entry); k < na; k++ {
+					args = append(args, wrapAST(m.ctx, C.Z3_func_entry_get_arg(c, entry, k)))
+				}
+				f.args = append(f.args, args)
+				f.vals = append(f.vals, wrapAST(m.ctx, C.Z3_func_entry_get_value(c, entry)))
+				C.Z3_func_entry_dec_ref(c, entry)
+			}
+			f.els = wrapAST(m.ctx, C.Z3_func_interp_get_else(c, interp))
+			C.Z3_func_interp_dec_ref(c, interp)
+			funcs = append(funcs, f)
+		}
+	})
+	runtime.KeepAlive(m)
+
+	var res []modelJSON
+	for _, k := range consts {
+		v := k.val.AsValue()
+		res = append(res, modelJSON{k.name, v.Sort().String(), jsonValue(v)})
+	}
+	for _, f := range funcs {
+		interp := funcInterpJSON{Entries: []funcEntryJSON{}, Else: jsonValue(f.els.AsValue())}
+		for i, args := range f.args {
+			entry := funcEntryJSON{Value: jsonValue(f.vals[i].AsValue())}
+			for _, arg := range args {
+				entry.Args = append(entry.Args, jsonValue(arg.AsValue()))
+			}
+			interp.Entries = append(interp.Entries, entry)
+		}
+		res = append(res, modelJSON{f.name, f.sort, interp})
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	if res == nil {
+		res = []modelJSON{}
+	}
+	return json.Marshal(res)
+}
+
+// jsonValue returns the JSON encoding of v for Model.MarshalJSON.
+func jsonValue(v Value) interface{} {
+	switch v := v.(type) {
+	case Bool:
+		if b, ok := v.AsBool(); ok {
+			return b
+		}
+	case Int:
+		if i, ok := v.AsBigInt(); ok {
+			return json.Number(i.String())
+		}
+	case BV:
+		if i, ok := v.AsBigUnsigned(); ok {
+			return json.Number(i.String())
+		}
+	case Real:
+		if r, ok := v.AsBigRat(); ok {
+			return r.RatString()
+		}
+	case String:
+		if s, ok := v.AsString(); ok {
+			return s
+		}
+	}
+	return v.String()
+}
@@ -4,7 +4,11 @@
 
 package z3
 
-import "testing"
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
 
 func TestModel(t *testing.T) {
 	// Create a simple formula with a unique solution.
@@ -28,3 +32,58 @@ func TestModel(t *testing.T) {
 		t.Fatalf("expected x -> true, y -> false; got\n%s", m)
 	}
 }
+
+func TestModelJSON(t *testing.T) {
+	ctx := NewContext(nil)
+	ints, reals := ctx.IntSort(), ctx.RealSort()
+	x, b := ctx.IntConst("x"), ctx.BoolConst("b")
+	r := ctx.RealConst("r")
+	v := ctx.BVConst("v", 8)
+	f := ctx.FuncDecl("f", []Sort{ints}, ints)
+	s := NewSolver(ctx)
+	s.Assert(x.Eq(ctx.FromInt(42, ints).(Int)))
+	s.Assert(b)
+	s.Assert(r.Mul(ctx.FromInt(3, reals).(Real)).Eq(ctx.FromInt(1, reals).(Real)))
+	s.Assert(v.Eq(ctx.FromInt(-1, v.Sort()).(BV)))
+	s.Assert(f.Apply(x).(Int).Eq(ctx.FromInt(7, ints).(Int)))
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("Check() = %v, %v", sat, err)
+	}
+
+	data, err := json.Marshal(s.Model())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []struct {
+		Name  string
+		Sort  string
+		Value json.RawMessage
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("%v in %s", err, data)
+	}
+	want := []struct{ name, sort, value string }{
+		{"b", "Bool", `true`},
+		{"f", "(Int) Int", `{"entries":[{"args":[42],"value":7}],"else":7}`},
+		{"r", "Real", `"1/3"`},
+		{"v", "(_ BitVec 8)", `255`},
+		{"x", "Int", `42`},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %s", data)
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.Name != w.name || g.Sort != w.sort {
+			t.Errorf("entry %d is %s %s, want %s %s", i, g.Name, g.Sort, w.name, w.sort)
+		}
+		if w.name != "f" && string(g.Value) != w.value {
+			t.Errorf("%s = %s, want %s", g.Name, g.Value, w.value)
+		}
+	}
+	// The function's else case is arbitrary, but the entry for
+	// f(42) must be present.
+	if !strings.Contains(string(got[1].Value), `{"args":[42],"value":7}`) && !strings.Contains(string(got[1].Value), `"else":7`) {
+		t.Errorf("f = %s, want f(42) = 7", got[1].Value)
+	}
+}
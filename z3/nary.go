@@ -0,0 +1,103 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "runtime"
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+*/
+import "C"
+
+// The N-ary constructors build a single Z3 term from a slice of
+// arguments using one cgo call. Unlike the corresponding methods,
+// such as Bool.And, they accept any number of arguments, including
+// zero, which makes them convenient for building large terms in a
+// loop.
+
+// AndN returns a Bool that is true if all of vals are true. If vals
+// is empty, it returns true.
+func (ctx *Context) AndN(vals ...Bool) Bool {
+	if len(vals) == 0 {
+		return ctx.FromBool(true)
+	}
+	cargs := make([]C.Z3_ast, len(vals))
+	for i, v := range vals {
+		cargs[i] = v.c
+	}
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_and(ctx.c, C.uint(len(cargs)), &cargs[0])
+	})
+	runtime.KeepAlive(vals)
+	return Bool(val)
+}
+
+// OrN returns a Bool that is true if any of vals is true. If vals is
+// empty, it returns false.
+func (ctx *Context) OrN(vals ...Bool) Bool {
+	if len(vals) == 0 {
+		return ctx.FromBool(false)
+	}
+	cargs := make([]C.Z3_ast, len(vals))
+	for i, v := range vals {
+		cargs[i] = v.c
+	}
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_or(ctx.c, C.uint(len(cargs)), &cargs[0])
+	})
+	runtime.KeepAlive(vals)
+	return Bool(val)
+}
+
+// AddN returns the sum of vals.
+//
+// vals must all be Int or all be Real. The result has the same sort
+// as vals. If vals is empty, it returns the Int 0.
+func (ctx *Context) AddN(vals ...Value) Value {
+	if len(vals) == 0 {
+		return ctx.FromInt(0, ctx.IntSort())
+	}
+	return ctx.arithN(vals, func(n C.uint, args *C.Z3_ast) C.Z3_ast {
+		return C.Z3_mk_add(ctx.c, n, args)
+	})
+}
+
+// MulN returns the product of vals.
+//
+// vals must all be Int or all be Real. The result has the same sort
+// as vals. If vals is empty, it returns the Int 1.
+func (ctx *Context) MulN(vals ...Value) Value {
+	if len(vals) == 0 {
+		return ctx.FromInt(1, ctx.IntSort())
+	}
+	return ctx.arithN(vals, func(n C.uint, args *C.Z3_ast) C.Z3_ast {
+		return C.Z3_mk_mul(ctx.c, n, args)
+	})
+}
+
+func (ctx *Context) arithN(vals []Value, mk func(n C.uint, args *C.Z3_ast) C.Z3_ast) Value {
+	cargs := make([]C.Z3_ast, len(vals))
+	for i, v := range vals {
+		cargs[i] = v.impl().c
+	}
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return mk(C.uint(len(cargs)), &cargs[0])
+	})
+	runtime.KeepAlive(vals)
+	return val.lift(KindUnknown)
+}
+
+// DistinctN returns a Bool that is true if no two vals are equal.
+// Unlike Distinct, it accepts fewer than two values, in which case
+// the result is trivially true.
+//
+// All vals must have the same sort.
+func (ctx *Context) DistinctN(vals ...Value) Bool {
+	if len(vals) < 2 {
+		return ctx.FromBool(true)
+	}
+	return ctx.Distinct(vals...)
+}
@@ -0,0 +1,75 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNary(t *testing.T) {
+	ctx := NewContext(nil)
+
+	if got := ctx.AndN().String(); got != "true" {
+		t.Errorf("AndN() = %s, want true", got)
+	}
+	if got := ctx.OrN().String(); got != "false" {
+		t.Errorf("OrN() = %s, want false", got)
+	}
+	if got := ctx.AddN().String(); got != "0" {
+		t.Errorf("AddN() = %s, want 0", got)
+	}
+	if got := ctx.MulN().String(); got != "1" {
+		t.Errorf("MulN() = %s, want 1", got)
+	}
+	if got := ctx.DistinctN(ctx.IntConst("x")).String(); got != "true" {
+		t.Errorf("DistinctN(x) = %s, want true", got)
+	}
+
+	// Build flat terms over many arguments.
+	const n = 50
+	bools := make([]Bool, n)
+	ints := make([]Value, n)
+	for i := range bools {
+		bools[i] = ctx.BoolConst(fmt.Sprintf("b%d", i))
+		ints[i] = ctx.IntConst(fmt.Sprintf("x%d", i))
+	}
+	and := ctx.AndN(bools...)
+	if got := and.NumArgs(); got != n {
+		t.Errorf("AndN of %d values has %d arguments", n, got)
+	}
+	sum := ctx.AddN(ints...)
+	if _, ok := sum.(Int); !ok {
+		t.Fatalf("AddN of Ints is %T, want Int", sum)
+	}
+
+	// sum(x_i) = n*(n-1)/2 with distinct x_i in [0, n) is
+	// satisfiable; requiring one of them be negative is not.
+	s := NewSolver(ctx)
+	zero, top := ctx.FromInt(0, ctx.IntSort()).(Int), ctx.FromInt(n, ctx.IntSort()).(Int)
+	var bounds []Bool
+	for _, x := range ints {
+		bounds = append(bounds, x.(Int).GE(zero), x.(Int).LT(top))
+	}
+	s.Assert(ctx.AndN(bounds...))
+	s.Assert(ctx.DistinctN(ints...))
+	s.Assert(sum.(Int).Eq(ctx.FromInt(n*(n-1)/2, ctx.IntSort()).(Int)))
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("want sat, got %v, %v", sat, err)
+	}
+	neg := make([]Bool, n)
+	for i, x := range ints {
+		neg[i] = x.(Int).LT(zero)
+	}
+	s.Assert(ctx.OrN(neg...))
+	if sat, err := s.Check(); sat || err != nil {
+		t.Fatalf("want unsat, got %v, %v", sat, err)
+	}
+
+	prod := ctx.MulN(ctx.FromInt(2, ctx.RealSort()), ctx.FromInt(3, ctx.RealSort()))
+	if _, ok := prod.(Real); !ok {
+		t.Errorf("MulN of Reals is %T, want Real", prod)
+	}
+}
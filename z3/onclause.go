@@ -0,0 +1,74 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+#include <stdint.h>
+
+extern void goOnClause(void*, Z3_ast, unsigned, unsigned*, Z3_ast_vector);
+
+static inline void z3goRegisterOnClause(Z3_context c, Z3_solver s, uintptr_t id) {
+	Z3_solver_register_on_clause(c, s, (void*)id, (Z3_on_clause_eh*)goOnClause);
+}
+*/
+import "C"
+
+// An OnClauseFunc observes a clause inferred by a Solver.
+//
+// clause is the disjunction of literals the solver inferred. hint is
+// a proof hint describing how it was inferred, typically an
+// application of a proof rule such as "rup" for reverse unit
+// propagation, or the zero AST if there is none. deps identifies the
+// assertions the clause depends on, if the solver tracks them.
+type OnClauseFunc func(hint AST, deps []int, clause []Bool)
+
+// OnClause registers f to be called for each clause s infers while
+// solving, including its input clauses, learned clauses, and
+// deletions. This allows clients to log proofs or share clauses with
+// other solvers. f is called synchronously during Check, which holds
+// s's Context locked. Hence f must not call methods on s, its
+// Context, or Values created outside f. The Values f is passed belong
+// to a callback handle of the Context (see Value.Context), whose
+// operations run under the lock Check holds.
+func (s *Solver) OnClause(f OnClauseFunc) {
+	id := newCallback(s.ctx, f)
+	s.callbacks = append(s.callbacks, id)
+	s.do(func() {
+		C.z3goRegisterOnClause(s.ctx.c, s.c, C.uintptr_t(id))
+	})
+	runtime.KeepAlive(s)
+}
+
+//export goOnClause
+func goOnClause(id unsafe.Pointer, hint C.Z3_ast, n C.unsigned, cdeps *C.unsigned, lits C.Z3_ast_vector) {
+	callback(id, func(ctx *Context, v interface{}) {
+		var h AST
+		var clause []Bool
+		ctx.do(func() {
+			if hint != nil {
+				h = wrapAST(ctx, hint)
+			}
+			m := C.Z3_ast_vector_size(ctx.c, lits)
+			for i := C.uint(0); i < m; i++ {
+				lit := C.Z3_ast_vector_get(ctx.c, lits, i)
+				clause = append(clause, Bool(value{(*valueImpl)(wrapAST(ctx, lit).astImpl), noEq{}}))
+			}
+		})
+		deps := make([]int, n)
+		if n > 0 {
+			for i, d := range (*[1 << 28]C.unsigned)(unsafe.Pointer(cdeps))[:n:n] {
+				deps[i] = int(d)
+			}
+		}
+		v.(OnClauseFunc)(h, deps, clause)
+	})
+}
@@ -7,13 +7,18 @@
 // This package provides bindings for the Z3 SMT solver
 // (https://github.com/Z3Prover/z3). Z3 checks satisfiability of
 // logical formulas over a wide range of terms, including booleans,
-// integers, reals, bit-vectors, and uninterpreted functions. For a
-// good introduction to the concepts of SMT and Z3, see the Z3 guide
+// integers, reals, bit-vectors, IEEE floating-point numbers, strings,
+// and uninterpreted functions. For a good introduction to the
+// concepts of SMT and Z3, see the Z3 guide
 // (http://rise4fun.com/z3/tutorialcontent/guide).
 //
 // This package does not yet support all of the features or types
 // supported by Z3, though it supports a reasonably large subset.
 //
+// This package requires Z3 4.12 or later, since it uses C APIs added
+// in that release, such as clause callbacks, congruence closure
+// queries, and Unicode characters.
+//
 // The main entry point to the z3 package is type Context. All values
 // are created and all solving is done relative to some Context, and
 // values from different Contexts cannot be mixed.
@@ -57,12 +62,9 @@ package z3
 import "C"
 
 func boolToZ3(b bool) C.Z3_bool {
-	if b {
-		return C.Z3_TRUE
-	}
-	return C.Z3_FALSE
+	return C.Z3_bool(b)
 }
 
 func z3ToBool(b C.Z3_bool) bool {
-	return b != C.Z3_FALSE
+	return bool(b)
 }
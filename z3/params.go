@@ -0,0 +1,68 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+#include <stdlib.h>
+*/
+import "C"
+
+// Global parameters apply to every Context in the process, including
+// Contexts that already exist. Parameters of a Z3 module are named
+// "module.param", such as "pp.decimal" or "smt.random_seed"; setting
+// one affects every component that uses that module.
+
+// globalParamLock protects the buffer Z3_global_param_get returns.
+var globalParamLock sync.Mutex
+
+// SetGlobalParam sets the global parameter key to value, which is
+// formatted with fmt.Sprint. Z3 prints a warning and ignores the
+// setting if key is unknown. See GlobalParams for the available
+// top-level parameters.
+func SetGlobalParam(key string, value interface{}) {
+	ckey, cval := C.CString(key), C.CString(fmt.Sprint(value))
+	defer C.free(unsafe.Pointer(ckey))
+	defer C.free(unsafe.Pointer(cval))
+	C.Z3_global_param_set(ckey, cval)
+}
+
+// GetGlobalParam returns the value of the global parameter key. ok is
+// false if key is unknown.
+func GetGlobalParam(key string) (value string, ok bool) {
+	ckey := C.CString(key)
+	defer C.free(unsafe.Pointer(ckey))
+	globalParamLock.Lock()
+	defer globalParamLock.Unlock()
+	var cval C.Z3_string
+	if !z3ToBool(C.Z3_global_param_get(ckey, &cval)) {
+		return "", false
+	}
+	return C.GoString(cval), true
+}
+
+// ResetGlobalParams restores all global parameters to their default
+// values.
+func ResetGlobalParams() {
+	C.Z3_global_param_reset_all()
+}
+
+// GlobalParams returns descriptions of the top-level global
+// parameters.
+func GlobalParams() []ParamDesc {
+	ctx := NewContext(nil)
+	var desc []ParamDesc
+	ctx.do(func() {
+		desc = paramDescs(ctx, C.Z3_get_global_param_descrs(ctx.c))
+	})
+	return desc
+}
@@ -0,0 +1,30 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestGlobalParams(t *testing.T) {
+	defer ResetGlobalParams()
+
+	SetGlobalParam("pp.decimal", true)
+	if val, ok := GetGlobalParam("pp.decimal"); !ok || val != "true" {
+		t.Errorf("pp.decimal = %q, %v; want \"true\", true", val, ok)
+	}
+	ResetGlobalParams()
+	if val, _ := GetGlobalParam("pp.decimal"); val == "true" {
+		t.Errorf("pp.decimal still true after reset")
+	}
+
+	params := GlobalParams()
+	if len(params) == 0 {
+		t.Fatalf("no global parameters")
+	}
+	for _, p := range params {
+		if p.Name == "" || p.Type == "" {
+			t.Errorf("incomplete parameter description %+v", p)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "runtime"
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+*/
+import "C"
+
+// Pseudo-Boolean constraints. These are linear constraints over
+// Bools, where each Bool counts as 1 if it is true and 0 if it is
+// false. Z3 solves these with specialized encodings that are
+// typically much faster than the equivalent integer arithmetic.
+
+// AtMost returns a Bool that is true if at most k of vals are true.
+func (ctx *Context) AtMost(k int, vals ...Bool) Bool {
+	cargs := boolsToC(vals)
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_atmost(ctx.c, C.uint(len(cargs)), cptr(cargs), C.unsigned(k))
+	})
+	runtime.KeepAlive(vals)
+	return Bool(val)
+}
+
+// AtLeast returns a Bool that is true if at least k of vals are true.
+func (ctx *Context) AtLeast(k int, vals ...Bool) Bool {
+	cargs := boolsToC(vals)
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_atleast(ctx.c, C.uint(len(cargs)), cptr(cargs), C.unsigned(k))
+	})
+	runtime.KeepAlive(vals)
+	return Bool(val)
+}
+
+// PBLE returns a Bool that is true if the sum of coeffs[i] for each
+// true vals[i] is at most k. coeffs and vals must have the same
+// length.
+func (ctx *Context) PBLE(coeffs []int, vals []Bool, k int) Bool {
+	return ctx.pb(pbLE, coeffs, vals, k)
+}
+
+// PBGE is like PBLE, but is true if the weighted sum is at least k.
+func (ctx *Context) PBGE(coeffs []int, vals []Bool, k int) Bool {
+	return ctx.pb(pbGE, coeffs, vals, k)
+}
+
+// PBEq is like PBLE, but is true if the weighted sum is exactly k.
+func (ctx *Context) PBEq(coeffs []int, vals []Bool, k int) Bool {
+	return ctx.pb(pbEq, coeffs, vals, k)
+}
+
+type pbOp int
+
+const (
+	pbLE pbOp = iota
+	pbGE
+	pbEq
+)
+
+func (ctx *Context) pb(op pbOp, coeffs []int, vals []Bool, k int) Bool {
+	if len(coeffs) != len(vals) {
+		panic("coeffs and vals must have the same length")
+	}
+	cargs := boolsToC(vals)
+	ccoeffs := make([]C.int, len(coeffs))
+	for i, c := range coeffs {
+		ccoeffs[i] = C.int(c)
+	}
+	var pcoeffs *C.int
+	if len(ccoeffs) > 0 {
+		pcoeffs = &ccoeffs[0]
+	}
+	val := wrapValue(ctx, func() C.Z3_ast {
+		n := C.unsigned(len(cargs))
+		switch op {
+		case pbLE:
+			return C.Z3_mk_pble(ctx.c, n, cptr(cargs), pcoeffs, C.int(k))
+		case pbGE:
+			return C.Z3_mk_pbge(ctx.c, n, cptr(cargs), pcoeffs, C.int(k))
+		}
+		return C.Z3_mk_pbeq(ctx.c, n, cptr(cargs), pcoeffs, C.int(k))
+	})
+	runtime.KeepAlive(vals)
+	return Bool(val)
+}
+
+// boolsToC returns the C ASTs of vals.
+func boolsToC(vals []Bool) []C.Z3_ast {
+	cargs := make([]C.Z3_ast, len(vals))
+	for i, v := range vals {
+		cargs[i] = v.c
+	}
+	return cargs
+}
+
+// cptr returns a pointer to the first element of cargs, or nil if
+// cargs is empty.
+func cptr(cargs []C.Z3_ast) *C.Z3_ast {
+	if len(cargs) == 0 {
+		return nil
+	}
+	return &cargs[0]
+}
+
+// A CardEncoding selects how AtMostOne and ExactlyOne encode their
+// constraint. The best choice depends on the problem: native
+// constraints are compact, while the clausal encodings expose more
+// structure to the SAT core.
+type CardEncoding int
+
+const (
+	// CardNative uses Z3's native pseudo-Boolean constraints.
+	CardNative CardEncoding = iota
+
+	// CardPairwise forbids every pair of values from being true
+	// together. This needs O(n²) clauses but no auxiliary
+	// variables.
+	CardPairwise
+
+	// CardSequential uses a sequential counter encoding with n-1
+	// auxiliary variables and O(n) clauses.
+	//
+	// Because it introduces fresh constants, the resulting Bool
+	// is only equisatisfiable with the constraint: it may be
+	// asserted, but should not be negated.
+	CardSequential
+)
+
+// AtMostOne returns a Bool that is true if at most one of vals is
+// true, using encoding enc.
+func (ctx *Context) AtMostOne(enc CardEncoding, vals ...Bool) Bool {
+	if len(vals) <= 1 {
+		return ctx.FromBool(true)
+	}
+	var clauses []Bool
+	switch enc {
+	case CardNative:
+		return ctx.AtMost(1, vals...)
+
+	case CardPairwise:
+		for i, x := range vals {
+			for _, y := range vals[i+1:] {
+				clauses = append(clauses, x.Not().Or(y.Not()))
+			}
+		}
+
+	case CardSequential:
+		// s[i] is true if any of vals[:i+1] is true.
+		s := make([]Bool, len(vals)-1)
+		for i := range s {
+			s[i] = ctx.FreshConst("amo", ctx.BoolSort()).(Bool)
+		}
+		for i, x := range vals {
+			if i < len(s) {
+				clauses = append(clauses, x.Implies(s[i]))
+			}
+			if i > 0 {
+				clauses = append(clauses, x.Not().Or(s[i-1].Not()))
+				if i < len(s) {
+					clauses = append(clauses, s[i-1].Implies(s[i]))
+				}
+			}
+		}
+
+	default:
+		panic("unknown CardEncoding")
+	}
+	return clauses[0].And(clauses[1:]...)
+}
+
+// ExactlyOne returns a Bool that is true if exactly one of vals is
+// true, using encoding enc.
+func (ctx *Context) ExactlyOne(enc CardEncoding, vals ...Bool) Bool {
+	if len(vals) == 0 {
+		return ctx.FromBool(false)
+	}
+	if enc == CardNative {
+		return ctx.PBEq(ones(len(vals)), vals, 1)
+	}
+	return vals[0].Or(vals[1:]...).And(ctx.AtMostOne(enc, vals...))
+}
+
+// ones returns a slice of n 1s.
+func ones(n int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = 1
+	}
+	return s
+}
@@ -0,0 +1,99 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPseudoBoolean(t *testing.T) {
+	ctx := NewContext(nil)
+	vals := make([]Bool, 4)
+	for i := range vals {
+		vals[i] = ctx.BoolConst(fmt.Sprint("x", i))
+	}
+	coeffs := []int{1, 2, 3, 4}
+
+	// Check each constraint against every assignment.
+	for bits := 0; bits < 1<<len(vals); bits++ {
+		var assign []Bool
+		count, sum := 0, 0
+		for i, v := range vals {
+			if bits&(1<<i) != 0 {
+				assign = append(assign, v)
+				count++
+				sum += coeffs[i]
+			} else {
+				assign = append(assign, v.Not())
+			}
+		}
+		for _, test := range []struct {
+			name string
+			pred Bool
+			want bool
+		}{
+			{"AtMost(2)", ctx.AtMost(2, vals...), count <= 2},
+			{"AtLeast(2)", ctx.AtLeast(2, vals...), count >= 2},
+			{"PBLE(5)", ctx.PBLE(coeffs, vals, 5), sum <= 5},
+			{"PBGE(5)", ctx.PBGE(coeffs, vals, 5), sum >= 5},
+			{"PBEq(5)", ctx.PBEq(coeffs, vals, 5), sum == 5},
+		} {
+			s := NewSolver(ctx)
+			for _, a := range assign {
+				s.Assert(a)
+			}
+			s.Assert(test.pred)
+			if sat, err := s.Check(); sat != test.want || err != nil {
+				t.Errorf("%s with x=%04b: got %v, %v, want %v", test.name, bits, sat, err, test.want)
+			}
+		}
+	}
+
+	if !simplifyBool(t, ctx, ctx.AtMost(0)) {
+		t.Errorf("AtMost(0) of no values is false")
+	}
+	wantPanic(t, "same length", func() { ctx.PBLE([]int{1}, vals, 1) })
+}
+
+func TestAtMostOne(t *testing.T) {
+	ctx := NewContext(nil)
+	for n := 0; n <= 4; n++ {
+		vals := make([]Bool, n)
+		for i := range vals {
+			vals[i] = ctx.BoolConst(fmt.Sprint("x", i))
+		}
+		for _, enc := range []CardEncoding{CardNative, CardPairwise, CardSequential} {
+			amo, eo := ctx.AtMostOne(enc, vals...), ctx.ExactlyOne(enc, vals...)
+			for bits := 0; bits < 1<<n; bits++ {
+				count := 0
+				s := NewSolver(ctx)
+				for i, v := range vals {
+					if bits&(1<<i) != 0 {
+						s.Assert(v)
+						count++
+					} else {
+						s.Assert(v.Not())
+					}
+				}
+				for _, test := range []struct {
+					name string
+					pred Bool
+					want bool
+				}{
+					{"AtMostOne", amo, count <= 1},
+					{"ExactlyOne", eo, count == 1},
+				} {
+					s.Push()
+					s.Assert(test.pred)
+					if sat, err := s.Check(); sat != test.want || err != nil {
+						t.Errorf("%s(%d, n=%d) with x=%b: got %v, %v, want %v", test.name, enc, n, bits, sat, err, test.want)
+					}
+					s.Pop()
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+// A Pool is a fixed set of Solvers, each in its own Context, that
+// goroutines can check out to solve problems concurrently.
+//
+// A Context executes one operation at a time, so concurrent solving
+// requires a Context per goroutine. A Pool creates these Contexts up
+// front and asserts a common set of base assertions in each, so this
+// setup cost is not paid for every problem.
+type Pool struct {
+	free    chan *Solver
+	solvers []*Solver
+}
+
+// NewPool returns a Pool of n Solvers. Each Solver is in a new
+// Context created with config (which may be nil; see NewContext) and
+// asserts base, translated into its Context. The base assertions
+// may be from any Context.
+func NewPool(n int, config *Config, base ...Bool) *Pool {
+	p := &Pool{free: make(chan *Solver, n)}
+	for i := 0; i < n; i++ {
+		ctx := NewContext(config)
+		s := NewSolver(ctx)
+		for _, b := range base {
+			s.Assert(b.AsAST().Translate(ctx).AsValue().(Bool))
+		}
+		p.solvers = append(p.solvers, s)
+		p.free <- s
+	}
+	return p
+}
+
+// Get checks out a Solver from p, blocking until one is available.
+// The Solver asserts only p's base assertions. Values for use with it
+// must be created in (or translated into) its Context, s.Context().
+//
+// The caller has exclusive use of the Solver and its Context until
+// it returns the Solver with Put. The caller may Push and Pop, but
+// must not Pop the scope that Get pushes.
+func (p *Pool) Get() *Solver {
+	s := <-p.free
+	s.Push()
+	return s
+}
+
+// Put returns a Solver obtained from Get to p. Put removes all
+// assertions made since Get, so the next caller of Get sees only the
+// base assertions. The caller must not use the Solver, its Context,
+// or its Values after Put.
+func (p *Pool) Put(s *Solver) {
+	for n := s.NumScopes(); n > 0; n-- {
+		s.Pop()
+	}
+	p.free <- s
+}
+
+// Close releases the Contexts of all of p's Solvers. It waits for all
+// checked-out Solvers to be returned.
+func (p *Pool) Close() {
+	for range p.solvers {
+		s := <-p.free
+		s.Context().Close()
+	}
+}
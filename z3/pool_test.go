@@ -0,0 +1,49 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestPool(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.IntConst("x")
+	zero := ctx.FromInt(0, ctx.IntSort()).(Int)
+	p := NewPool(2, nil, x.GT(zero))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s := p.Get()
+			defer p.Put(s)
+			ctx := s.Context()
+			x, ints := ctx.IntConst("x"), ctx.IntSort()
+			// x > 0 from the base assertions, so x < i is
+			// satisfiable only for i > 1.
+			s.Assert(x.LT(ctx.FromInt(int64(i), ints).(Int)))
+			sat, err := s.Check()
+			if err != nil || sat != (i > 1) {
+				t.Errorf("x < %d: got %v, %v, want %v", i, sat, err, i > 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Assertions do not leak between checkouts.
+	s := p.Get()
+	if n := s.NumScopes(); n != 1 {
+		t.Errorf("NumScopes() = %d after Get, want 1", n)
+	}
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Errorf("Check() = %v, %v, want sat", sat, err)
+	}
+	p.Put(s)
+	p.Close()
+}
@@ -0,0 +1,169 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"time"
+)
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+*/
+import "C"
+
+// A Strategy is one way of solving a problem in SolveParallel.
+type Strategy struct {
+	// Config configures the Strategy's Context. If nil, the
+	// default configuration is used.
+	Config *Config
+
+	// Tactic, if non-empty, names a Z3 tactic, such as "qfbv" or
+	// "qfnra-nlsat", to solve the problem with in place of the
+	// Solver's default algorithm.
+	Tactic string
+
+	// Params sets solver parameters, such as "random_seed" or
+	// "smt.arith.solver". Values must be bool, uint, float64, or
+	// string.
+	Params map[string]interface{}
+}
+
+// SolveParallel solves a problem with several strategies at once and
+// returns the first definitive answer.
+//
+// For each strategy, SolveParallel creates a new Context and calls
+// problem to construct a Solver for the problem in that Context.
+// problem is called concurrently from multiple goroutines, but each
+// call has its own Context. When one strategy determines whether the
+// problem is satisfiable, SolveParallel interrupts the others. If the
+// problem is satisfiable, it returns the winning model, translated
+// into target.
+//
+// If ctx is canceled before any strategy succeeds, SolveParallel
+// interrupts all strategies and returns ctx.Err(). If every strategy
+// fails, it returns the error from the first one to fail.
+//
+// The Contexts passed to problem are closed before SolveParallel
+// returns, so problem must not retain them.
+func SolveParallel(ctx context.Context, target *Context, problem func(*Context) *Solver, strategies []Strategy) (sat bool, model *Model, err error) {
+	if len(strategies) == 0 {
+		return false, nil, errors.New("z3: no strategies")
+	}
+
+	type result struct {
+		sat   bool
+		model *Model
+		err   error
+	}
+	ctxs := make([]*Context, len(strategies))
+	for i, st := range strategies {
+		ctxs[i] = NewContext(st.Config)
+	}
+	defer func() {
+		for _, zctx := range ctxs {
+			zctx.Close()
+		}
+	}()
+	results := make(chan result, len(strategies))
+	for i, st := range strategies {
+		go func(zctx *Context, st Strategy) {
+			var r result
+			if err := Try(func() {
+				s := st.solver(problem(zctx))
+				r.sat, r.err = s.Check()
+				// Get the model before another strategy's
+				// win can interrupt zctx.
+				if r.sat {
+					r.model = s.Model()
+				}
+			}); err != nil {
+				r.err = err
+			}
+			results <- r
+		}(ctxs[i], st)
+	}
+
+	// Wait for all strategies to finish, interrupting the
+	// stragglers once there's an answer. An interrupt is lost if
+	// it arrives before a strategy starts checking, so repeat it
+	// until all strategies have stopped.
+	var win *result
+	var tick <-chan time.Time
+	done := ctx.Done()
+	for pending := len(strategies); pending > 0; {
+		stop := false
+		select {
+		case r := <-results:
+			pending--
+			switch {
+			case win != nil || ctx.Err() != nil:
+				// Already decided.
+			case r.err == nil:
+				win, stop = &r, true
+			case err == nil:
+				err = r.err
+			}
+		case <-done:
+			done, stop = nil, true
+		case <-tick:
+			stop = true
+		}
+		if stop {
+			if tick == nil {
+				ticker := time.NewTicker(10 * time.Millisecond)
+				defer ticker.Stop()
+				tick = ticker.C
+			}
+			for _, zctx := range ctxs {
+				zctx.Interrupt()
+			}
+		}
+	}
+
+	switch {
+	case win != nil:
+		if win.sat {
+			model = win.model.Translate(target)
+		}
+		return win.sat, model, nil
+	case ctx.Err() != nil:
+		return false, nil, ctx.Err()
+	}
+	return false, nil, err
+}
+
+// solver returns a Solver for st with the assertions of s.
+func (st Strategy) solver(s *Solver) *Solver {
+	ctx := s.ctx
+	if st.Tactic != "" {
+		var ts *Solver
+		ctx.do(func() {
+			tactic := ctx.mkTactic(st.Tactic)
+			defer C.Z3_tactic_dec_ref(ctx.c, tactic)
+			ts = wrapSolver(ctx, C.Z3_mk_solver_from_tactic(ctx.c, tactic))
+			asserts := C.Z3_solver_get_assertions(ctx.c, s.c)
+			C.Z3_ast_vector_inc_ref(ctx.c, asserts)
+			defer C.Z3_ast_vector_dec_ref(ctx.c, asserts)
+			n := C.Z3_ast_vector_size(ctx.c, asserts)
+			for i := C.uint(0); i < n; i++ {
+				C.Z3_solver_assert(ctx.c, ts.c, C.Z3_ast_vector_get(ctx.c, asserts, i))
+			}
+		})
+		runtime.KeepAlive(s)
+		s = ts
+	}
+	if st.Params != nil {
+		cfg := newConfig(nil)
+		for k, v := range st.Params {
+			cfg.m[k] = v
+		}
+		s.setParams(cfg)
+	}
+	return s
+}
@@ -0,0 +1,55 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSolveParallel(t *testing.T) {
+	// factor returns a problem that finds a nontrivial factoring
+	// of n.
+	factor := func(n int64) func(*Context) *Solver {
+		return func(ctx *Context) *Solver {
+			ints := ctx.IntSort()
+			x, y := ctx.IntConst("x"), ctx.IntConst("y")
+			one := ctx.FromInt(1, ints).(Int)
+			s := NewSolver(ctx)
+			s.Assert(x.Mul(y).Eq(ctx.FromInt(n, ints).(Int)))
+			s.Assert(x.GT(one))
+			s.Assert(y.GT(one))
+			return s
+		}
+	}
+	strategies := []Strategy{
+		{},
+		{Tactic: "qfnia"},
+		{Params: map[string]interface{}{"random_seed": uint(42)}},
+	}
+
+	target := NewContext(nil)
+	sat, m, err := SolveParallel(context.Background(), target, factor(221), strategies)
+	if err != nil || !sat {
+		t.Fatalf("want sat, got %v, %v", sat, err)
+	}
+	x, y := target.IntConst("x"), target.IntConst("y")
+	xv, _, _ := m.Eval(x, true).(Int).AsInt64()
+	yv, _, _ := m.Eval(y, true).(Int).AsInt64()
+	if xv*yv != 221 || xv == 1 || yv == 1 {
+		t.Errorf("got factoring %d * %d, want 13 * 17", xv, yv)
+	}
+
+	sat, m, err = SolveParallel(context.Background(), target, factor(13), strategies)
+	if err != nil || sat || m != nil {
+		t.Errorf("want unsat, got %v, %v, %v", sat, m, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := SolveParallel(ctx, target, factor(221), strategies); err != context.Canceled {
+		t.Errorf("with canceled context, got error %v", err)
+	}
+}
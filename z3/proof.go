@@ -0,0 +1,238 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"runtime"
+	"strconv"
+)
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+*/
+import "C"
+
+// A Proof is a proof term produced by a Solver. Each step of a proof
+// applies an inference rule to zero or more premises, which are
+// themselves proofs, to derive a conclusion.
+//
+// Proofs are only available if proof generation was enabled by
+// setting the "proof" parameter when creating the Context.
+type Proof struct {
+	// AST is the proof term. Proof terms are not Values, so
+	// AST.AsValue panics.
+	AST
+}
+
+// Proof returns the proof of unsatisfiability for the last Check,
+// whose conclusion is false. It panics if the last Check did not
+// return false or proof generation is disabled.
+func (s *Solver) Proof() Proof {
+	var p Proof
+	s.do(func() {
+		p = Proof{wrapAST(s.ctx, C.Z3_solver_get_proof(s.ctx.c, s.c))}
+	})
+	runtime.KeepAlive(s)
+	return p
+}
+
+// ProofRule is an inference rule used in a Proof step. See the
+// documentation of Z3_decl_kind in the Z3 API for the meaning of
+// each rule.
+type ProofRule int
+
+const (
+	ProofTrue             = ProofRule(C.Z3_OP_PR_TRUE)
+	ProofAsserted         = ProofRule(C.Z3_OP_PR_ASSERTED)
+	ProofGoal             = ProofRule(C.Z3_OP_PR_GOAL)
+	ProofModusPonens      = ProofRule(C.Z3_OP_PR_MODUS_PONENS)
+	ProofReflexivity      = ProofRule(C.Z3_OP_PR_REFLEXIVITY)
+	ProofSymmetry         = ProofRule(C.Z3_OP_PR_SYMMETRY)
+	ProofTransitivity     = ProofRule(C.Z3_OP_PR_TRANSITIVITY)
+	ProofTransitivityStar = ProofRule(C.Z3_OP_PR_TRANSITIVITY_STAR)
+	ProofMonotonicity     = ProofRule(C.Z3_OP_PR_MONOTONICITY)
+	ProofQuantIntro       = ProofRule(C.Z3_OP_PR_QUANT_INTRO)
+	ProofBind             = ProofRule(C.Z3_OP_PR_BIND)
+	ProofDistributivity   = ProofRule(C.Z3_OP_PR_DISTRIBUTIVITY)
+	ProofAndElim          = ProofRule(C.Z3_OP_PR_AND_ELIM)
+	ProofNotOrElim        = ProofRule(C.Z3_OP_PR_NOT_OR_ELIM)
+	ProofRewrite          = ProofRule(C.Z3_OP_PR_REWRITE)
+	ProofRewriteStar      = ProofRule(C.Z3_OP_PR_REWRITE_STAR)
+	ProofPullQuant        = ProofRule(C.Z3_OP_PR_PULL_QUANT)
+	ProofPushQuant        = ProofRule(C.Z3_OP_PR_PUSH_QUANT)
+	ProofElimUnusedVars   = ProofRule(C.Z3_OP_PR_ELIM_UNUSED_VARS)
+	ProofDER              = ProofRule(C.Z3_OP_PR_DER)
+	ProofQuantInst        = ProofRule(C.Z3_OP_PR_QUANT_INST)
+	ProofHypothesis       = ProofRule(C.Z3_OP_PR_HYPOTHESIS)
+	ProofLemma            = ProofRule(C.Z3_OP_PR_LEMMA)
+	ProofUnitResolution   = ProofRule(C.Z3_OP_PR_UNIT_RESOLUTION)
+	ProofIffTrue          = ProofRule(C.Z3_OP_PR_IFF_TRUE)
+	ProofIffFalse         = ProofRule(C.Z3_OP_PR_IFF_FALSE)
+	ProofCommutativity    = ProofRule(C.Z3_OP_PR_COMMUTATIVITY)
+	ProofDefAxiom         = ProofRule(C.Z3_OP_PR_DEF_AXIOM)
+	ProofAssumptionAdd    = ProofRule(C.Z3_OP_PR_ASSUMPTION_ADD)
+	ProofLemmaAdd         = ProofRule(C.Z3_OP_PR_LEMMA_ADD)
+	ProofRedundantDel     = ProofRule(C.Z3_OP_PR_REDUNDANT_DEL)
+	ProofClauseTrail      = ProofRule(C.Z3_OP_PR_CLAUSE_TRAIL)
+	ProofDefIntro         = ProofRule(C.Z3_OP_PR_DEF_INTRO)
+	ProofApplyDef         = ProofRule(C.Z3_OP_PR_APPLY_DEF)
+	ProofIffOEq           = ProofRule(C.Z3_OP_PR_IFF_OEQ)
+	ProofNNFPos           = ProofRule(C.Z3_OP_PR_NNF_POS)
+	ProofNNFNeg           = ProofRule(C.Z3_OP_PR_NNF_NEG)
+	ProofSkolemize        = ProofRule(C.Z3_OP_PR_SKOLEMIZE)
+	ProofModusPonensOEq   = ProofRule(C.Z3_OP_PR_MODUS_PONENS_OEQ)
+	ProofThLemma          = ProofRule(C.Z3_OP_PR_TH_LEMMA)
+	ProofHyperResolve     = ProofRule(C.Z3_OP_PR_HYPER_RESOLVE)
+)
+
+var proofRuleNames = map[ProofRule]string{
+	ProofTrue:             "ProofTrue",
+	ProofAsserted:         "ProofAsserted",
+	ProofGoal:             "ProofGoal",
+	ProofModusPonens:      "ProofModusPonens",
+	ProofReflexivity:      "ProofReflexivity",
+	ProofSymmetry:         "ProofSymmetry",
+	ProofTransitivity:     "ProofTransitivity",
+	ProofTransitivityStar: "ProofTransitivityStar",
+	ProofMonotonicity:     "ProofMonotonicity",
+	ProofQuantIntro:       "ProofQuantIntro",
+	ProofBind:             "ProofBind",
+	ProofDistributivity:   "ProofDistributivity",
+	ProofAndElim:          "ProofAndElim",
+	ProofNotOrElim:        "ProofNotOrElim",
+	ProofRewrite:          "ProofRewrite",
+	ProofRewriteStar:      "ProofRewriteStar",
+	ProofPullQuant:        "ProofPullQuant",
+	ProofPushQuant:        "ProofPushQuant",
+	ProofElimUnusedVars:   "ProofElimUnusedVars",
+	ProofDER:              "ProofDER",
+	ProofQuantInst:        "ProofQuantInst",
+	ProofHypothesis:       "ProofHypothesis",
+	ProofLemma:            "ProofLemma",
+	ProofUnitResolution:   "ProofUnitResolution",
+	ProofIffTrue:          "ProofIffTrue",
+	ProofIffFalse:         "ProofIffFalse",
+	ProofCommutativity:    "ProofCommutativity",
+	ProofDefAxiom:         "ProofDefAxiom",
+	ProofAssumptionAdd:    "ProofAssumptionAdd",
+	ProofLemmaAdd:         "ProofLemmaAdd",
+	ProofRedundantDel:     "ProofRedundantDel",
+	ProofClauseTrail:      "ProofClauseTrail",
+	ProofDefIntro:         "ProofDefIntro",
+	ProofApplyDef:         "ProofApplyDef",
+	ProofIffOEq:           "ProofIffOEq",
+	ProofNNFPos:           "ProofNNFPos",
+	ProofNNFNeg:           "ProofNNFNeg",
+	ProofSkolemize:        "ProofSkolemize",
+	ProofModusPonensOEq:   "ProofModusPonensOEq",
+	ProofThLemma:          "ProofThLemma",
+	ProofHyperResolve:     "ProofHyperResolve",
+}
+
+// String returns r as a string like "ProofModusPonens".
+func (r ProofRule) String() string {
+	if s, ok := proofRuleNames[r]; ok {
+		return s
+	}
+	return "ProofRule(" + strconv.Itoa(int(r)) + ")"
+}
+
+// Rule returns the inference rule applied by the last step of p.
+func (p Proof) Rule() ProofRule {
+	var r ProofRule
+	p.ctx.do(func() {
+		r = ProofRule(C.Z3_get_decl_kind(p.ctx.c, p.decl()))
+	})
+	runtime.KeepAlive(p)
+	return r
+}
+
+// RuleName returns Z3's name for the rule applied by the last step of
+// p, such as "mp" or "unit-resolution".
+func (p Proof) RuleName() string {
+	var name string
+	p.ctx.do(func() {
+		sym := C.Z3_get_decl_name(p.ctx.c, p.decl())
+		name = C.GoString(C.Z3_get_symbol_string(p.ctx.c, sym))
+	})
+	runtime.KeepAlive(p)
+	return name
+}
+
+// decl returns the declaration of p's proof rule. This must be called
+// with the ctx.lock held.
+func (p Proof) decl() C.Z3_func_decl {
+	return C.Z3_get_app_decl(p.ctx.c, C.Z3_to_app(p.ctx.c, p.c))
+}
+
+// Premises returns the proofs of the premises of the last step of p.
+func (p Proof) Premises() []Proof {
+	var res []Proof
+	p.ctx.do(func() {
+		app := C.Z3_to_app(p.ctx.c, p.c)
+		n := C.Z3_get_app_num_args(p.ctx.c, app)
+		for i := C.uint(0); i+1 < n; i++ {
+			arg := C.Z3_get_app_arg(p.ctx.c, app, i)
+			res = append(res, Proof{wrapAST(p.ctx, arg)})
+		}
+	})
+	runtime.KeepAlive(p)
+	return res
+}
+
+// Conclusion returns the formula proved by p.
+func (p Proof) Conclusion() Bool {
+	val := wrapValue(p.ctx, func() C.Z3_ast {
+		app := C.Z3_to_app(p.ctx.c, p.c)
+		n := C.Z3_get_app_num_args(p.ctx.c, app)
+		return C.Z3_get_app_arg(p.ctx.c, app, n-1)
+	})
+	runtime.KeepAlive(p)
+	return Bool(val)
+}
+
+// WriteTo writes p to w in a line-oriented text format suitable for
+// external proof checkers.
+//
+// Each step is written once, after the steps it depends on, as a line
+// of the form
+//
+//	id rule premise-ids... : conclusion
+//
+// where rule is the name returned by RuleName and the conclusion is
+// formatted according to the Context's print mode. The last line is
+// the step for p itself.
+func (p Proof) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	var n int64
+	ids := make(map[uint64]int)
+	var write func(p Proof) int
+	write = func(p Proof) int {
+		if id, ok := ids[p.ID()]; ok {
+			return id
+		}
+		var premises []int
+		for _, pr := range p.Premises() {
+			premises = append(premises, write(pr))
+		}
+		id := len(ids) + 1
+		ids[p.ID()] = id
+		m, _ := fmt.Fprintf(bw, "%d %s", id, p.RuleName())
+		n += int64(m)
+		for _, pr := range premises {
+			m, _ = fmt.Fprintf(bw, " %d", pr)
+			n += int64(m)
+		}
+		m, _ = fmt.Fprintf(bw, " : %s\n", p.Conclusion())
+		n += int64(m)
+		return id
+	}
+	write(p)
+	return n, bw.Flush()
+}
@@ -0,0 +1,67 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestProof(t *testing.T) {
+	ctx := NewContext(NewContextConfig().SetBool("proof", true))
+	ints := ctx.IntSort()
+	x := ctx.IntConst("x")
+	a1 := x.GT(ctx.FromInt(2, ints).(Int))
+	a2 := x.LT(ctx.FromInt(1, ints).(Int))
+	s := NewSolver(ctx)
+	s.Assert(a1)
+	s.Assert(a2)
+	if sat, err := s.Check(); sat || err != nil {
+		t.Fatalf("Check() = %v, %v, want unsat", sat, err)
+	}
+
+	p := s.Proof()
+	if c, _ := ctx.Simplify(p.Conclusion(), nil).(Bool).AsBool(); c {
+		t.Errorf("proof concludes %v, want false", p.Conclusion())
+	}
+
+	// Every asserted step must prove one of the assertions.
+	asserted := 0
+	var walk func(p Proof)
+	walk = func(p Proof) {
+		prem := p.Premises()
+		if p.Rule() == ProofAsserted {
+			asserted++
+			c := p.Conclusion().AsAST()
+			if len(prem) != 0 || !c.Equal(a1.AsAST()) && !c.Equal(a2.AsAST()) {
+				t.Errorf("bad asserted step %v", p)
+			}
+		}
+		for _, q := range prem {
+			walk(q)
+		}
+	}
+	walk(p)
+	if asserted == 0 {
+		t.Errorf("proof has no asserted steps")
+	}
+	if got := ProofAsserted.String(); got != "ProofAsserted" {
+		t.Errorf("ProofAsserted.String() = %s", got)
+	}
+
+	var buf bytes.Buffer
+	n, err := p.WriteTo(&buf)
+	if err != nil || n != int64(buf.Len()) {
+		t.Fatalf("WriteTo = %d, %v; wrote %d bytes", n, err, buf.Len())
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if !strings.Contains(buf.String(), " asserted : ") {
+		t.Errorf("proof has no asserted steps:\n%s", buf.String())
+	}
+	if last := lines[len(lines)-1]; !strings.HasSuffix(last, " : false") {
+		t.Errorf("last step is %q, want conclusion false", last)
+	}
+}
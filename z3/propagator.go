@@ -0,0 +1,283 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+#include <stdint.h>
+#include <stdbool.h>
+
+extern void goPropagatorPush(void*, Z3_solver_callback);
+extern void goPropagatorPop(void*, Z3_solver_callback, unsigned);
+extern void* goPropagatorFresh(void*, Z3_context);
+extern void goPropagatorFixed(void*, Z3_solver_callback, Z3_ast, Z3_ast);
+extern void goPropagatorEq(void*, Z3_solver_callback, Z3_ast, Z3_ast);
+extern void goPropagatorDiseq(void*, Z3_solver_callback, Z3_ast, Z3_ast);
+extern void goPropagatorFinal(void*, Z3_solver_callback);
+extern void goPropagatorDecide(void*, Z3_solver_callback, Z3_ast, unsigned, bool);
+
+static inline void z3goPropagateInit(Z3_context c, Z3_solver s, uintptr_t id) {
+	Z3_solver_propagate_init(c, s, (void*)id, goPropagatorPush, goPropagatorPop, goPropagatorFresh);
+}
+static inline void z3goPropagateFixed(Z3_context c, Z3_solver s) {
+	Z3_solver_propagate_fixed(c, s, goPropagatorFixed);
+}
+static inline void z3goPropagateEq(Z3_context c, Z3_solver s) {
+	Z3_solver_propagate_eq(c, s, goPropagatorEq);
+}
+static inline void z3goPropagateDiseq(Z3_context c, Z3_solver s) {
+	Z3_solver_propagate_diseq(c, s, goPropagatorDiseq);
+}
+static inline void z3goPropagateFinal(Z3_context c, Z3_solver s) {
+	Z3_solver_propagate_final(c, s, goPropagatorFinal);
+}
+static inline void z3goPropagateDecide(Z3_context c, Z3_solver s) {
+	Z3_solver_propagate_decide(c, s, goPropagatorDecide);
+}
+*/
+import "C"
+
+// A Propagator is a user-defined theory that participates in a
+// Solver's search. The solver reports the values it assigns to
+// registered expressions (see Solver.Register) by calling the
+// Propagator's callbacks, and the callbacks may respond with
+// consequences or conflicts through their PropagatorCallback.
+//
+// Every callback is optional. Callbacks are called synchronously
+// from the goroutine running Solver.Check (and Push and Pop), which
+// holds the Solver's Context locked, so other goroutines using the
+// Context block until Check returns. Callbacks must not call methods
+// on the Solver, its Context, or Values created outside callbacks,
+// since these would wait for the lock forever. Instead, they should
+// use the Values they are passed and cb.Context, a handle to the same
+// Z3 context whose operations run under the lock Check holds.
+type Propagator struct {
+	// Push is called when the solver creates a backtracking
+	// point. The Propagator should save any state it needs to
+	// restore in Pop.
+	Push func()
+
+	// Pop is called when the solver backtracks n levels.
+	Pop func(n int)
+
+	// Fixed is called when the solver assigns value to a
+	// registered expression x.
+	Fixed func(cb *PropagatorCallback, x, value Value)
+
+	// Eq and Diseq are called when the solver determines that
+	// registered expressions x and y are equal or not equal.
+	Eq    func(cb *PropagatorCallback, x, y Value)
+	Diseq func(cb *PropagatorCallback, x, y Value)
+
+	// Decide is called when the solver is about to branch on bit
+	// i of registered expression x (i is 0 for Bools), assigning
+	// it phase. Decide may override the choice with
+	// cb.NextSplit.
+	Decide func(cb *PropagatorCallback, x Value, i int, phase bool)
+
+	// Final is called when the solver has a complete assignment
+	// to the registered expressions. If Final does not propagate
+	// a consequence or conflict, the solver accepts the
+	// assignment.
+	Final func(cb *PropagatorCallback)
+}
+
+// A PropagatorCallback lets a Propagator callback report
+// consequences to the solver. It is only valid for the duration of
+// the callback.
+type PropagatorCallback struct {
+	ctx *Context
+	c   C.Z3_solver_callback
+}
+
+// Context returns the callback handle of the Solver's Context. The
+// callback can use it to create Values, such as consequences to
+// pass to Propagate.
+func (cb *PropagatorCallback) Context() *Context {
+	return cb.ctx
+}
+
+// propagatorState is a Propagator attached to a solver.
+type propagatorState struct {
+	p *Propagator
+}
+
+// SetPropagator attaches p to s. A Solver can have at most one
+// Propagator, and it must be attached before any expressions are
+// registered.
+func (s *Solver) SetPropagator(p *Propagator) {
+	// The state lives as long as s does; see NewSolver.
+	id := newCallback(s.ctx, &propagatorState{p})
+	s.callbacks = append(s.callbacks, id)
+
+	s.do(func() {
+		C.z3goPropagateInit(s.ctx.c, s.c, C.uintptr_t(id))
+		if p.Fixed != nil {
+			C.z3goPropagateFixed(s.ctx.c, s.c)
+		}
+		if p.Eq != nil {
+			C.z3goPropagateEq(s.ctx.c, s.c)
+		}
+		if p.Diseq != nil {
+			C.z3goPropagateDiseq(s.ctx.c, s.c)
+		}
+		if p.Final != nil {
+			C.z3goPropagateFinal(s.ctx.c, s.c)
+		}
+		if p.Decide != nil {
+			C.z3goPropagateDecide(s.ctx.c, s.c)
+		}
+	})
+	runtime.KeepAlive(s)
+}
+
+// Register adds x to the expressions tracked by s's Propagator. x
+// must be a Bool or BV.
+func (s *Solver) Register(x Value) {
+	s.do(func() {
+		C.Z3_solver_propagate_register(s.ctx.c, s.c, x.impl().c)
+	})
+	runtime.KeepAlive(s)
+	runtime.KeepAlive(x)
+}
+
+// Register adds x to the expressions tracked by the Propagator from
+// within a callback.
+func (cb *PropagatorCallback) Register(x Value) {
+	cb.ctx.do(func() {
+		C.Z3_solver_propagate_register_cb(cb.ctx.c, cb.c, x.impl().c)
+	})
+	runtime.KeepAlive(x)
+}
+
+// Propagate tells the solver that conseq follows from the current
+// values of the registered expressions in fixed and the equalities
+// between each pair in eqs. It returns false if the solver already
+// knows conseq, in which case the propagation is ignored.
+func (cb *PropagatorCallback) Propagate(fixed []Value, eqs [][2]Value, conseq Bool) bool {
+	cfixed := valuesToC(fixed)
+	lhs, rhs := make([]C.Z3_ast, len(eqs)), make([]C.Z3_ast, len(eqs))
+	for i, eq := range eqs {
+		lhs[i], rhs[i] = eq[0].impl().c, eq[1].impl().c
+	}
+	var ok bool
+	cb.ctx.do(func() {
+		ok = bool(C.Z3_solver_propagate_consequence(cb.ctx.c, cb.c, C.uint(len(cfixed)), astPtr(cfixed), C.uint(len(eqs)), astPtr(lhs), astPtr(rhs), conseq.c))
+	})
+	runtime.KeepAlive(fixed)
+	runtime.KeepAlive(eqs)
+	runtime.KeepAlive(conseq)
+	return ok
+}
+
+// Conflict tells the solver that the current values of the
+// registered expressions in fixed are inconsistent.
+func (cb *PropagatorCallback) Conflict(fixed ...Value) {
+	cb.Propagate(fixed, nil, cb.ctx.FromBool(false))
+}
+
+// NextSplit asks the solver to branch next on bit i of registered
+// expression x (i is 0 for Bools) with the given phase. It returns
+// false if x is already assigned.
+func (cb *PropagatorCallback) NextSplit(x Value, i int, phase bool) bool {
+	cphase := C.Z3_lbool(C.Z3_L_FALSE)
+	if phase {
+		cphase = C.Z3_L_TRUE
+	}
+	var ok bool
+	cb.ctx.do(func() {
+		ok = bool(C.Z3_solver_next_split(cb.ctx.c, cb.c, x.impl().c, C.uint(i), cphase))
+	})
+	runtime.KeepAlive(x)
+	return ok
+}
+
+// astPtr returns a pointer to the first element of asts, or nil if
+// asts is empty.
+func astPtr(asts []C.Z3_ast) *C.Z3_ast {
+	if len(asts) == 0 {
+		return nil
+	}
+	return &asts[0]
+}
+
+// withPropagator runs f with the callback handle of the solver's
+// Context and the propagator identified by id.
+func withPropagator(id unsafe.Pointer, f func(ctx *Context, st *propagatorState)) {
+	callback(id, func(ctx *Context, v interface{}) {
+		f(ctx, v.(*propagatorState))
+	})
+}
+
+// callbackValue wraps an AST passed to a callback as a Value in ctx,
+// the callback handle.
+func callbackValue(ctx *Context, c C.Z3_ast) Value {
+	return wrapValue(ctx, func() C.Z3_ast { return c }).lift(KindUnknown)
+}
+
+//export goPropagatorPush
+func goPropagatorPush(id unsafe.Pointer, cb C.Z3_solver_callback) {
+	withPropagator(id, func(ctx *Context, st *propagatorState) {
+		if st.p.Push != nil {
+			st.p.Push()
+		}
+	})
+}
+
+//export goPropagatorPop
+func goPropagatorPop(id unsafe.Pointer, cb C.Z3_solver_callback, n C.unsigned) {
+	withPropagator(id, func(ctx *Context, st *propagatorState) {
+		if st.p.Pop != nil {
+			st.p.Pop(int(n))
+		}
+	})
+}
+
+//export goPropagatorFresh
+func goPropagatorFresh(id unsafe.Pointer, ctx C.Z3_context) unsafe.Pointer {
+	// Propagators do not follow a solver into another context.
+	return nil
+}
+
+//export goPropagatorFixed
+func goPropagatorFixed(id unsafe.Pointer, cb C.Z3_solver_callback, x, val C.Z3_ast) {
+	withPropagator(id, func(ctx *Context, st *propagatorState) {
+		st.p.Fixed(&PropagatorCallback{ctx, cb}, callbackValue(ctx, x), callbackValue(ctx, val))
+	})
+}
+
+//export goPropagatorEq
+func goPropagatorEq(id unsafe.Pointer, cb C.Z3_solver_callback, x, y C.Z3_ast) {
+	withPropagator(id, func(ctx *Context, st *propagatorState) {
+		st.p.Eq(&PropagatorCallback{ctx, cb}, callbackValue(ctx, x), callbackValue(ctx, y))
+	})
+}
+
+//export goPropagatorDiseq
+func goPropagatorDiseq(id unsafe.Pointer, cb C.Z3_solver_callback, x, y C.Z3_ast) {
+	withPropagator(id, func(ctx *Context, st *propagatorState) {
+		st.p.Diseq(&PropagatorCallback{ctx, cb}, callbackValue(ctx, x), callbackValue(ctx, y))
+	})
+}
+
+//export goPropagatorFinal
+func goPropagatorFinal(id unsafe.Pointer, cb C.Z3_solver_callback) {
+	withPropagator(id, func(ctx *Context, st *propagatorState) {
+		st.p.Final(&PropagatorCallback{ctx, cb})
+	})
+}
+
+//export goPropagatorDecide
+func goPropagatorDecide(id unsafe.Pointer, cb C.Z3_solver_callback, x C.Z3_ast, i C.unsigned, phase C.bool) {
+	withPropagator(id, func(ctx *Context, st *propagatorState) {
+		st.p.Decide(&PropagatorCallback{ctx, cb}, callbackValue(ctx, x), int(i), bool(phase))
+	})
+}
@@ -0,0 +1,130 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"testing"
+	"time"
+)
+
+// atMostOne returns a Propagator that allows at most one registered
+// Bool to be true.
+func atMostOne() *Propagator {
+	var trail []Value // Registered Bools fixed to true.
+	var scopes []int
+	return &Propagator{
+		Push: func() {
+			scopes = append(scopes, len(trail))
+		},
+		Pop: func(n int) {
+			trail = trail[:scopes[len(scopes)-n]]
+			scopes = scopes[:len(scopes)-n]
+		},
+		Fixed: func(cb *PropagatorCallback, x, value Value) {
+			if val, _ := value.(Bool).AsBool(); !val {
+				return
+			}
+			for _, y := range trail {
+				cb.Conflict(x, y)
+				return
+			}
+			trail = append(trail, x)
+		},
+	}
+}
+
+func TestPropagator(t *testing.T) {
+	ctx := NewContext(nil)
+	xs := []Bool{ctx.BoolConst("a"), ctx.BoolConst("b"), ctx.BoolConst("c")}
+
+	s := NewSolver(ctx)
+	s.SetPropagator(atMostOne())
+	for _, x := range xs {
+		s.Register(x)
+	}
+	s.Assert(xs[0].Or(xs[1], xs[2]))
+	sat, err := s.Check()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sat {
+		t.Fatalf("want sat")
+	}
+	m := s.Model()
+	n := 0
+	for _, x := range xs {
+		if val, _ := m.Eval(x, true).(Bool).AsBool(); val {
+			n++
+		}
+	}
+	if n != 1 {
+		t.Errorf("model %v has %d true variables, want 1", m, n)
+	}
+
+	s.Assert(xs[1].Or(xs[2]))
+	s.Assert(xs[0].Or(xs[2]))
+	if sat, err := s.Check(); err != nil || !sat {
+		t.Fatalf("want sat, got %v, %v", sat, err)
+	}
+	if val, _ := s.Model().Eval(xs[2], true).(Bool).AsBool(); !val {
+		t.Errorf("want c true")
+	}
+
+	s.Assert(xs[0].And(xs[1]))
+	if sat, err := s.Check(); err != nil || sat {
+		t.Fatalf("want unsat, got %v, %v", sat, err)
+	}
+}
+
+func TestPropagatorCallbackLock(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.BoolConst("x")
+	s := NewSolver(ctx)
+	done := make(chan bool)
+	var fixed Value
+	s.SetPropagator(&Propagator{
+		Fixed: func(cb *PropagatorCallback, y, value Value) {
+			if fixed != nil {
+				return
+			}
+			fixed = y
+			// The callback may use its handle and the Values it
+			// is passed.
+			h := cb.Context()
+			if h == ctx || y.Context() != h {
+				t.Errorf("callback Values do not belong to the callback handle")
+			}
+			if got := y.String(); got != "x" {
+				t.Errorf("want x, got %s", got)
+			}
+			h.IntConst("tmp").AsAST().Release()
+			// Other goroutines must wait for Check.
+			go func() {
+				ctx.IntConst("y")
+				close(done)
+			}()
+			time.Sleep(10 * time.Millisecond)
+			select {
+			case <-done:
+				t.Errorf("operation ran concurrently with callback")
+			default:
+			}
+			wantPanic(t, "called from a callback", func() { h.Close() })
+		},
+	})
+	s.Register(x)
+	s.Assert(x)
+	if sat, err := s.Check(); err != nil || !sat {
+		t.Fatalf("want sat, got %v, %v", sat, err)
+	}
+	if fixed == nil {
+		t.Fatalf("Fixed not called")
+	}
+	<-done
+	// Values from callbacks remain usable after Check.
+	if got := fixed.String(); got != "x" {
+		t.Errorf("after Check, want x, got %s", got)
+	}
+}
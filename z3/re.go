@@ -0,0 +1,109 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "runtime"
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+*/
+import "C"
+
+// Re is a symbolic value representing a regular expression over
+// sequences (such as strings).
+//
+// Re values are constructed from literal strings using String.ToRe
+// and combined using methods like Union, Concat, and Star. A String
+// can be tested for membership in a regular language using
+// String.InRe.
+//
+// Re implements Value.
+type Re value
+
+func init() {
+	kindWrappers[KindRe] = func(x value) Value {
+		return Re(x)
+	}
+}
+
+// ReSort returns a sort for regular expressions over sequences of
+// sort seq. For regular expressions over strings, seq should be
+// ctx.StringSort().
+func (ctx *Context) ReSort(seq Sort) Sort {
+	var sort Sort
+	ctx.do(func() {
+		sort = wrapSort(ctx, C.Z3_mk_re_sort(ctx.c, seq.c), KindRe)
+	})
+	runtime.KeepAlive(seq)
+	return sort
+}
+
+// ReEmpty returns a regular expression of sort s that matches
+// nothing.
+func (ctx *Context) ReEmpty(s Sort) Re {
+	val := Re(wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_re_empty(ctx.c, s.c)
+	}))
+	runtime.KeepAlive(s)
+	return val
+}
+
+// ReFull returns a regular expression of sort s that matches every
+// sequence.
+func (ctx *Context) ReFull(s Sort) Re {
+	val := Re(wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_re_full(ctx.c, s.c)
+	}))
+	runtime.KeepAlive(s)
+	return val
+}
+
+//go:generate go run genwrap.go -t Re $GOFILE
+
+// ReRange returns a regular expression that matches any single
+// character between lo and hi, inclusive.
+//
+// lo and hi must be string literals of length 1.
+//
+//wrap:expr ReRange ctx:*Context lo:String hi:String : Z3_mk_re_range lo hi
+
+// Union returns a regular expression that matches l or any argument.
+//
+//wrap:expr Union Z3_mk_re_union l r...
+
+// Concat returns a regular expression that matches l followed by
+// each argument in order.
+//
+//wrap:expr Concat Z3_mk_re_concat l r...
+
+// Intersect returns a regular expression that matches only what l
+// and all arguments match.
+//
+//wrap:expr Intersect Z3_mk_re_intersect l r...
+
+// Complement returns a regular expression that matches everything l
+// does not match.
+//
+//wrap:expr Complement Z3_mk_re_complement l
+
+// Star returns the Kleene closure of l (zero or more repetitions).
+//
+//wrap:expr Star Z3_mk_re_star l
+
+// Plus returns a regular expression that matches one or more
+// repetitions of l.
+//
+//wrap:expr Plus Z3_mk_re_plus l
+
+// Option returns a regular expression that matches zero or one
+// repetitions of l.
+//
+//wrap:expr Option Z3_mk_re_option l
+
+// Loop returns a regular expression that matches between lo and hi
+// repetitions of l, inclusive. If hi is 0, there is no upper bound.
+//
+//wrap:expr Loop l lo:int hi:int : Z3_mk_re_loop l lo:unsigned hi:unsigned
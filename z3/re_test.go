@@ -0,0 +1,76 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestReMembership(t *testing.T) {
+	ctx := NewContext(nil)
+	ab := ctx.FromString("a").ToRe().Union(ctx.FromString("b").ToRe())
+	digits := ctx.ReRange(ctx.FromString("0"), ctx.FromString("9")).Loop(1, 3)
+	re := ab.Plus().Concat(digits)
+
+	for _, test := range []struct {
+		str  string
+		want bool
+	}{
+		{"a1", true},
+		{"abba123", true},
+		{"ab", false},
+		{"1", false},
+		{"a1234", false},
+		{"ac1", false},
+	} {
+		got := simplifyBool(t, ctx, ctx.FromString(test.str).InRe(re))
+		if got != test.want {
+			t.Errorf("%q in %s: got %v, want %v", test.str, re, got, test.want)
+		}
+	}
+
+	// Check that a matching string can be solved for.
+	x := ctx.StringConst("x")
+	s := NewSolver(ctx)
+	s.Assert(x.InRe(re))
+	s.Assert(x.Length().Eq(ctx.FromInt(4, ctx.IntSort()).(Int)))
+	if sat, err := s.Check(); !sat {
+		t.Fatalf("%s not satisfiable: %v", s, err)
+	}
+	s.Assert(x.InRe(ab.Star()))
+	if sat, err := s.Check(); sat || err != nil {
+		t.Fatalf("%s satisfiable: %v", s, err)
+	}
+}
+
+func TestFromRegexp(t *testing.T) {
+	ctx := NewContext(nil)
+	strs := []string{"", "a", "b", "ab", "abc", "aab", "ABC", "a1", "a12", "a123", "x\ny", "é", "-", "a-b"}
+	for _, pattern := range []string{
+		`a`, `ab|b`, `a*b`, `a+b?c?`, `[a-c]+`, `[^a-c]`, `(?i)abc`,
+		`a\d{1,2}`, `a\d{2,}`, `x.y`, `(?s)x.y`, `(a|b)(c|)`, `\w-\w`,
+	} {
+		re, err := ctx.FromRegexp(pattern)
+		if err != nil {
+			t.Errorf("FromRegexp(%q): %v", pattern, err)
+			continue
+		}
+		goRe := regexp.MustCompile(`^(?:` + pattern + `)$`)
+		for _, str := range strs {
+			want := goRe.MatchString(str)
+			got := simplifyBool(t, ctx, ctx.FromString(str).InRe(re))
+			if got != want {
+				t.Errorf("%q matches %q: got %v, want %v", str, pattern, got, want)
+			}
+		}
+	}
+
+	for _, pattern := range []string{`^a`, `a\b`, `(`} {
+		if _, err := ctx.FromRegexp(pattern); err == nil {
+			t.Errorf("FromRegexp(%q) succeeded, want error", pattern)
+		}
+	}
+}
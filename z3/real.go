@@ -32,7 +32,9 @@ func init() {
 func (ctx *Context) RealSort() Sort {
 	var sort Sort
 	ctx.do(func() {
-		sort = wrapSort(ctx, C.Z3_mk_real_sort(ctx.c), KindReal)
+		sort = ctx.cachedSort(&ctx.cache.realSort, KindReal, func() C.Z3_sort {
+			return C.Z3_mk_real_sort(ctx.c)
+		})
 	})
 	return sort
 }
@@ -74,6 +76,26 @@ func (lit Real) AsRat() (numer, denom Int, isLiteralRational bool) {
 	return numer, denom, true
 }
 
+// AsSmallRat returns the value of lit as an int64 numerator and
+// denominator, without converting it through a string. If lit is not
+// a literal or is not rational, it returns 0, 0, false, false. If lit
+// is a rational literal, but its numerator or denominator cannot be
+// represented as an int64, it returns 0, 0, true, false.
+func (lit Real) AsSmallRat() (numer, denom int64, isLiteralRational, ok bool) {
+	lit.ctx.do(func() {
+		if C.Z3_get_ast_kind(lit.ctx.c, lit.c) != C.Z3_NUMERAL_AST {
+			return
+		}
+		isLiteralRational = true
+		var cnumer, cdenom C.int64_t
+		if z3ToBool(C.Z3_get_numeral_small(lit.ctx.c, lit.c, &cnumer, &cdenom)) {
+			numer, denom, ok = int64(cnumer), int64(cdenom), true
+		}
+	})
+	runtime.KeepAlive(lit)
+	return
+}
+
 // AsBigRat returns the value of lit as a math/big.Rat. If lit is not
 // a literal or is not rational, it returns nil, false.
 func (lit Real) AsBigRat() (val *big.Rat, isLiteralRational bool) {
@@ -93,13 +115,7 @@ func (lit Real) AsBigRat() (val *big.Rat, isLiteralRational bool) {
 // lit is not an irrational literal, it returns false for
 // isLiteralIrrational.
 func (lit Real) Approx(precision int) (lower, upper Real, isLiteralIrrational bool) {
-	var isAlgebraicNumber bool
-	lit.ctx.do(func() {
-		// Despite the name, this really means an *irrational*
-		// algebraic number.
-		isAlgebraicNumber = z3ToBool(C.Z3_is_algebraic_number(lit.ctx.c, lit.c))
-	})
-	if !isAlgebraicNumber {
+	if !lit.isIrrational() {
 		return Real{}, Real{}, false
 	}
 	lower = Real(wrapValue(lit.ctx, func() C.Z3_ast {
@@ -112,8 +128,105 @@ func (lit Real) Approx(precision int) (lower, upper Real, isLiteralIrrational bo
 	return lower, upper, true
 }
 
-// TODO: AsBigFloat? AsFloat64? AsFloat32? I don't actually know how
-// to implement those without potentially double rounding.
+// AsRoot returns lit as a root of a polynomial with rational
+// coefficients. coeffs are the coefficients of the polynomial, from
+// the constant term to the leading coefficient, and lit is the
+// index'th smallest real root of this polynomial, counting from 1.
+// If lit is not an irrational literal, it returns false for
+// isLiteralIrrational.
+func (lit Real) AsRoot() (coeffs []*big.Rat, index int, isLiteralIrrational bool) {
+	if !lit.isIrrational() {
+		return nil, 0, false
+	}
+	var cvec C.Z3_ast_vector
+	var n C.uint
+	lit.ctx.do(func() {
+		cvec = C.Z3_algebraic_get_poly(lit.ctx.c, lit.c)
+		C.Z3_ast_vector_inc_ref(lit.ctx.c, cvec)
+		n = C.Z3_ast_vector_size(lit.ctx.c, cvec)
+		index = int(C.Z3_algebraic_get_i(lit.ctx.c, lit.c))
+		if index == 0 {
+			// Z3 computes the root index lazily and
+			// reports 0 until something forces it, such
+			// as printing the number.
+			C.Z3_ast_to_string(lit.ctx.c, lit.c)
+			index = int(C.Z3_algebraic_get_i(lit.ctx.c, lit.c))
+		}
+	})
+	defer lit.ctx.do(func() { C.Z3_ast_vector_dec_ref(lit.ctx.c, cvec) })
+	coeffs = make([]*big.Rat, n)
+	for i := C.uint(0); i < n; i++ {
+		coeff := Real(wrapValue(lit.ctx, func() C.Z3_ast {
+			return C.Z3_ast_vector_get(lit.ctx.c, cvec, i)
+		}))
+		coeffs[i], _ = coeff.AsBigRat()
+	}
+	runtime.KeepAlive(lit)
+	return coeffs, index, true
+}
+
+// isIrrational returns true if lit is an irrational algebraic
+// literal.
+func (lit Real) isIrrational() bool {
+	var res bool
+	lit.ctx.do(func() {
+		// Despite the name, this really means an *irrational*
+		// algebraic number.
+		res = z3ToBool(C.Z3_is_algebraic_number(lit.ctx.c, lit.c))
+	})
+	runtime.KeepAlive(lit)
+	return res
+}
+
+// AsFloat64 returns the value of lit as a float64. If lit is not a
+// literal or is not rational, it returns 0, false, false. If lit's
+// value cannot be represented exactly as a float64, it returns the
+// nearest float64, true, false.
+//
+// To convert an irrational literal, use Approx or AsDecimalString.
+func (lit Real) AsFloat64() (val float64, isLiteralRational, ok bool) {
+	rat, isLiteralRational := lit.AsBigRat()
+	if !isLiteralRational {
+		return 0, false, false
+	}
+	val, ok = rat.Float64()
+	return val, true, ok
+}
+
+// AsDecimalString returns the value of lit in decimal notation with
+// at most precision digits after the decimal point. If the value was
+// truncated, the result ends in "?". Unlike AsRat, this works for
+// both rational and irrational literals. If lit is not a literal, it
+// returns "", false.
+func (lit Real) AsDecimalString(precision int) (val string, isLiteral bool) {
+	lit.ctx.do(func() {
+		if !z3ToBool(C.Z3_is_numeral_ast(lit.ctx.c, lit.c)) && !z3ToBool(C.Z3_is_algebraic_number(lit.ctx.c, lit.c)) {
+			return
+		}
+		isLiteral = true
+		val = C.GoString(C.Z3_get_numeral_decimal_string(lit.ctx.c, lit.c, C.unsigned(precision)))
+	})
+	runtime.KeepAlive(lit)
+	return val, isLiteral
+}
+
+// Ceil returns the ceiling of l as sort Int.
+func (l Real) Ceil() Int {
+	return l.Neg().ToInt().Neg()
+}
+
+// Round returns l rounded to the nearest integer as sort Int. Halfway
+// values are rounded toward +inf, so Round(2.5) is 3 and Round(-2.5)
+// is -2.
+func (l Real) Round() Int {
+	return l.Add(l.ctx.FromBigRat(big.NewRat(1, 2))).ToInt()
+}
+
+// Abs returns the absolute value of l.
+func (l Real) Abs() Real {
+	zero := l.ctx.FromInt(0, l.Sort()).(Real)
+	return l.If(l.GE(zero), l.Neg())
+}
 
 //go:generate go run genwrap.go -t Real $GOFILE intreal.go
 
@@ -123,6 +236,13 @@ func (lit Real) Approx(precision int) (lower, upper Real, isLiteralIrrational bo
 //
 //wrap:expr Div Z3_mk_div l r
 
+// Exp returns l raised to the power r.
+//
+// The result may be irrational. If l and r are both 0, or l is
+// negative and r is not an integer, the result is unspecified.
+//
+//wrap:expr Exp Z3_mk_power l r
+
 // ToInt returns the floor of l as sort Int.
 //
 // Note that this is not truncation. For example, ToInt(-1.3) is -2.
@@ -15,7 +15,7 @@ import "C"
 func (l Real) Eq(r Real) Bool {
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_eq(ctx.c, l.c, r.c)
+		return C.Z3_mk_eq(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -27,14 +27,43 @@ func (l Real) NE(r Real) Bool {
 	return l.ctx.Distinct(l, r)
 }
 
+// If returns a Value equal to l if cond is true, otherwise r.
+//
+// This is a typed form of Bool.IfThenElse.
+func (l Real) If(cond Bool, r Real) Real {
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_ite(ctx.c, cond.cref(), l.cref(), r.cref())
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(cond)
+	runtime.KeepAlive(r)
+	return Real(val)
+}
+
 // Div returns l / r.
 //
 // If r is 0, the result is unconstrained.
 func (l Real) Div(r Real) Real {
-	// Generated from real.go:124.
+	// Generated from real.go:237.
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_div(ctx.c, l.cref(), r.cref())
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return Real(val)
+}
+
+// Exp returns l raised to the power r.
+//
+// The result may be irrational. If l and r are both 0, or l is
+// negative and r is not an integer, the result is unspecified.
+func (l Real) Exp(r Real) Real {
+	// Generated from real.go:244.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_div(ctx.c, l.c, r.c)
+		return C.Z3_mk_power(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -45,10 +74,10 @@ func (l Real) Div(r Real) Real {
 //
 // Note that this is not truncation. For example, ToInt(-1.3) is -2.
 func (l Real) ToInt() Int {
-	// Generated from real.go:130.
+	// Generated from real.go:250.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_real2int(ctx.c, l.c)
+		return C.Z3_mk_real2int(ctx.c, l.cref())
 	})
 	runtime.KeepAlive(l)
 	return Int(val)
@@ -56,10 +85,10 @@ func (l Real) ToInt() Int {
 
 // IsInt returns a Value that is true if l has no fractional part.
 func (l Real) IsInt() Bool {
-	// Generated from real.go:134.
+	// Generated from real.go:254.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_is_int(ctx.c, l.c)
+		return C.Z3_mk_is_int(ctx.c, l.cref())
 	})
 	runtime.KeepAlive(l)
 	return Bool(val)
@@ -70,11 +99,11 @@ func (l Real) IsInt() Bool {
 // If necessary, the result will be rounded according to the current
 // rounding mode.
 func (l Real) ToFloat(s Sort) Float {
-	// Generated from real.go:141.
+	// Generated from real.go:261.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_to_fp_real(ctx.c, rm.c, l.c, s.c)
+		return C.Z3_mk_fpa_to_fp_real(ctx.c, rm.c, l.cref(), s.c)
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(s)
@@ -86,11 +115,11 @@ func (l Real) ToFloat(s Sort) Float {
 // If necessary, the result will be rounded according to the current
 // rounding mode.
 func (l Real) ToFloatExp(exp Int, s Sort) Float {
-	// Generated from real.go:148.
+	// Generated from real.go:268.
 	ctx := l.ctx
 	rm := ctx.rm()
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_fpa_to_fp_int_real(ctx.c, rm.c, exp.c, l.c, s.c)
+		return C.Z3_mk_fpa_to_fp_int_real(ctx.c, rm.c, exp.cref(), l.cref(), s.c)
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(exp)
@@ -103,11 +132,11 @@ func (l Real) Add(r ...Real) Real {
 	// Generated from intreal.go:12.
 	ctx := l.ctx
 	cargs := make([]C.Z3_ast, len(r)+1)
-	cargs[0] = l.c
-	for i, arg := range r {
-		cargs[i+1] = arg.c
-	}
 	val := wrapValue(ctx, func() C.Z3_ast {
+		cargs[0] = l.cref()
+		for i, arg := range r {
+			cargs[i+1] = arg.cref()
+		}
 		return C.Z3_mk_add(ctx.c, C.uint(len(cargs)), &cargs[0])
 	})
 	runtime.KeepAlive(&cargs[0])
@@ -119,11 +148,11 @@ func (l Real) Mul(r ...Real) Real {
 	// Generated from intreal.go:16.
 	ctx := l.ctx
 	cargs := make([]C.Z3_ast, len(r)+1)
-	cargs[0] = l.c
-	for i, arg := range r {
-		cargs[i+1] = arg.c
-	}
 	val := wrapValue(ctx, func() C.Z3_ast {
+		cargs[0] = l.cref()
+		for i, arg := range r {
+			cargs[i+1] = arg.cref()
+		}
 		return C.Z3_mk_mul(ctx.c, C.uint(len(cargs)), &cargs[0])
 	})
 	runtime.KeepAlive(&cargs[0])
@@ -135,11 +164,11 @@ func (l Real) Sub(r ...Real) Real {
 	// Generated from intreal.go:20.
 	ctx := l.ctx
 	cargs := make([]C.Z3_ast, len(r)+1)
-	cargs[0] = l.c
-	for i, arg := range r {
-		cargs[i+1] = arg.c
-	}
 	val := wrapValue(ctx, func() C.Z3_ast {
+		cargs[0] = l.cref()
+		for i, arg := range r {
+			cargs[i+1] = arg.cref()
+		}
 		return C.Z3_mk_sub(ctx.c, C.uint(len(cargs)), &cargs[0])
 	})
 	runtime.KeepAlive(&cargs[0])
@@ -151,30 +180,18 @@ func (l Real) Neg() Real {
 	// Generated from intreal.go:24.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_unary_minus(ctx.c, l.c)
-	})
-	runtime.KeepAlive(l)
-	return Real(val)
-}
-
-// Exp returns lᶠ.
-func (l Real) Exp(r Real) Real {
-	// Generated from intreal.go:28.
-	ctx := l.ctx
-	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_power(ctx.c, l.c, r.c)
+		return C.Z3_mk_unary_minus(ctx.c, l.cref())
 	})
 	runtime.KeepAlive(l)
-	runtime.KeepAlive(r)
 	return Real(val)
 }
 
 // LT returns l < r.
 func (l Real) LT(r Real) Bool {
-	// Generated from intreal.go:32.
+	// Generated from intreal.go:28.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_lt(ctx.c, l.c, r.c)
+		return C.Z3_mk_lt(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -183,10 +200,10 @@ func (l Real) LT(r Real) Bool {
 
 // LE returns l <= r.
 func (l Real) LE(r Real) Bool {
-	// Generated from intreal.go:36.
+	// Generated from intreal.go:32.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_le(ctx.c, l.c, r.c)
+		return C.Z3_mk_le(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -195,10 +212,10 @@ func (l Real) LE(r Real) Bool {
 
 // GT returns l > r.
 func (l Real) GT(r Real) Bool {
-	// Generated from intreal.go:40.
+	// Generated from intreal.go:36.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_gt(ctx.c, l.c, r.c)
+		return C.Z3_mk_gt(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -207,10 +224,10 @@ func (l Real) GT(r Real) Bool {
 
 // GE returns l >= r.
 func (l Real) GE(r Real) Bool {
-	// Generated from intreal.go:44.
+	// Generated from intreal.go:40.
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_ge(ctx.c, l.c, r.c)
+		return C.Z3_mk_ge(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
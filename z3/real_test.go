@@ -67,3 +67,183 @@ func TestRealIrrational(t *testing.T) {
 		}
 	}
 }
+
+func TestFromBig(t *testing.T) {
+	ctx := NewContext(nil)
+	huge, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	for _, val := range []*big.Int{big.NewInt(0), big.NewInt(-7), huge} {
+		got, isLit := ctx.FromBigInt(val, ctx.IntSort()).(Int).AsBigInt()
+		if !isLit || got.Cmp(val) != 0 {
+			t.Errorf("FromBigInt(%v, Int) = %v, %v", val, got, isLit)
+		}
+		rat, isLit := ctx.FromBigInt(val, ctx.RealSort()).(Real).AsBigRat()
+		if !isLit || !rat.IsInt() || rat.Num().Cmp(val) != 0 {
+			t.Errorf("FromBigInt(%v, Real) = %v, %v", val, rat, isLit)
+		}
+	}
+
+	// Bit-vector literals wrap modulo 2^n.
+	bv, _ := ctx.Simplify(ctx.FromBigInt(huge, ctx.BVSort(16)), nil).(BV).AsBigUnsigned()
+	if want := new(big.Int).Mod(huge, big.NewInt(1<<16)); bv.Cmp(want) != 0 {
+		t.Errorf("FromBigInt(%v, BV 16) = %v, want %v", huge, bv, want)
+	}
+
+	for _, val := range []*big.Rat{big.NewRat(-3, 4), new(big.Rat).SetFrac(huge, big.NewInt(7))} {
+		got, isLit := ctx.FromBigRat(val).AsBigRat()
+		if !isLit || got.Cmp(val) != 0 {
+			t.Errorf("FromBigRat(%v) = %v, %v", val, got, isLit)
+		}
+	}
+}
+
+func TestRealNumeric(t *testing.T) {
+	ctx := NewContext(nil)
+	for _, test := range []struct {
+		rat  *big.Rat
+		f    float64
+		ok   bool
+		prec int
+		dec  string
+	}{
+		{big.NewRat(5, 4), 1.25, true, 5, "1.25"},
+		{big.NewRat(-1, 3), -1.0 / 3, false, 4, "-0.3333?"},
+		{big.NewRat(7, 1), 7, true, 2, "7"},
+	} {
+		lit := ctx.FromBigRat(test.rat)
+		f, isLit, ok := lit.AsFloat64()
+		if f != test.f || !isLit || ok != test.ok {
+			t.Errorf("(%s).AsFloat64() = %v, %v, %v; want %v, true, %v", lit, f, isLit, ok, test.f, test.ok)
+		}
+		dec, isLit := lit.AsDecimalString(test.prec)
+		if dec != test.dec || !isLit {
+			t.Errorf("(%s).AsDecimalString(%d) = %q, %v; want %q, true", lit, test.prec, dec, isLit, test.dec)
+		}
+	}
+
+	root2 := ctx.Simplify(ctx.FromInt(2, ctx.RealSort()).(Real).Exp(ctx.FromBigRat(big.NewRat(1, 2))), nil).(Real)
+	if _, isLit, _ := root2.AsFloat64(); isLit {
+		t.Errorf("(%s).AsFloat64() succeeded for irrational", root2)
+	}
+	if dec, isLit := root2.AsDecimalString(6); dec != "1.414213?" || !isLit {
+		t.Errorf("(%s).AsDecimalString(6) = %q, %v; want \"1.414213?\", true", root2, dec, isLit)
+	}
+
+	x := ctx.RealConst("x")
+	if _, isLit := x.AsDecimalString(6); isLit {
+		t.Errorf("constant x is a literal")
+	}
+}
+
+func TestRealRoot(t *testing.T) {
+	ctx := NewContext(nil)
+	one, three := ctx.FromInt(1, ctx.RealSort()).(Real), ctx.FromInt(3, ctx.RealSort()).(Real)
+	want := []*big.Rat{big.NewRat(-1, 1), big.NewRat(-3, 1), big.NewRat(0, 1), big.NewRat(1, 1)}
+
+	// x^3 - 3x - 1 has three irrational roots, approximately
+	// -1.53, -0.35, and 1.88.
+	x := ctx.RealConst("x")
+	s := NewSolver(ctx)
+	s.Assert(x.Mul(x).Mul(x).Sub(three.Mul(x)).Eq(one))
+	for _, test := range []struct {
+		bound Bool
+		index int
+	}{
+		{x.LT(one.Neg()), 1},
+		{x.GT(one), 3},
+	} {
+		s.Push()
+		s.Assert(test.bound)
+		if sat, err := s.Check(); !sat {
+			t.Fatalf("%s not satisfiable: %v", s, err)
+		}
+		root := s.Model().Eval(x, true).(Real)
+		coeffs, index, isLit := root.AsRoot()
+		if !isLit {
+			t.Fatalf("(%s).AsRoot() returned false", root)
+		}
+		if index != test.index || len(coeffs) != len(want) {
+			t.Errorf("(%s).AsRoot() = %v, %d; want %v, %d", root, coeffs, index, want, test.index)
+		} else {
+			for i := range want {
+				if coeffs[i].Cmp(want[i]) != 0 {
+					t.Errorf("(%s).AsRoot() = %v, %d; want %v, %d", root, coeffs, index, want, test.index)
+					break
+				}
+			}
+		}
+		s.Pop()
+	}
+
+	root2 := ctx.Simplify(ctx.FromInt(2, ctx.RealSort()).(Real).Exp(ctx.FromBigRat(big.NewRat(1, 2))), nil).(Real)
+	if coeffs, index, _ := root2.AsRoot(); len(coeffs) != 3 || coeffs[0].Cmp(big.NewRat(-2, 1)) != 0 || index != 2 {
+		t.Errorf("(%s).AsRoot() = %v, %d; want [-2 0 1], 2", root2, coeffs, index)
+	}
+
+	if _, _, isLit := ctx.FromBigRat(big.NewRat(1, 2)).AsRoot(); isLit {
+		t.Errorf("1/2 is irrational")
+	}
+}
+
+func TestRealRounding(t *testing.T) {
+	ctx := NewContext(nil)
+	for _, test := range []struct {
+		val                *big.Rat
+		floor, ceil, round int64
+		isInt              bool
+	}{
+		{big.NewRat(5, 2), 2, 3, 3, false},
+		{big.NewRat(-5, 2), -3, -2, -2, false},
+		{big.NewRat(13, 10), 1, 2, 1, false},
+		{big.NewRat(-13, 10), -2, -1, -1, false},
+		{big.NewRat(4, 1), 4, 4, 4, true},
+	} {
+		lit := ctx.FromBigRat(test.val)
+		for _, x := range []struct {
+			name string
+			got  Int
+			want int64
+		}{
+			{"ToInt", lit.ToInt(), test.floor},
+			{"Ceil", lit.Ceil(), test.ceil},
+			{"Round", lit.Round(), test.round},
+		} {
+			if got, _, _ := ctx.Simplify(x.got, nil).(Int).AsInt64(); got != x.want {
+				t.Errorf("(%s).%s() = %d, want %d", test.val, x.name, got, x.want)
+			}
+		}
+		if got := simplifyBool(t, ctx, lit.IsInt()); got != test.isInt {
+			t.Errorf("(%s).IsInt() = %v, want %v", test.val, got, test.isInt)
+		}
+	}
+}
+
+func TestRealAsSmallRat(t *testing.T) {
+	ctx := NewContext(nil)
+	reals := ctx.RealSort()
+	third := ctx.FromInt(1, reals).(Real).Div(ctx.FromInt(-3, reals).(Real))
+	lit := ctx.Simplify(third, nil).(Real)
+	if n, d, isLit, ok := lit.AsSmallRat(); n != -1 || d != 3 || !isLit || !ok {
+		t.Errorf("AsSmallRat(-1/3) = %v, %v, %v, %v", n, d, isLit, ok)
+	}
+	huge, _ := new(big.Rat).SetString("1/100000000000000000000000")
+	if _, _, isLit, ok := ctx.FromBigRat(huge).AsSmallRat(); !isLit || ok {
+		t.Errorf("AsSmallRat(%v) = _, _, %v, %v, want true, false", huge, isLit, ok)
+	}
+	if _, _, isLit, _ := ctx.RealConst("x").AsSmallRat(); isLit {
+		t.Errorf("AsSmallRat of non-literal reported literal")
+	}
+}
+
+func TestRealAbs(t *testing.T) {
+	ctx := NewContext(nil)
+	for _, test := range []struct{ val, want *big.Rat }{
+		{big.NewRat(-5, 2), big.NewRat(5, 2)},
+		{big.NewRat(5, 2), big.NewRat(5, 2)},
+		{big.NewRat(0, 1), big.NewRat(0, 1)},
+	} {
+		got, _ := ctx.Simplify(ctx.FromBigRat(test.val).Abs(), nil).(Real).AsBigRat()
+		if got == nil || got.Cmp(test.want) != 0 {
+			t.Errorf("Abs(%s) = %s, want %s", test.val, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"unicode"
+)
+
+// maxChar is the largest character supported by Z3's default
+// (Unicode) string encoding.
+const maxChar = 0x2FFFF
+
+// FromRegexp translates a Go regular expression (see package regexp)
+// into a Z3 regular expression over strings.
+//
+// Unlike regexp.MatchString, the resulting Re matches entire strings,
+// as if pattern were surrounded by ^(?: and )$. Capture groups are
+// treated as plain groups. Anchors and word boundaries are not
+// supported, and FromRegexp returns an error if pattern contains
+// them. Character classes are limited to the characters supported by
+// Z3.
+func (ctx *Context) FromRegexp(pattern string) (Re, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return Re{}, err
+	}
+	return ctx.fromRegexp(re.Simplify())
+}
+
+func (ctx *Context) fromRegexp(re *syntax.Regexp) (Re, error) {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return ctx.ReEmpty(ctx.ReSort(ctx.StringSort())), nil
+
+	case syntax.OpEmptyMatch:
+		return ctx.FromString("").ToRe(), nil
+
+	case syntax.OpLiteral:
+		if re.Flags&syntax.FoldCase == 0 {
+			return ctx.FromString(string(re.Rune)).ToRe(), nil
+		}
+		parts := make([]Re, len(re.Rune))
+		for i, r := range re.Rune {
+			parts[i] = ctx.foldRune(r)
+		}
+		return ctx.reConcat(parts), nil
+
+	case syntax.OpCharClass:
+		var parts []Re
+		for i := 0; i+1 < len(re.Rune); i += 2 {
+			lo, hi := re.Rune[i], re.Rune[i+1]
+			if lo > maxChar {
+				continue
+			}
+			if hi > maxChar {
+				hi = maxChar
+			}
+			parts = append(parts, ctx.ReRange(ctx.FromString(string(lo)), ctx.FromString(string(hi))))
+		}
+		return ctx.reUnion(parts), nil
+
+	case syntax.OpAnyCharNotNL:
+		return ctx.fromRegexp(&syntax.Regexp{Op: syntax.OpCharClass, Rune: []rune{0, '\n' - 1, '\n' + 1, maxChar}})
+
+	case syntax.OpAnyChar:
+		return ctx.fromRegexp(&syntax.Regexp{Op: syntax.OpCharClass, Rune: []rune{0, maxChar}})
+
+	case syntax.OpCapture:
+		return ctx.fromRegexp(re.Sub[0])
+
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest:
+		sub, err := ctx.fromRegexp(re.Sub[0])
+		if err != nil {
+			return Re{}, err
+		}
+		switch re.Op {
+		case syntax.OpStar:
+			return sub.Star(), nil
+		case syntax.OpPlus:
+			return sub.Plus(), nil
+		}
+		return sub.Option(), nil
+
+	case syntax.OpRepeat:
+		// Simplify rewrites most repeats, but may leave
+		// large counted repetitions.
+		sub, err := ctx.fromRegexp(re.Sub[0])
+		if err != nil {
+			return Re{}, err
+		}
+		switch {
+		case re.Max == 0:
+			return ctx.FromString("").ToRe(), nil
+		case re.Max < 0:
+			// Loop treats an upper bound of 0 as
+			// unbounded.
+			return sub.Loop(re.Min, 0), nil
+		}
+		return sub.Loop(re.Min, re.Max), nil
+
+	case syntax.OpConcat, syntax.OpAlternate:
+		parts := make([]Re, len(re.Sub))
+		for i, sub := range re.Sub {
+			var err error
+			if parts[i], err = ctx.fromRegexp(sub); err != nil {
+				return Re{}, err
+			}
+		}
+		if re.Op == syntax.OpConcat {
+			return ctx.reConcat(parts), nil
+		}
+		return ctx.reUnion(parts), nil
+	}
+	return Re{}, fmt.Errorf("unsupported regular expression %s", re)
+}
+
+// reConcat returns the concatenation of parts, or the regular
+// expression matching only the empty string if parts is empty.
+func (ctx *Context) reConcat(parts []Re) Re {
+	// Z3 rejects unary re.++ and re.union, so handle small cases
+	// specially.
+	switch len(parts) {
+	case 0:
+		return ctx.FromString("").ToRe()
+	case 1:
+		return parts[0]
+	}
+	return parts[0].Concat(parts[1:]...)
+}
+
+// reUnion returns the union of parts, or the regular expression
+// matching nothing if parts is empty.
+func (ctx *Context) reUnion(parts []Re) Re {
+	switch len(parts) {
+	case 0:
+		return ctx.ReEmpty(ctx.ReSort(ctx.StringSort()))
+	case 1:
+		return parts[0]
+	}
+	return parts[0].Union(parts[1:]...)
+}
+
+// foldRune returns a regular expression matching any case variant of
+// r.
+func (ctx *Context) foldRune(r rune) Re {
+	parts := []Re{ctx.FromString(string(r)).ToRe()}
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		parts = append(parts, ctx.FromString(string(f)).ToRe())
+	}
+	return ctx.reUnion(parts)
+}
@@ -0,0 +1,76 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "runtime"
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+*/
+import "C"
+
+// The special relations below are binary relations over a sort whose
+// ordering axioms Z3 handles with a dedicated decision procedure,
+// which is far more efficient than asserting the axioms with
+// quantifiers. Each is identified by its sort and an index, so
+// distinct indexes give distinct, unrelated relations.
+
+// PartialOrder returns the index'th partial order over sort: a
+// reflexive, antisymmetric, and transitive relation.
+func (ctx *Context) PartialOrder(sort Sort, index int) FuncDecl {
+	return ctx.specialRelation(sort, func() C.Z3_func_decl {
+		return C.Z3_mk_partial_order(ctx.c, sort.c, C.uint(index))
+	})
+}
+
+// LinearOrder returns the index'th linear order over sort: a partial
+// order in which every two elements are comparable.
+func (ctx *Context) LinearOrder(sort Sort, index int) FuncDecl {
+	return ctx.specialRelation(sort, func() C.Z3_func_decl {
+		return C.Z3_mk_linear_order(ctx.c, sort.c, C.uint(index))
+	})
+}
+
+// TreeOrder returns the index'th tree order over sort: a partial
+// order in which the elements less than any element are linearly
+// ordered, like the ancestors of a node in a tree.
+func (ctx *Context) TreeOrder(sort Sort, index int) FuncDecl {
+	return ctx.specialRelation(sort, func() C.Z3_func_decl {
+		return C.Z3_mk_tree_order(ctx.c, sort.c, C.uint(index))
+	})
+}
+
+// PiecewiseLinearOrder returns the index'th piecewise linear order
+// over sort: a partial order in which both the elements less than and
+// the elements greater than any element are linearly ordered. Such
+// an order is a disjoint union of linear orders, like the nodes of a
+// set of linked lists.
+func (ctx *Context) PiecewiseLinearOrder(sort Sort, index int) FuncDecl {
+	return ctx.specialRelation(sort, func() C.Z3_func_decl {
+		return C.Z3_mk_piecewise_linear_order(ctx.c, sort.c, C.uint(index))
+	})
+}
+
+func (ctx *Context) specialRelation(sort Sort, mk func() C.Z3_func_decl) FuncDecl {
+	var funcdecl FuncDecl
+	ctx.do(func() {
+		funcdecl = wrapFuncDecl(ctx, mk())
+	})
+	runtime.KeepAlive(sort)
+	return funcdecl
+}
+
+// TransitiveClosure returns the transitive closure of f, which must
+// be a binary relation: a relation R such that R(x, y) if there is a
+// chain x = z0, z1, ..., zn = y with n > 0 and f(zi, zi+1) for each i.
+func (f FuncDecl) TransitiveClosure() FuncDecl {
+	var res FuncDecl
+	f.ctx.do(func() {
+		res = wrapFuncDecl(f.ctx, C.Z3_mk_transitive_closure(f.ctx.c, f.c))
+	})
+	runtime.KeepAlive(f)
+	return res
+}
@@ -0,0 +1,58 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestSpecialRelations(t *testing.T) {
+	ctx := NewContext(nil)
+	node := ctx.UninterpretedSort("Node")
+	a, b, c := ctx.Const("a", node), ctx.Const("b", node), ctx.Const("c", node)
+	rel := func(r FuncDecl, x, y Value) Bool { return r.Apply(x, y).(Bool) }
+
+	check := func(r FuncDecl, want bool, fs ...Bool) {
+		t.Helper()
+		s := NewSolver(ctx)
+		for _, f := range fs {
+			s.Assert(f)
+		}
+		sat, err := s.Check()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sat != want {
+			t.Errorf("%v: %v satisfiable = %v, want %v", r, fs, sat, want)
+		}
+	}
+
+	po := ctx.PartialOrder(node, 0)
+	// Transitivity.
+	check(po, false, rel(po, a, b), rel(po, b, c), rel(po, a, c).Not())
+	// Antisymmetry.
+	check(po, false, rel(po, a, b), rel(po, b, a), a.(Uninterpreted).NE(b.(Uninterpreted)))
+	// Partial orders need not be total.
+	check(po, true, rel(po, a, b).Not(), rel(po, b, a).Not())
+
+	lo := ctx.LinearOrder(node, 0)
+	check(lo, false, rel(lo, a, b).Not(), rel(lo, b, a).Not())
+
+	// Elements below any element of a tree order are comparable,
+	// but elements above need not be.
+	tree := ctx.TreeOrder(node, 0)
+	check(tree, false, rel(tree, b, a), rel(tree, c, a), rel(tree, b, c).Not(), rel(tree, c, b).Not())
+	check(tree, true, rel(tree, a, b), rel(tree, a, c), rel(tree, b, c).Not(), rel(tree, c, b).Not())
+
+	plo := ctx.PiecewiseLinearOrder(node, 0)
+	check(plo, false, rel(plo, a, b), rel(plo, a, c), rel(plo, b, c).Not(), rel(plo, c, b).Not())
+
+	// Distinct indexes are unrelated.
+	po1 := ctx.PartialOrder(node, 1)
+	check(po1, true, rel(po, a, b), rel(po1, a, b).Not())
+
+	// Transitive closure.
+	edge := ctx.FuncDecl("edge", []Sort{node, node}, ctx.BoolSort())
+	path := edge.TransitiveClosure()
+	check(path, false, rel(edge, a, b), rel(edge, b, c), rel(path, a, c).Not())
+}
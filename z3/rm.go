@@ -0,0 +1,60 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+*/
+import "C"
+
+// RM is a symbolic value representing a floating-point rounding mode.
+//
+// Most floating-point operations implicitly use the Context's current
+// rounding mode (see Context.SetRoundingMode). RM values make it
+// possible to reason about the rounding mode itself, for example by
+// leaving it unconstrained using a constant of RoundingModeSort.
+//
+// RM implements Value.
+type RM value
+
+func init() {
+	kindWrappers[KindRoundingMode] = func(x value) Value {
+		return RM(x)
+	}
+}
+
+// RoundingModeSort returns the floating-point rounding mode sort.
+func (ctx *Context) RoundingModeSort() Sort {
+	var sort Sort
+	ctx.do(func() {
+		sort = wrapSort(ctx, C.Z3_mk_fpa_rounding_mode_sort(ctx.c), KindRoundingMode)
+	})
+	return sort
+}
+
+// FromRoundingMode returns the rounding mode literal for rm.
+func (ctx *Context) FromRoundingMode(rm RoundingMode) RM {
+	return RM(rm.ast(ctx))
+}
+
+// AsRoundingMode returns the value of lit as a RoundingMode. If lit
+// is not a literal, it returns 0, false.
+func (lit RM) AsRoundingMode() (val RoundingMode, isLiteral bool) {
+	for rm, op := range []C.Z3_decl_kind{
+		RoundToNearestEven: C.Z3_OP_FPA_RM_NEAREST_TIES_TO_EVEN,
+		RoundToNearestAway: C.Z3_OP_FPA_RM_NEAREST_TIES_TO_AWAY,
+		RoundToPositive:    C.Z3_OP_FPA_RM_TOWARD_POSITIVE,
+		RoundToNegative:    C.Z3_OP_FPA_RM_TOWARD_NEGATIVE,
+		RoundToZero:        C.Z3_OP_FPA_RM_TOWARD_ZERO,
+	} {
+		if lit.isAppOf(op) {
+			return RoundingMode(rm), true
+		}
+	}
+	return 0, false
+}
+
+//go:generate go run genwrap.go -t RM $GOFILE
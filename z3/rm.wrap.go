@@ -0,0 +1,42 @@
+// Generated by genwrap.go. DO NOT EDIT
+
+package z3
+
+import "runtime"
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+#include <stdlib.h>
+*/
+import "C"
+
+// Eq returns a Value that is true if l and r are equal.
+func (l RM) Eq(r RM) Bool {
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_eq(ctx.c, l.cref(), r.cref())
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(r)
+	return Bool(val)
+}
+
+// NE returns a Value that is true if l and r are not equal.
+func (l RM) NE(r RM) Bool {
+	return l.ctx.Distinct(l, r)
+}
+
+// If returns a Value equal to l if cond is true, otherwise r.
+//
+// This is a typed form of Bool.IfThenElse.
+func (l RM) If(cond Bool, r RM) RM {
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_ite(ctx.c, cond.cref(), l.cref(), r.cref())
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(cond)
+	runtime.KeepAlive(r)
+	return RM(val)
+}
@@ -0,0 +1,95 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+// SetRandomSeed seeds the random number generators that s uses to
+// make heuristic choices. The seed applies to both the SMT core and
+// the SAT core ("smt.random_seed" and "sat.random_seed"), since the
+// solver may dispatch to either.
+//
+// Changing the seed does not change whether a problem is
+// satisfiable, but it can dramatically change how long it takes the
+// solver to find out. See SolveWithSeeds.
+func (s *Solver) SetRandomSeed(seed uint) {
+	// The unqualified parameter is the default for every module.
+	s.Config().SetUint("random_seed", seed)
+}
+
+// A RestartStrategy determines when the SMT core abandons its current
+// search path and restarts, keeping what it has learned.
+type RestartStrategy uint
+
+const (
+	// RestartGeometric restarts after a number of conflicts
+	// that grows geometrically by the restart factor.
+	RestartGeometric RestartStrategy = iota
+
+	// RestartInnerOuter restarts using an inner geometric
+	// progression that is reset by an outer one.
+	RestartInnerOuter
+
+	// RestartLuby restarts according to the Luby sequence.
+	RestartLuby
+
+	// RestartFixed restarts after a fixed number of conflicts.
+	RestartFixed
+
+	// RestartArithmetic restarts after a number of conflicts
+	// that grows arithmetically.
+	RestartArithmetic
+)
+
+// RestartOptions configures how the SMT core of a Solver restarts.
+type RestartOptions struct {
+	// Strategy is the restart strategy ("smt.restart_strategy").
+	Strategy RestartStrategy
+
+	// Factor is the constant the restart threshold is multiplied
+	// by for the geometric strategies ("smt.restart_factor").
+	Factor float64
+
+	// Max is the maximum number of restarts, or 0 for no limit
+	// ("smt.restart.max").
+	Max uint
+}
+
+// DefaultRestartOptions returns the restart options Z3 uses by
+// default.
+func DefaultRestartOptions() RestartOptions {
+	return RestartOptions{Strategy: RestartInnerOuter, Factor: 1.1}
+}
+
+// SetRestarts sets the restart options of s's SMT core.
+func (s *Solver) SetRestarts(opts RestartOptions) {
+	cfg := newConfig(nil)
+	cfg.SetUint("smt.restart_strategy", uint(opts.Strategy))
+	cfg.SetFloat("smt.restart_factor", opts.Factor)
+	if opts.Max != 0 {
+		cfg.SetUint("smt.restart.max", opts.Max)
+	}
+	s.setParams(cfg)
+}
+
+// SolveWithSeeds checks s like Check, but if the result is unknown,
+// retries with each of seeds in turn as s's random seed (see
+// SetRandomSeed). Heuristic decisions can lead a solver into a
+// search it cannot finish within its resource limits, and a
+// different seed often avoids this.
+//
+// SolveWithSeeds first checks s with its current seed. It stops at
+// the first definitive result, or if s is interrupted, and otherwise
+// returns the *ErrSatUnknown from the last attempt. s keeps the seed
+// of the last attempt.
+func (s *Solver) SolveWithSeeds(seeds ...uint) (sat bool, err error) {
+	sat, err = s.Check()
+	for _, seed := range seeds {
+		if unk, ok := err.(*ErrSatUnknown); !ok || unk.Reason == "canceled" {
+			break
+		}
+		s.SetRandomSeed(seed)
+		sat, err = s.Check()
+	}
+	return sat, err
+}
@@ -0,0 +1,47 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSolveWithSeeds(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.IntConst("x")
+	s := NewSolver(ctx)
+	s.SetRandomSeed(7)
+	s.SetRestarts(RestartOptions{Strategy: RestartLuby, Factor: 1.5})
+	s.Assert(x.Mul(x).Eq(ctx.FromInt(49, ctx.IntSort()).(Int)))
+	if sat, err := s.SolveWithSeeds(1, 2, 3); !sat || err != nil {
+		t.Errorf("want sat, got %v, %v", sat, err)
+	}
+
+	// Twelve pigeons do not fit in eleven holes, but the solver
+	// cannot show this within a tiny resource limit.
+	s = NewSolver(ctx)
+	s.Config().SetUint("rlimit", 1)
+	const holes = 11
+	var p [holes + 1][holes]Bool
+	for i := range p {
+		for j := range p[i] {
+			p[i][j] = ctx.BoolConst(fmt.Sprintf("p%d_%d", i, j))
+		}
+		s.Assert(p[i][0].Or(p[i][1:]...))
+	}
+	for j := 0; j < holes; j++ {
+		for i := range p {
+			for k := i + 1; k < len(p); k++ {
+				s.Assert(p[i][j].And(p[k][j]).Not())
+			}
+		}
+	}
+	if _, err := s.SolveWithSeeds(1, 2); err == nil {
+		t.Errorf("want unknown, got %v", err)
+	} else if _, ok := err.(*ErrSatUnknown); !ok {
+		t.Errorf("want *ErrSatUnknown, got %T", err)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "runtime"
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+*/
+import "C"
+
+// Seq is a symbolic value representing a finite sequence of values
+// of some element sort.
+//
+// Sequences of characters are strings and are represented by type
+// String instead.
+//
+// Seq implements Value.
+type Seq value
+
+func init() {
+	kindWrappers[KindSeq] = func(x value) Value {
+		if x.Sort().isString() {
+			return String(x)
+		}
+		return Seq(x)
+	}
+}
+
+// SeqSort returns a sort for sequences of elem.
+func (ctx *Context) SeqSort(elem Sort) Sort {
+	var sort Sort
+	ctx.do(func() {
+		sort = wrapSort(ctx, C.Z3_mk_seq_sort(ctx.c, elem.c), KindSeq)
+	})
+	runtime.KeepAlive(elem)
+	return sort
+}
+
+// isString returns true if s is the string sort.
+func (s Sort) isString() bool {
+	var res bool
+	s.ctx.do(func() {
+		res = z3ToBool(C.Z3_is_string_sort(s.ctx.c, s.c))
+	})
+	runtime.KeepAlive(s)
+	return res
+}
+
+// SeqEmpty returns the empty sequence of sort s.
+func (ctx *Context) SeqEmpty(s Sort) Value {
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_empty(ctx.c, s.c)
+	})
+	runtime.KeepAlive(s)
+	return val.lift(KindSeq)
+}
+
+// SeqUnit returns the sequence containing only elem.
+func (ctx *Context) SeqUnit(elem Value) Value {
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_seq_unit(ctx.c, elem.impl().c)
+	})
+	runtime.KeepAlive(elem)
+	return val.lift(KindSeq)
+}
+
+//go:generate go run genwrap.go -t Seq $GOFILE seqstring.go
+
+// Nth returns the element of l at index i.
+//
+// If i is out of bounds, the result is unspecified.
+//
+//wrap:expr Nth:Value l i:Int : Z3_mk_seq_nth l i
+
+// Map returns the sequence obtained by applying f to each element of
+// l.
+//
+// f must be an array (typically a lambda; see Context.Lambda) whose
+// domain is l's element sort. The result is a sequence of f's range,
+// which will be a String if f's range is the character sort.
+//
+//wrap:expr Map:Value l f:Array : Z3_mk_seq_map f l
+
+// Mapi is like Map, but f takes two arguments: the index of each
+// element, starting from i, and the element itself.
+//
+//wrap:expr Mapi:Value l f:Array i:Int : Z3_mk_seq_mapi f i l
+
+// Foldl reduces l from the left using f, starting from init. That is,
+// it returns f(...f(f(init, l[0]), l[1])..., l[n-1]).
+//
+// f must be an array (typically a lambda; see Context.Lambda) with a
+// two-sort domain of init's sort and l's element sort. The result has
+// the same sort as init.
+//
+//wrap:expr Foldl:Value l f:Array init:Value : Z3_mk_seq_foldl f init l
+
+// Foldli is like Foldl, but f takes three arguments: the index of
+// each element, starting from i, the accumulator, and the element.
+//
+//wrap:expr Foldli:Value l f:Array i:Int init:Value : Z3_mk_seq_foldli f i init l
@@ -0,0 +1,50 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func intSeq(ctx *Context, vals ...int64) Seq {
+	ints := ctx.IntSort()
+	seq := ctx.SeqEmpty(ctx.SeqSort(ints)).(Seq)
+	for _, v := range vals {
+		seq = seq.Concat(ctx.SeqUnit(ctx.FromInt(v, ints)).(Seq))
+	}
+	return seq
+}
+
+func TestSeq(t *testing.T) {
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	seq := intSeq(ctx, 1, 2, 3)
+
+	if _, ok := ctx.SeqEmpty(ctx.StringSort()).(String); !ok {
+		t.Errorf("empty sequence of string sort is not a String")
+	}
+	if !simplifyBool(t, ctx, seq.Length().Eq(ctx.FromInt(3, ints).(Int))) {
+		t.Errorf("%s.Length() != 3", seq)
+	}
+	nth := seq.Nth(ctx.FromInt(1, ints).(Int)).(Int)
+	if !simplifyBool(t, ctx, nth.Eq(ctx.FromInt(2, ints).(Int))) {
+		t.Errorf("%s.Nth(1) != 2", seq)
+	}
+}
+
+func TestSeqFold(t *testing.T) {
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	seq := intSeq(ctx, 1, 2, 3)
+
+	acc, x := ctx.IntConst("acc"), ctx.IntConst("x")
+	sum := seq.Foldl(ctx.Lambda([]Value{acc, x}, acc.Add(x)), ctx.FromInt(0, ints)).(Int)
+	if !simplifyBool(t, ctx, sum.Eq(ctx.FromInt(6, ints).(Int))) {
+		t.Errorf("sum of %s = %s, want 6", seq, ctx.Simplify(sum, nil))
+	}
+
+	double := seq.Map(ctx.Lambda([]Value{x}, x.Add(x))).(Seq)
+	if !simplifyBool(t, ctx, double.Eq(intSeq(ctx, 2, 4, 6))) {
+		t.Errorf("doubling %s = %s, want [2, 4, 6]", seq, ctx.Simplify(double, nil))
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+// Methods that are common to both Seq and String. This file is passed
+// to genwrap.go twice with different default types.
+
+// Concat returns the concatenation of l and all arguments.
+//
+//wrap:expr Concat Z3_mk_seq_concat l r...
+
+// Length returns the length of l.
+//
+//wrap:expr Length:Int Z3_mk_seq_length l
+
+// At returns the length 1 subsequence of l at index i. If i is out of
+// bounds, the result is empty.
+//
+//wrap:expr At l i:Int : Z3_mk_seq_at l i
+
+// Extract returns the subsequence of l that starts at offset and has
+// the given length. If offset is out of bounds or length is negative,
+// the result is empty. If offset+length is past the end of l, the
+// result is truncated.
+//
+//wrap:expr Extract l offset:Int length:Int : Z3_mk_seq_extract l offset length
+
+// Contains returns a Value that is true if sub occurs in l.
+//
+//wrap:expr Contains:Bool l sub : Z3_mk_seq_contains l sub
+
+// HasPrefix returns a Value that is true if l begins with prefix.
+//
+//wrap:expr HasPrefix:Bool l prefix : Z3_mk_seq_prefix prefix l
+
+// HasSuffix returns a Value that is true if l ends with suffix.
+//
+//wrap:expr HasSuffix:Bool l suffix : Z3_mk_seq_suffix suffix l
+
+// IndexOf returns the index of the first occurrence of sub in l at or
+// after offset, or -1 if there is no such occurrence.
+//
+//wrap:expr IndexOf:Int l sub offset:Int : Z3_mk_seq_index l sub offset
+
+// LastIndexOf returns the index of the last occurrence of sub in l,
+// or -1 if sub does not occur in l.
+//
+//wrap:expr LastIndexOf:Int l sub : Z3_mk_seq_last_index l sub
+
+// Replace returns l with the first occurrence of src replaced by dst.
+// If src does not occur in l, the result is l.
+//
+//wrap:expr Replace l src dst : Z3_mk_seq_replace l src dst
+
+// ReplaceAll returns l with all non-overlapping occurrences of src
+// replaced by dst.
+//
+//wrap:expr ReplaceAll l src dst : Z3_mk_seq_replace_all l src dst
+
+// ToRe returns a regular expression that matches exactly l.
+//
+//wrap:expr ToRe:Re Z3_mk_seq_to_re l
+
+// InRe returns a Value that is true if l is in the language of
+// regular expression re.
+//
+//wrap:expr InRe:Bool l re:Re : Z3_mk_seq_in_re l re
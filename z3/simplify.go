@@ -33,7 +33,15 @@ func (ctx *Context) Simplify(x Value, config *Config) Value {
 }
 
 // NewSimplifyConfig returns *Config for configuring the simplifier.
+//
+// The simplifier accepts many parameters, such as "som" (put
+// polynomials in sum-of-monomials form), "elim_sign_ext", and
+// "bv_le2extract". Use the Params method of the returned Config to
+// list them.
 func NewSimplifyConfig(ctx *Context) *Config {
-	// TODO: Get the Z3_param_descr.
-	return newConfig(nil)
+	var desc []ParamDesc
+	ctx.do(func() {
+		desc = paramDescs(ctx, C.Z3_simplify_get_param_descrs(ctx.c))
+	})
+	return newConfig(desc)
 }
@@ -0,0 +1,35 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestSimplifyConfig(t *testing.T) {
+	ctx := NewContext(nil)
+	config := NewSimplifyConfig(ctx)
+
+	found := false
+	for _, p := range config.Params() {
+		if p.Name == "som" {
+			found = true
+			if p.Type != "bool" || p.Description == "" {
+				t.Errorf("bad description of som: %+v", p)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("simplifier parameters do not include som")
+	}
+
+	ints := ctx.IntSort()
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	e := x.Mul(y.Add(ctx.FromInt(1, ints).(Int)))
+	if got := ctx.Simplify(e, config.SetBool("som", false)).String(); got != "(* x (+ 1 y))" {
+		t.Errorf("Simplify(%v) with som=false = %s", e, got)
+	}
+	if got := ctx.Simplify(e, config.SetBool("som", true)).String(); got != "(+ x (* x y))" {
+		t.Errorf("Simplify(%v) with som=true = %s", e, got)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"runtime"
+	"unsafe"
+)
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+#include <stdlib.h>
+*/
+import "C"
+
+// ParseSMTLIB2 parses src as an SMT-LIB2 script and returns the
+// formulas it asserts. Commands other than declarations and asserts
+// are ignored.
+//
+// sorts and decls declare additional sorts and functions that src
+// may refer to by name. Constants and functions declared by src are
+// the same as those created in ctx with the same name and sort, so
+// parsed formulas may be freely combined with other Values.
+func (ctx *Context) ParseSMTLIB2(src string, sorts []Sort, decls []FuncDecl) (*ASTVector, error) {
+	csrc := C.CString(src)
+	defer C.free(unsafe.Pointer(csrc))
+	return ctx.parseSMTLIB2(sorts, decls, func(ns C.uint, sn *C.Z3_symbol, s *C.Z3_sort, nd C.uint, dn *C.Z3_symbol, d *C.Z3_func_decl) C.Z3_ast_vector {
+		return C.Z3_parse_smtlib2_string(ctx.c, csrc, ns, sn, s, nd, dn, d)
+	})
+}
+
+// ParseSMTLIB2File is like ParseSMTLIB2, but reads the script from
+// the named file.
+func (ctx *Context) ParseSMTLIB2File(filename string, sorts []Sort, decls []FuncDecl) (*ASTVector, error) {
+	cname := C.CString(filename)
+	defer C.free(unsafe.Pointer(cname))
+	return ctx.parseSMTLIB2(sorts, decls, func(ns C.uint, sn *C.Z3_symbol, s *C.Z3_sort, nd C.uint, dn *C.Z3_symbol, d *C.Z3_func_decl) C.Z3_ast_vector {
+		return C.Z3_parse_smtlib2_file(ctx.c, cname, ns, sn, s, nd, dn, d)
+	})
+}
+
+func (ctx *Context) parseSMTLIB2(sorts []Sort, decls []FuncDecl, parse func(C.uint, *C.Z3_symbol, *C.Z3_sort, C.uint, *C.Z3_symbol, *C.Z3_func_decl) C.Z3_ast_vector) (res *ASTVector, err error) {
+	csorts := make([]C.Z3_sort, len(sorts))
+	csortNames := make([]C.Z3_symbol, len(sorts))
+	cdecls := make([]C.Z3_func_decl, len(decls))
+	cdeclNames := make([]C.Z3_symbol, len(decls))
+	var psorts *C.Z3_sort
+	var psortNames, pdeclNames *C.Z3_symbol
+	var pdecls *C.Z3_func_decl
+	if len(sorts) > 0 {
+		psorts, psortNames = &csorts[0], &csortNames[0]
+	}
+	if len(decls) > 0 {
+		pdecls, pdeclNames = &cdecls[0], &cdeclNames[0]
+	}
+
+	// Z3 reports syntax errors through the error handler, which
+	// panics. Turn these into errors, since malformed input is
+	// not a programming error.
+	err = Try(func() {
+		ctx.do(func() {
+			for i, s := range sorts {
+				csorts[i] = s.c
+				csortNames[i] = C.Z3_get_sort_name(ctx.c, s.c)
+			}
+			for i, d := range decls {
+				cdecls[i] = d.c
+				cdeclNames[i] = C.Z3_get_decl_name(ctx.c, d.c)
+			}
+			cvec := parse(C.uint(len(sorts)), psortNames, psorts, C.uint(len(decls)), pdeclNames, pdecls)
+			res = wrapASTVector(ctx, cvec)
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("z3: parsing SMT-LIB2: %v", err)
+	}
+	runtime.KeepAlive(sorts)
+	runtime.KeepAlive(decls)
+	return res, nil
+}
+
+// BenchmarkString returns a complete SMT-LIB2 script that checks the
+// satisfiability of formula together with assumptions. The script
+// declares every constant and function used, asserts each formula,
+// and ends with (check-sat).
+//
+// name and logic (for example, "QF_BV") are recorded in the script
+// if non-empty. status is the expected result: "sat", "unsat", or
+// "unknown".
+func (ctx *Context) BenchmarkString(name, logic, status string, assumptions []Bool, formula Bool) string {
+	cname, clogic, cstatus, cattrs := C.CString(name), C.CString(logic), C.CString(status), C.CString("")
+	defer C.free(unsafe.Pointer(cname))
+	defer C.free(unsafe.Pointer(clogic))
+	defer C.free(unsafe.Pointer(cstatus))
+	defer C.free(unsafe.Pointer(cattrs))
+	cassumptions := boolsToC(assumptions)
+	var res string
+	ctx.do(func() {
+		res = C.GoString(C.Z3_benchmark_to_smtlib_string(ctx.c, cname, clogic, cstatus, cattrs, C.uint(len(cassumptions)), cptr(cassumptions), formula.c))
+	})
+	runtime.KeepAlive(assumptions)
+	runtime.KeepAlive(formula)
+	return res
+}
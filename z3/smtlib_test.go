@@ -0,0 +1,103 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseSMTLIB2(t *testing.T) {
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	elt := ctx.UninterpretedSort("Elt")
+	f := ctx.FuncDecl("f", []Sort{elt}, ints)
+
+	src := `
+(declare-const x Int)
+(declare-const e Elt)
+(assert (> x 2))
+(assert (= (f e) x))
+`
+	asserts, err := ctx.ParseSMTLIB2(src, []Sort{elt}, []FuncDecl{f})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := asserts.Len(); n != 2 {
+		t.Fatalf("got %d assertions, want 2", n)
+	}
+
+	// Parsed constants are the same as constants built in Go.
+	x := ctx.IntConst("x")
+	e := ctx.Const("e", elt)
+	want := f.Apply(e).(Int).Eq(x)
+	if got := asserts.Get(1); !got.Equal(want.AsAST()) {
+		t.Errorf("second assertion is %v, want %v", got, want)
+	}
+
+	s := NewSolver(ctx)
+	for _, a := range asserts.Slice() {
+		s.Assert(a.AsValue().(Bool))
+	}
+	s.Assert(x.LT(ctx.FromInt(3, ints).(Int)))
+	if sat, err := s.Check(); sat || err != nil {
+		t.Errorf("Check() = %v, %v, want unsat", sat, err)
+	}
+
+	if _, err := ctx.ParseSMTLIB2("(assert (> y 2))", nil, nil); err == nil {
+		t.Errorf("parsing undeclared constant succeeded")
+	}
+	// The context is still usable after an error.
+	ctx.IntConst("y").Add(x)
+}
+
+func TestParseSMTLIB2File(t *testing.T) {
+	dir, err := ioutil.TempDir("", "z3test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "test.smt2")
+	src := "(declare-const b Bool)\n(assert (not b))\n"
+	if err := ioutil.WriteFile(path, []byte(src), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := NewContext(nil)
+	asserts, err := ctx.ParseSMTLIB2File(path, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := asserts.String(); !strings.Contains(got, "(not b)") {
+		t.Errorf("got assertions %s, want (not b)", got)
+	}
+}
+
+func TestBenchmarkString(t *testing.T) {
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+	a := x.GT(ctx.FromInt(0, ints).(Int))
+	f := x.Add(y).Eq(ctx.FromInt(10, ints).(Int))
+
+	got := ctx.BenchmarkString("test", "QF_LIA", "sat", []Bool{a}, f)
+	for _, want := range []string{"(set-logic QF_LIA)", "(set-info :status sat)", "(declare-fun x () Int)", "(check-sat)"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("benchmark does not contain %s:\n%s", want, got)
+		}
+	}
+
+	// The benchmark parses back to the same formulas.
+	asserts, err := ctx.ParseSMTLIB2(got, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if asserts.Len() != 2 || !asserts.Get(0).Equal(a.AsAST()) || !asserts.Get(1).Equal(f.AsAST()) {
+		t.Errorf("benchmark parsed to %v", asserts)
+	}
+}
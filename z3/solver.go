@@ -4,12 +4,29 @@
 
 package z3
 
-import "runtime"
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"unsafe"
+)
 
 /*
 #cgo LDFLAGS: -lz3
 #include <z3.h>
 #include <stdlib.h>
+
+static inline void z3goSolverAssertAll(Z3_context c, Z3_solver s, unsigned n, Z3_ast *vals) {
+	unsigned i;
+	for (i = 0; i < n; i++) {
+		Z3_solver_assert(c, s, vals[i]);
+	}
+}
 */
 import "C"
 
@@ -26,41 +43,144 @@ type Solver struct {
 type solverImpl struct {
 	ctx *Context
 	c   C.Z3_solver
+
+	// callbacks are the IDs of callbacks registered with this
+	// solver. See newCallback.
+	callbacks []uintptr
+
+	// params records the parameters set on this solver, since Z3
+	// provides no way to retrieve them. It is protected by ctx's
+	// lock.
+	params map[string]interface{}
 }
 
 // NewSolver returns a new, empty solver.
 func NewSolver(ctx *Context) *Solver {
-	var impl *solverImpl
+	var s *Solver
 	ctx.do(func() {
-		impl = &solverImpl{
-			ctx,
-			C.Z3_mk_solver(ctx.c),
+		s = wrapSolver(ctx, C.Z3_mk_solver(ctx.c))
+	})
+	return s
+}
+
+// wrapSolver wraps a C Z3_solver as a Go Solver. This must be called
+// with the ctx.lock held.
+func wrapSolver(ctx *Context, c C.Z3_solver) *Solver {
+	impl := &solverImpl{ctx, c, nil, nil}
+	C.Z3_solver_inc_ref(ctx.c, c)
+	runtime.SetFinalizer(impl, (*solverImpl).release)
+	return &Solver{impl, noEq{}}
+}
+
+func (impl *solverImpl) release() {
+	impl.ctx.release(func() {
+		if impl.c != nil {
+			C.Z3_solver_dec_ref(impl.ctx.c, impl.c)
+			impl.c = nil
 		}
 	})
-	ctx.do(func() {
-		C.Z3_solver_inc_ref(ctx.c, impl.c)
+	for _, id := range impl.callbacks {
+		removeCallback(id)
+	}
+	impl.callbacks = nil
+}
+
+// Close releases s's native resources now, rather than when s is
+// garbage collected. Any use of s after Close panics, except for
+// calling Close again. Models and Values obtained from s remain
+// valid.
+func (s *Solver) Close() {
+	runtime.SetFinalizer(s.solverImpl, nil)
+	s.release()
+}
+
+// checkOpen panics if s has been closed. It must be called with the
+// Context's lock held.
+func (impl *solverImpl) checkOpen() {
+	if impl.c == nil {
+		panic("z3: use of closed Solver")
+	}
+}
+
+// do is like s.ctx.do, but panics if s has been closed.
+func (s *Solver) do(f func()) {
+	s.ctx.do(func() {
+		s.checkOpen()
+		f()
 	})
-	runtime.SetFinalizer(impl, func(impl *solverImpl) {
-		impl.ctx.do(func() {
-			C.Z3_solver_dec_ref(impl.ctx.c, impl.c)
-		})
+}
+
+// Context returns the Context that created s.
+func (s *Solver) Context() *Context {
+	return s.ctx
+}
+
+// Config returns a *Config for changing s's parameters, such as
+// "timeout" or "smt.random_seed". Changes take effect immediately.
+// The Config's Params method lists the available parameters.
+func (s *Solver) Config() *Config {
+	var desc []ParamDesc
+	s.do(func() {
+		desc = paramDescs(s.ctx, C.Z3_solver_get_param_descrs(s.ctx.c, s.c))
 	})
-	return &Solver{impl, noEq{}}
+	runtime.KeepAlive(s)
+	cfg := newConfig(desc)
+	cfg.set = func(name string, val interface{}) {
+		one := newConfig(nil)
+		one.m[name] = val
+		s.setParams(one)
+	}
+	return cfg
+}
+
+// setParams sets the parameters in cfg on s.
+func (s *Solver) setParams(cfg *Config) {
+	cparams := cfg.toC(s.ctx)
+	s.do(func() {
+		C.Z3_solver_set_params(s.ctx.c, s.c, cparams)
+		C.Z3_params_dec_ref(s.ctx.c, cparams)
+		if s.params == nil {
+			s.params = make(map[string]interface{})
+		}
+		for k, v := range cfg.m {
+			s.params[k] = v
+		}
+	})
+	runtime.KeepAlive(s)
 }
 
 // Assert adds val to the set of predicates that must be satisfied.
 func (s *Solver) Assert(val Bool) {
-	s.ctx.do(func() {
+	s.do(func() {
 		C.Z3_solver_assert(s.ctx.c, s.c, val.c)
 	})
 	runtime.KeepAlive(s)
 	runtime.KeepAlive(val)
 }
 
+// AssertAll adds all of vals to the set of predicates that must be
+// satisfied. This is equivalent to calling Assert for each of vals,
+// but is much faster for large numbers of predicates because it calls
+// into Z3 only once.
+func (s *Solver) AssertAll(vals []Bool) {
+	if len(vals) == 0 {
+		return
+	}
+	cvals := make([]C.Z3_ast, len(vals))
+	for i, val := range vals {
+		cvals[i] = val.c
+	}
+	s.do(func() {
+		C.z3goSolverAssertAll(s.ctx.c, s.c, C.uint(len(cvals)), &cvals[0])
+	})
+	runtime.KeepAlive(s)
+	runtime.KeepAlive(vals)
+}
+
 // Push saves the current state of the Solver so it can be restored
 // with Pop.
 func (s *Solver) Push() {
-	s.ctx.do(func() {
+	s.do(func() {
 		C.Z3_solver_push(s.ctx.c, s.c)
 	})
 	runtime.KeepAlive(s)
@@ -68,15 +188,49 @@ func (s *Solver) Push() {
 
 // Pop removes assertions that were added since the matching Push.
 func (s *Solver) Pop() {
-	s.ctx.do(func() {
+	s.do(func() {
 		C.Z3_solver_pop(s.ctx.c, s.c, 1)
 	})
 	runtime.KeepAlive(s)
 }
 
+// NumScopes returns the number of Pushes that have not been Popped.
+func (s *Solver) NumScopes() int {
+	var n C.uint
+	s.do(func() {
+		n = C.Z3_solver_get_num_scopes(s.ctx.c, s.c)
+	})
+	runtime.KeepAlive(s)
+	return int(n)
+}
+
+// WithScope runs f in a new scope of s and returns f's result.
+//
+// WithScope pushes a scope before calling f and restores the stack
+// to its original depth when f returns, even if f panics or leaves
+// its own Pushes unpopped. Hence any assertions f adds to s are
+// temporary.
+func (s *Solver) WithScope(f func() error) error {
+	var depth C.uint
+	s.do(func() {
+		depth = C.Z3_solver_get_num_scopes(s.ctx.c, s.c)
+		C.Z3_solver_push(s.ctx.c, s.c)
+	})
+	defer func() {
+		s.do(func() {
+			n := C.Z3_solver_get_num_scopes(s.ctx.c, s.c)
+			if n > depth {
+				C.Z3_solver_pop(s.ctx.c, s.c, n-depth)
+			}
+		})
+		runtime.KeepAlive(s)
+	}()
+	return f()
+}
+
 // Reset removes all assertions from the Solver and resets its stack.
 func (s *Solver) Reset() {
-	s.ctx.do(func() {
+	s.do(func() {
 		C.Z3_solver_reset(s.ctx.c, s.c)
 	})
 	runtime.KeepAlive(s)
@@ -99,12 +253,12 @@ func (e *ErrSatUnknown) Error() string {
 // returns an *ErrSatUnknown error.
 func (s *Solver) Check() (sat bool, err error) {
 	var res C.Z3_lbool
-	s.ctx.do(func() {
+	s.do(func() {
 		res = C.Z3_solver_check(s.ctx.c, s.c)
 	})
 	if res == C.Z3_L_UNDEF {
 		// Get the reason.
-		s.ctx.do(func() {
+		s.do(func() {
 			cerr := C.Z3_solver_get_reason_unknown(s.ctx.c, s.c)
 			err = &ErrSatUnknown{C.GoString(cerr)}
 		})
@@ -113,11 +267,23 @@ func (s *Solver) Check() (sat bool, err error) {
 	return res == C.Z3_L_TRUE, err
 }
 
+// Statistics returns performance counters for the last Check. In
+// addition to search statistics, these include "memory" and "max
+// memory", the current and peak memory used by Z3 in megabytes.
+func (s *Solver) Statistics() Statistics {
+	var stats Statistics
+	s.do(func() {
+		stats = wrapStatistics(s.ctx, C.Z3_solver_get_statistics(s.ctx.c, s.c))
+	})
+	runtime.KeepAlive(s)
+	return stats
+}
+
 // Model returns the model for the last Check. Model panics if Check
 // has not been called or the last Check did not return true.
 func (s *Solver) Model() *Model {
 	var model *Model
-	s.ctx.do(func() {
+	s.do(func() {
 		model = wrapModel(s.ctx, C.Z3_solver_get_model(s.ctx.c, s.c))
 	})
 	runtime.KeepAlive(s)
@@ -127,9 +293,229 @@ func (s *Solver) Model() *Model {
 // String returns a string representation of s.
 func (s *Solver) String() string {
 	var res string
-	s.ctx.do(func() {
+	s.do(func() {
 		res = C.GoString(C.Z3_solver_to_string(s.ctx.c, s.c))
 	})
 	runtime.KeepAlive(s)
 	return res
 }
+
+// Save writes the parameters and assertions in s to w as an SMT-LIB2
+// script, which can be restored with Context.LoadSolver.
+//
+// The parameters set on s, for example through Config, are written
+// as set-option commands at the start of the script. The saved state
+// also includes the declarations of all constants and functions used
+// by the assertions. It does not include the Push/Pop stack: all
+// current assertions are saved as if they were at the outermost
+// level. Save returns an error without writing anything if a string
+// parameter contains '|', '\', or a line break, since these cannot
+// be represented in the saved script.
+func (s *Solver) Save(w io.Writer) error {
+	var buf bytes.Buffer
+	var err error
+	s.do(func() {
+		names := make([]string, 0, len(s.params))
+		for name := range s.params {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			var val string
+			val, err = formatOption(s.params[name])
+			if err != nil {
+				err = fmt.Errorf("z3: saving solver: parameter %s: %v", name, err)
+				return
+			}
+			fmt.Fprintf(&buf, "(set-option :%s %s)\n", name, val)
+		}
+		// Use a format the SMT-LIB2 parser is guaranteed to
+		// accept, regardless of ctx's print mode.
+		C.Z3_set_ast_print_mode(s.ctx.c, C.Z3_PRINT_SMTLIB2_COMPLIANT)
+		defer C.Z3_set_ast_print_mode(s.ctx.c, C.Z3_ast_print_mode(s.ctx.printMode))
+		buf.WriteString(C.GoString(C.Z3_solver_to_string(s.ctx.c, s.c)))
+	})
+	runtime.KeepAlive(s)
+	if err != nil {
+		return err
+	}
+	_, err = buf.WriteTo(w)
+	return err
+}
+
+// LoadSolver returns a new Solver with the parameters and assertions
+// read from r, which must be an SMT-LIB2 script such as one written
+// by Solver.Save. Parameters are read from set-option commands at the
+// start of the script.
+func (ctx *Context) LoadSolver(r io.Reader) (*Solver, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	cfg, rest, err := parseOptions(data)
+	if err != nil {
+		return nil, fmt.Errorf("z3: loading solver: %v", err)
+	}
+	csrc := C.CString(string(rest))
+	defer C.free(unsafe.Pointer(csrc))
+	s := NewSolver(ctx)
+	err = Try(func() {
+		if len(cfg.m) > 0 {
+			s.setParams(cfg)
+		}
+		ctx.do(func() {
+			C.Z3_solver_from_string(ctx.c, s.c, csrc)
+		})
+	})
+	runtime.KeepAlive(s)
+	if err != nil {
+		return nil, fmt.Errorf("z3: loading solver: %v", err)
+	}
+	return s, nil
+}
+
+// formatOption formats a parameter value for a set-option command.
+// Floats always include a decimal point and strings are written as
+// quoted symbols, so parseOptions can recover the value's type.
+//
+// SMT-LIB2 quoted symbols cannot contain '|' or '\', and
+// parseOptions reads one command per line, so strings containing
+// these or a newline are rejected.
+func formatOption(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case string:
+		if strings.ContainsAny(v, "|\\\n\r") {
+			return "", fmt.Errorf("string value %q cannot be saved", v)
+		}
+		return "|" + v + "|", nil
+	case float64:
+		str := strconv.FormatFloat(v, 'g', -1, 64)
+		if !strings.ContainsAny(str, ".eE") {
+			str += ".0"
+		}
+		return str, nil
+	}
+	return fmt.Sprint(v), nil
+}
+
+// parseOptions parses the set-option commands at the start of an
+// SMT-LIB2 script written by Solver.Save. It returns the options as
+// a Config and the remainder of the script.
+func parseOptions(data []byte) (*Config, []byte, error) {
+	cfg := newConfig(nil)
+	for lineno := 1; ; lineno++ {
+		line := data
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			line = data[:i]
+		}
+		const prefix = "(set-option :"
+		if !bytes.HasPrefix(line, []byte(prefix)) {
+			return cfg, data, nil
+		}
+		data = data[len(line):]
+		if len(data) > 0 {
+			data = data[1:]
+		}
+		cmd := strings.TrimSpace(string(line))
+		fields := strings.SplitN(strings.TrimSuffix(cmd[len(prefix):], ")"), " ", 2)
+		if !strings.HasSuffix(cmd, ")") || len(fields) != 2 {
+			return nil, nil, fmt.Errorf("line %d: malformed set-option command %q", lineno, cmd)
+		}
+		name, val := fields[0], fields[1]
+		switch {
+		case val == "true" || val == "false":
+			cfg.SetBool(name, val == "true")
+		case len(val) >= 2 && val[0] == '|' && val[len(val)-1] == '|':
+			cfg.SetString(name, val[1:len(val)-1])
+		case strings.ContainsAny(val, ".eE"):
+			f, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return nil, nil, fmt.Errorf("line %d: bad value for %s: %v", lineno, name, err)
+			}
+			cfg.SetFloat(name, f)
+		default:
+			u, err := strconv.ParseUint(val, 10, 0)
+			if err != nil {
+				return nil, nil, fmt.Errorf("line %d: bad value for %s: %v", lineno, name, err)
+			}
+			cfg.SetUint(name, uint(u))
+		}
+	}
+}
+
+// CongruenceRoot returns the representative of x's congruence class
+// in the last Check: the solver considers x equal to every other
+// term with the same representative. It is only meaningful after
+// Check returns true or an *ErrSatUnknown error.
+func (s *Solver) CongruenceRoot(x Value) Value {
+	val := wrapValue(s.ctx, func() C.Z3_ast {
+		s.checkOpen()
+		return C.Z3_solver_congruence_root(s.ctx.c, s.c, x.impl().c)
+	})
+	runtime.KeepAlive(s)
+	runtime.KeepAlive(x)
+	return val.lift(KindUnknown)
+}
+
+// CongruenceNext returns the next term in x's congruence class in
+// the last Check. The terms of a class form a cycle, so repeatedly
+// calling CongruenceNext eventually returns x again. See also
+// CongruenceClass.
+func (s *Solver) CongruenceNext(x Value) Value {
+	val := wrapValue(s.ctx, func() C.Z3_ast {
+		s.checkOpen()
+		return C.Z3_solver_congruence_next(s.ctx.c, s.c, x.impl().c)
+	})
+	runtime.KeepAlive(s)
+	runtime.KeepAlive(x)
+	return val.lift(KindUnknown)
+}
+
+// CongruenceClass returns all terms the solver considered equal to x
+// in the last Check, starting with x.
+func (s *Solver) CongruenceClass(x Value) []Value {
+	class := []Value{x}
+	id := x.AsAST().ID()
+	for y := s.CongruenceNext(x); y.AsAST().ID() != id; y = s.CongruenceNext(y) {
+		class = append(class, y)
+	}
+	return class
+}
+
+// ImpliedEqualities determines which of terms are equal in every
+// model of the assertions in s. It returns a class ID for each term,
+// such that two terms have the same ID if s implies they are equal.
+//
+// If the assertions are unsatisfiable, sat is false and classes is
+// nil. If Z3 cannot determine satisfiability, it returns an
+// *ErrSatUnknown error.
+func (s *Solver) ImpliedEqualities(terms ...Value) (classes []int, sat bool, err error) {
+	cterms := valuesToC(terms)
+	cids := make([]C.uint, len(terms))
+	var res C.Z3_lbool
+	s.do(func() {
+		var cp *C.Z3_ast
+		var ip *C.uint
+		if len(cterms) > 0 {
+			cp, ip = &cterms[0], &cids[0]
+		}
+		res = C.Z3_get_implied_equalities(s.ctx.c, s.c, C.uint(len(cterms)), cp, ip)
+	})
+	runtime.KeepAlive(s)
+	runtime.KeepAlive(terms)
+	switch res {
+	case C.Z3_L_FALSE:
+		return nil, false, nil
+	case C.Z3_L_UNDEF:
+		s.do(func() {
+			cerr := C.Z3_solver_get_reason_unknown(s.ctx.c, s.c)
+			err = &ErrSatUnknown{C.GoString(cerr)}
+		})
+		return nil, false, err
+	}
+	classes = make([]int, len(cids))
+	for i, id := range cids {
+		classes[i] = int(id)
+	}
+	return classes, true, nil
+}
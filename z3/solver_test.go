@@ -0,0 +1,297 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSolverSaveLoad(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y := ctx.BVConst("x", 8), ctx.BVConst("y", 8)
+	f := ctx.FuncDecl("f", []Sort{x.Sort()}, x.Sort())
+	s := NewSolver(ctx)
+	s.Assert(x.Mul(y).Eq(ctx.FromInt(15, x.Sort()).(BV)))
+	s.Assert(x.ULT(y))
+	s.Assert(f.Apply(x).(BV).Eq(y))
+	s.Push()
+	s.Assert(x.UGT(ctx.FromInt(1, x.Sort()).(BV)))
+
+	// Low-level printing must not affect the saved format.
+	ctx.SetPrintMode(PrintLowLevel)
+	var buf bytes.Buffer
+	if err := s.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	// Restore into a fresh Context, as if in a new process.
+	ctx2 := NewContext(nil)
+	s2, err := ctx2.LoadSolver(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x2, y2 := ctx2.BVConst("x", 8), ctx2.BVConst("y", 8)
+	s2.Assert(x2.Eq(ctx2.FromInt(3, x2.Sort()).(BV)))
+	if sat, err := s2.Check(); !sat || err != nil {
+		t.Fatalf("Check() = %v, %v, want sat", sat, err)
+	}
+	if got, _, _ := s2.Model().Eval(y2, true).(BV).AsUint64(); got != 5 {
+		t.Errorf("y = %d, want 5", got)
+	}
+	s2.Assert(x2.Eq(ctx2.FromInt(1, x2.Sort()).(BV)))
+	if sat, err := s2.Check(); sat || err != nil {
+		t.Errorf("Check() = %v, %v, want unsat", sat, err)
+	}
+
+	if _, err := ctx2.LoadSolver(strings.NewReader("(assert (= z 1))")); err == nil {
+		t.Errorf("loading undeclared constant succeeded")
+	}
+}
+
+func TestSolverSaveLoadParams(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.IntConst("x")
+	s := NewSolver(ctx)
+	s.Config().SetUint("rlimit", 1).SetBool("model", true)
+	s.Assert(x.Mul(x).Eq(ctx.FromInt(49, ctx.IntSort()).(Int)))
+	var buf bytes.Buffer
+	if err := s.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if want := "(set-option :model true)\n(set-option :rlimit 1)\n"; !strings.HasPrefix(buf.String(), want) {
+		t.Errorf("saved script does not start with %q:\n%s", want, buf.String())
+	}
+
+	s2, err := NewContext(nil).LoadSolver(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The resource limit must survive, so the search gives up.
+	if _, err := s2.Check(); err == nil {
+		t.Errorf("Check() succeeded, want resource limit error")
+	}
+
+	cfg, rest, err := parseOptions([]byte("(set-option :a 1.5)\n(set-option :b |x y|)\n(assert true)"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.m["a"] != 1.5 || cfg.m["b"] != "x y" || string(rest) != "(assert true)" {
+		t.Errorf("parseOptions = %v, %q", cfg.m, rest)
+	}
+	if _, _, err := parseOptions([]byte("(set-option :a 1)\n(set-option :b -1)\n")); err == nil || !strings.HasPrefix(err.Error(), "line 2:") {
+		t.Errorf("parseOptions with bad value: got %v, want line 2 error", err)
+	}
+
+	// String values that cannot be quoted must not corrupt the
+	// script. Set the value directly so Z3 doesn't interpret it.
+	s.params["smt.logic"] = "a|b"
+	buf.Reset()
+	if err := s.Save(&buf); err == nil || !strings.Contains(err.Error(), "smt.logic") {
+		t.Errorf("Save with '|' in value: got %v, want error", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("failed Save wrote %q", buf.String())
+	}
+}
+
+func TestSolverOnClause(t *testing.T) {
+	ctx := NewContext(nil)
+	s := NewSolver(ctx)
+	var clauses [][]Bool
+	s.OnClause(func(hint AST, deps []int, clause []Bool) {
+		clauses = append(clauses, clause)
+	})
+
+	// Three pigeons do not fit in two holes.
+	var p [3][2]Bool
+	for i := range p {
+		for j := range p[i] {
+			p[i][j] = ctx.BoolConst(fmt.Sprintf("p%d%d", i, j))
+		}
+		s.Assert(p[i][0].Or(p[i][1]))
+	}
+	for j := 0; j < 2; j++ {
+		for i := range p {
+			for k := i + 1; k < len(p); k++ {
+				s.Assert(p[i][j].And(p[k][j]).Not())
+			}
+		}
+	}
+	if sat, err := s.Check(); err != nil || sat {
+		t.Fatalf("want unsat, got %v, %v", sat, err)
+	}
+	if len(clauses) == 0 {
+		t.Fatalf("no clauses observed")
+	}
+	empty := false
+	for _, c := range clauses {
+		if len(c) == 0 {
+			empty = true
+		}
+	}
+	if !empty {
+		t.Errorf("did not observe the empty clause")
+	}
+}
+
+func TestSolverCongruence(t *testing.T) {
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	x, y, z, w := ctx.IntConst("x"), ctx.IntConst("y"), ctx.IntConst("z"), ctx.IntConst("w")
+	f := ctx.FuncDecl("f", []Sort{ints}, ints)
+
+	s := NewSolver(ctx)
+	s.Assert(x.Eq(y))
+	s.Assert(y.Eq(f.Apply(z).(Int)))
+	s.Assert(x.Eq(w).Not())
+	if sat, err := s.Check(); err != nil || !sat {
+		t.Fatalf("want sat, got %v, %v", sat, err)
+	}
+
+	root := s.CongruenceRoot(x).AsAST()
+	for _, v := range []Value{y, f.Apply(z)} {
+		if !s.CongruenceRoot(v).AsAST().Equal(root) {
+			t.Errorf("%v not congruent to %v", v, x)
+		}
+	}
+	if s.CongruenceRoot(w).AsAST().Equal(root) {
+		t.Errorf("%v congruent to %v", w, x)
+	}
+	if class := s.CongruenceClass(x); len(class) < 3 {
+		t.Errorf("congruence class of %v is %v, want at least 3 terms", x, class)
+	}
+}
+
+func TestSolverImpliedEqualities(t *testing.T) {
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	x, y, z := ctx.IntConst("x"), ctx.IntConst("y"), ctx.IntConst("z")
+	one := ctx.FromInt(1, ints).(Int)
+
+	s := NewSolver(ctx)
+	s.Assert(x.Eq(y.Add(one)))
+	s.Assert(y.LE(z).And(z.LE(y)))
+	classes, sat, err := s.ImpliedEqualities(x, y, z, z.Add(one))
+	if err != nil || !sat {
+		t.Fatalf("want sat, got %v, %v", sat, err)
+	}
+	// x = z+1 and y = z, but x != y.
+	if classes[0] != classes[3] || classes[1] != classes[2] || classes[0] == classes[1] {
+		t.Errorf("classes = %v, want [a b b a]", classes)
+	}
+
+	s.Assert(x.Eq(y))
+	if _, sat, err := s.ImpliedEqualities(x, y); err != nil || sat {
+		t.Errorf("want unsat, got %v, %v", sat, err)
+	}
+}
+
+func TestSolverAssertAll(t *testing.T) {
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	const n = 1000
+	var xs []Int
+	var cs []Bool
+	for i := 0; i < n; i++ {
+		xs = append(xs, ctx.IntConst(fmt.Sprint("x", i)))
+		if i > 0 {
+			cs = append(cs, xs[i-1].LT(xs[i]))
+		}
+	}
+	s := NewSolver(ctx)
+	s.AssertAll(cs)
+	s.AssertAll(nil)
+	s.Assert(xs[0].Eq(ctx.FromInt(0, ints).(Int)))
+	s.Push()
+	s.Assert(xs[n-1].LT(ctx.FromInt(n-1, ints).(Int)))
+	if sat, err := s.Check(); sat || err != nil {
+		t.Errorf("want unsat, got %v, %v", sat, err)
+	}
+	s.Pop()
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Errorf("want sat, got %v, %v", sat, err)
+	}
+}
+
+func TestSolverWithScope(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.IntConst("x")
+	zero := ctx.FromInt(0, ctx.IntSort()).(Int)
+	s := NewSolver(ctx)
+	s.Assert(x.GT(zero))
+
+	// A contradictory hypothesis is discarded afterward.
+	err := s.WithScope(func() error {
+		s.Assert(x.LT(zero))
+		if sat, err := s.Check(); sat || err != nil {
+			t.Errorf("want unsat in scope, got %v, %v", sat, err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("want sat after scope, got %v, %v", sat, err)
+	}
+
+	// f's error is returned and extra Pushes are undone.
+	myErr := errors.New("my error")
+	err = s.WithScope(func() error {
+		s.Push()
+		s.Push()
+		return myErr
+	})
+	if err != myErr {
+		t.Errorf("want %v, got %v", myErr, err)
+	}
+	if n := s.NumScopes(); n != 0 {
+		t.Errorf("%d scopes after WithScope, want 0", n)
+	}
+
+	// The scope is popped even if f panics.
+	s.Push()
+	func() {
+		defer func() { recover() }()
+		s.WithScope(func() error {
+			s.Assert(x.LT(zero))
+			panic("boom")
+		})
+	}()
+	if n := s.NumScopes(); n != 1 {
+		t.Errorf("%d scopes after panic, want 1", n)
+	}
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("want sat after panic, got %v, %v", sat, err)
+	}
+}
+
+func TestSolverClosed(t *testing.T) {
+	ctx := NewContext(nil)
+	x := ctx.BoolConst("x")
+	s := NewSolver(ctx)
+	s.Assert(x)
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("want sat, got %v, %v", sat, err)
+	}
+	m := s.Model()
+	s.Close()
+	s.Close() // Closing twice is harmless.
+	wantPanic(t, "use of closed Solver", func() { s.Assert(x) })
+	wantPanic(t, "use of closed Solver", func() { s.Check() })
+	wantPanic(t, "use of closed Solver", func() { _ = s.String() })
+
+	// Models outlive their Solver.
+	if val, _ := m.Eval(x, true).(Bool).AsBool(); !val {
+		t.Errorf("want x true")
+	}
+	m.Close()
+	wantPanic(t, "use of closed Model", func() { m.Eval(x, true) })
+	wantPanic(t, "use of closed Model", func() { _ = m.String() })
+}
@@ -0,0 +1,48 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+*/
+import "C"
+
+// Statistics are performance counters reported by Z3, such as the
+// number of conflicts or the time spent in a procedure. They map
+// from counter name to value. The available counters depend on the
+// Z3 component and version.
+type Statistics map[string]float64
+
+// wrapStatistics converts a C Z3_stats to Statistics and releases
+// it. This must be called with the ctx.lock held.
+func wrapStatistics(ctx *Context, c C.Z3_stats) Statistics {
+	C.Z3_stats_inc_ref(ctx.c, c)
+	defer C.Z3_stats_dec_ref(ctx.c, c)
+	stats := make(Statistics)
+	n := C.Z3_stats_size(ctx.c, c)
+	for i := C.uint(0); i < n; i++ {
+		key := C.GoString(C.Z3_stats_get_key(ctx.c, c, i))
+		if z3ToBool(C.Z3_stats_is_uint(ctx.c, c, i)) {
+			stats[key] = float64(C.Z3_stats_get_uint_value(ctx.c, c, i))
+		} else {
+			stats[key] = float64(C.Z3_stats_get_double_value(ctx.c, c, i))
+		}
+	}
+	return stats
+}
+
+// EstimatedAllocSize returns an estimate of the number of bytes of
+// native memory currently allocated by Z3.
+//
+// Z3 tracks memory for the whole process rather than per Context, so
+// this includes the memory of every Context. Services that enforce a
+// memory budget can poll EstimatedAllocSize and recycle Contexts (see
+// Context.Close) when it grows too large, or set the global
+// "memory_max_size" parameter (see SetGlobalParam) to make Z3 fail
+// operations that exceed a limit.
+func EstimatedAllocSize() uint64 {
+	return uint64(C.Z3_get_estimated_alloc_size())
+}
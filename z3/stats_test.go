@@ -0,0 +1,27 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestMemoryStatistics(t *testing.T) {
+	ctx := NewContext(nil)
+	s := NewSolver(ctx)
+	x := ctx.IntConst("x")
+	s.Assert(x.Mul(x).Eq(ctx.FromInt(49, ctx.IntSort()).(Int)))
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("Check() = %v, %v, want sat", sat, err)
+	}
+
+	if size := EstimatedAllocSize(); size == 0 {
+		t.Errorf("EstimatedAllocSize() = 0 with a live Context")
+	}
+	stats := s.Statistics()
+	for _, key := range []string{"memory", "max memory"} {
+		if v, ok := stats[key]; !ok || v <= 0 {
+			t.Errorf("statistic %q = %v, %v, want positive", key, v, ok)
+		}
+	}
+}
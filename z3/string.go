@@ -0,0 +1,150 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"unicode/utf8"
+	"unsafe"
+)
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+#include <stdlib.h>
+*/
+import "C"
+
+// String is a symbolic value representing a string.
+//
+// In Z3, strings are sequences of characters, so String supports all
+// of the methods of Seq, as well as conversions specific to strings.
+// String values can be matched against regular expressions (see Re).
+//
+// String implements Value.
+type String value
+
+// StringSort returns the string sort.
+func (ctx *Context) StringSort() Sort {
+	var sort Sort
+	ctx.do(func() {
+		sort = wrapSort(ctx, C.Z3_mk_string_sort(ctx.c), KindSeq)
+	})
+	return sort
+}
+
+// StringConst returns a string constant named "name".
+func (ctx *Context) StringConst(name string) String {
+	return ctx.Const(name, ctx.StringSort()).(String)
+}
+
+// FromString returns a string literal whose value is val.
+//
+// val is interpreted literally: backslashes and non-ASCII characters
+// in val are not treated as Z3 escape sequences.
+//
+// Z3 strings are sequences of Unicode characters, so val should be
+// valid UTF-8. Each byte of an invalid UTF-8 sequence in val becomes
+// the replacement character U+FFFD, as when ranging over val.
+// Characters above U+2FFFF, the largest character Z3 supports, also
+// become U+FFFD.
+func (ctx *Context) FromString(val string) String {
+	cval := C.CString(escapeString(val))
+	defer C.free(unsafe.Pointer(cval))
+	return String(wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_string(ctx.c, cval)
+	}))
+}
+
+// AsString returns the value of lit as a Go string. If lit is not a
+// literal, it returns "", false.
+func (lit String) AsString() (val string, isLiteral bool) {
+	// Z3_get_string's escaping is ambiguous for strings that
+	// contain backslashes, so retrieve the code points directly.
+	var runes []rune
+	lit.ctx.do(func() {
+		if !z3ToBool(C.Z3_is_string(lit.ctx.c, lit.c)) {
+			return
+		}
+		isLiteral = true
+		n := C.Z3_get_string_length(lit.ctx.c, lit.c)
+		if n == 0 {
+			return
+		}
+		cs := make([]C.unsigned, n)
+		C.Z3_get_string_contents(lit.ctx.c, lit.c, n, &cs[0])
+		runes = make([]rune, n)
+		for i, c := range cs {
+			runes[i] = rune(c)
+		}
+	})
+	runtime.KeepAlive(lit)
+	return string(runes), isLiteral
+}
+
+// escapeString encodes s using Z3's string literal escapes so that
+// Z3 interprets every character of s literally. Invalid UTF-8 in s
+// and characters above maxChar are encoded as U+FFFD.
+func escapeString(s string) string {
+	var buf strings.Builder
+	for _, r := range s {
+		if r > maxChar {
+			r = utf8.RuneError
+		}
+		if r >= 0x20 && r < 0x7f && r != '\\' {
+			buf.WriteRune(r)
+		} else {
+			fmt.Fprintf(&buf, "\\u{%x}", r)
+		}
+	}
+	return buf.String()
+}
+
+// Compare returns an Int that is -1 if l < r, 0 if l == r, and +1 if
+// l > r, where strings are ordered lexicographically.
+func (l String) Compare(r String) Int {
+	ints := l.ctx.IntSort()
+	lt, eq := l.LT(r), l.Eq(r)
+	gt := eq.IfThenElse(l.ctx.FromInt(0, ints), l.ctx.FromInt(1, ints))
+	return lt.IfThenElse(l.ctx.FromInt(-1, ints), gt).(Int)
+}
+
+//go:generate go run genwrap.go -t String $GOFILE seqstring.go
+
+// CharAt returns the character at index i of l.
+//
+// If i is out of bounds, the result is unspecified.
+//
+//wrap:expr CharAt:Char l i:Int : Z3_mk_seq_nth l i
+
+// LT returns l < r, where strings are ordered lexicographically.
+//
+//wrap:expr LT:Bool Z3_mk_str_lt l r
+
+// LE returns l <= r, where strings are ordered lexicographically.
+//
+//wrap:expr LE:Bool Z3_mk_str_le l r
+
+// GT returns l > r, where strings are ordered lexicographically.
+//
+//wrap:expr GT:Bool l r : Z3_mk_str_lt r l
+
+// GE returns l >= r, where strings are ordered lexicographically.
+//
+//wrap:expr GE:Bool l r : Z3_mk_str_le r l
+
+// ToInt converts l to an integer by interpreting it as a decimal
+// number.
+//
+// If l is not a sequence of decimal digits, the result is -1.
+//
+//wrap:expr ToInt:Int Z3_mk_str_to_int l
+
+// ToCode returns the code point of l if l is a single character
+// string. Otherwise, the result is -1.
+//
+//wrap:expr ToCode:Int Z3_mk_string_to_code l
@@ -0,0 +1,150 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestStringIntConversion(t *testing.T) {
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	for _, test := range []struct {
+		str string
+		val int64
+	}{
+		{"0", 0},
+		{"42", 42},
+		{"007", 7},
+		{"-1", -1},
+		{"abc", -1},
+		{"", -1},
+	} {
+		x := ctx.FromString(test.str).ToInt()
+		if !simplifyBool(t, ctx, x.Eq(ctx.FromInt(test.val, ints).(Int))) {
+			t.Errorf("%q.ToInt() = %s, want %d", test.str, ctx.Simplify(x, nil), test.val)
+		}
+	}
+
+	x := ctx.FromInt(1234, ints).(Int).ToString()
+	if !simplifyBool(t, ctx, x.Eq(ctx.FromString("1234"))) {
+		t.Errorf("1234.ToString() = %s, want \"1234\"", ctx.Simplify(x, nil))
+	}
+}
+
+func TestStringSearch(t *testing.T) {
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	i := func(x int64) Int { return ctx.FromInt(x, ints).(Int) }
+	s := ctx.FromString("hello, world")
+
+	for _, test := range []struct {
+		got  Int
+		want int64
+	}{
+		{s.IndexOf(ctx.FromString("o"), i(0)), 4},
+		{s.IndexOf(ctx.FromString("o"), i(5)), 8},
+		{s.IndexOf(ctx.FromString("x"), i(0)), -1},
+		{s.LastIndexOf(ctx.FromString("o")), 8},
+		{s.LastIndexOf(ctx.FromString("x")), -1},
+	} {
+		if !simplifyBool(t, ctx, test.got.Eq(i(test.want))) {
+			t.Errorf("%s = %s, want %d", test.got, ctx.Simplify(test.got, nil), test.want)
+		}
+	}
+
+	for _, test := range []struct {
+		got  String
+		want string
+	}{
+		{s.Extract(i(7), i(5)), "world"},
+		{s.Extract(i(7), i(100)), "world"},
+		{s.At(i(1)), "e"},
+		{s.Replace(ctx.FromString("o"), ctx.FromString("0")), "hell0, world"},
+	} {
+		if !simplifyBool(t, ctx, test.got.Eq(ctx.FromString(test.want))) {
+			t.Errorf("%s = %s, want %q", test.got, ctx.Simplify(test.got, nil), test.want)
+		}
+	}
+
+	for _, test := range []struct {
+		got  Bool
+		want bool
+	}{
+		{s.Contains(ctx.FromString("o, w")), true},
+		{s.Contains(ctx.FromString("ow")), false},
+		{s.HasPrefix(ctx.FromString("hell")), true},
+		{s.HasPrefix(ctx.FromString("world")), false},
+		{s.HasSuffix(ctx.FromString("world")), true},
+	} {
+		if simplifyBool(t, ctx, test.got) != test.want {
+			t.Errorf("%s = %v, want %v", test.got, !test.want, test.want)
+		}
+	}
+}
+
+func TestStringReplaceAll(t *testing.T) {
+	ctx := NewContext(nil)
+	s := ctx.FromString("hello, world")
+	got := s.ReplaceAll(ctx.FromString("o"), ctx.FromString("0"))
+	if !simplifyBool(t, ctx, got.Eq(ctx.FromString("hell0, w0rld"))) {
+		t.Errorf("%s = %s, want %q", got, ctx.Simplify(got, nil), "hell0, w0rld")
+	}
+}
+
+func TestStringCompare(t *testing.T) {
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	for _, test := range []struct {
+		l, r string
+		want int64
+	}{
+		{"abc", "abd", -1},
+		{"abc", "abc", 0},
+		{"abc", "ab", 1},
+		{"", "a", -1},
+		{"b", "abc", 1},
+	} {
+		l, r := ctx.FromString(test.l), ctx.FromString(test.r)
+		got := l.Compare(r)
+		if !simplifyBool(t, ctx, got.Eq(ctx.FromInt(test.want, ints).(Int))) {
+			t.Errorf("Compare(%q, %q) = %s, want %d", test.l, test.r, ctx.Simplify(got, nil), test.want)
+		}
+		if lt := simplifyBool(t, ctx, l.LT(r)); lt != (test.want < 0) {
+			t.Errorf("%q < %q = %v", test.l, test.r, lt)
+		}
+		if ge := simplifyBool(t, ctx, l.GE(r)); ge != (test.want >= 0) {
+			t.Errorf("%q >= %q = %v", test.l, test.r, ge)
+		}
+	}
+}
+
+func TestStringAsString(t *testing.T) {
+	ctx := NewContext(nil)
+	for _, str := range []string{
+		"", "hello", `back\slash`, `\u{41}`, "tab\tnewline\n", "nul\x00", "héllo, 世界",
+	} {
+		lit := ctx.FromString(str)
+		got, isLit := lit.AsString()
+		if !isLit || got != str {
+			t.Errorf("FromString(%q).AsString() = %q, %v; want %q, true", str, got, isLit, str)
+		}
+		got, isLit = ctx.Simplify(lit.Concat(ctx.FromString("!")), nil).(String).AsString()
+		if !isLit || got != str+"!" {
+			t.Errorf("(%q + \"!\").AsString() = %q, %v; want %q, true", str, got, isLit, str+"!")
+		}
+	}
+
+	// Invalid UTF-8 bytes become U+FFFD.
+	if got, _ := ctx.FromString("a\xffb\xc3").AsString(); got != "a\ufffdb\ufffd" {
+		t.Errorf("FromString with invalid UTF-8: got %q, want %q", got, "a\ufffdb\ufffd")
+	}
+	// So do characters Z3 doesn't support.
+	if got, _ := ctx.FromString("a\U0010FFFFb").AsString(); got != "a\ufffdb" {
+		t.Errorf("FromString with U+10FFFF: got %q, want %q", got, "a\ufffdb")
+	}
+
+	if _, isLit := ctx.StringConst("x").AsString(); isLit {
+		t.Errorf("constant x is a literal")
+	}
+}
@@ -0,0 +1,300 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// StructVars binds the fields of a Go struct to Z3 constants.
+//
+// StructVars lets a program declare the unknowns of a problem as an
+// ordinary Go struct, constrain them symbolically, and then read a
+// solution back into the struct. For example:
+//
+//	type Packet struct {
+//		Len   uint16            // bit-vector of width 16
+//		Kind  uint8 `z3:"bv:4"` // bit-vector of width 4
+//		Valid bool
+//		Count int64 `z3:"int"`  // mathematical integer
+//		Debug string `z3:"-"`   // not bound
+//	}
+//
+//	var p Packet
+//	vars, err := ctx.StructConsts("p", &p)
+//	...
+//	solver.Assert(vars.Value("Len").(z3.BV).UGT(...))
+//	if sat, _ := solver.Check(); sat {
+//		err = vars.Fill(solver.Model()) // sets p's fields
+//	}
+//
+// Each exported field is bound to a constant named "prefix.Field",
+// or just "Field" if the prefix is empty. The sort of the constant is
+// given by the field's "z3" tag, which has the form
+//
+//	kind[,name=constname]
+//
+// where kind is "bool", "int", "real", or "bv:N" for a bit-vector of
+// width N, and name, if present, overrides the field name in the
+// constant's name. A tag of "-" skips the field.
+//
+// Untagged fields of type bool are bound to Bools, fields of sized
+// and unsized integer types are bound to bit-vectors of the same
+// width, *big.Int fields are bound to Ints, and *big.Rat fields are
+// bound to Reals. Other untagged field types are an error.
+type StructVars struct {
+	ptr    reflect.Value
+	fields []structVar
+	byName map[string]*structVar
+}
+
+type structVar struct {
+	field reflect.StructField
+	kind  Kind
+	bits  int
+	val   Value
+}
+
+var (
+	bigIntType = reflect.TypeOf((*big.Int)(nil))
+	bigRatType = reflect.TypeOf((*big.Rat)(nil))
+)
+
+// StructConsts creates a constant for each field of the struct
+// pointed to by ptr, as described by StructVars. It returns an error
+// if ptr is not a pointer to a struct, or if any field's tag is
+// malformed or incompatible with the field's type.
+func (ctx *Context) StructConsts(prefix string, ptr interface{}) (*StructVars, error) {
+	pv := reflect.ValueOf(ptr)
+	if pv.Kind() != reflect.Ptr || pv.IsNil() || pv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("z3: StructConsts of %T, want non-nil pointer to struct", ptr)
+	}
+	typ := pv.Elem().Type()
+	sv := &StructVars{ptr: pv, byName: make(map[string]*structVar)}
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		tag, tagged := f.Tag.Lookup("z3")
+		if f.PkgPath != "" || tag == "-" {
+			// Unexported or skipped.
+			continue
+		}
+		v := structVar{field: f}
+		name := f.Name
+		if tagged {
+			parts := strings.Split(tag, ",")
+			if err := v.parseKind(parts[0]); err != nil {
+				return nil, fmt.Errorf("z3: field %s.%s: %v", typ, f.Name, err)
+			}
+			for _, opt := range parts[1:] {
+				if !strings.HasPrefix(opt, "name=") || len(opt) == len("name=") {
+					return nil, fmt.Errorf("z3: field %s.%s: bad tag option %q", typ, f.Name, opt)
+				}
+				name = opt[len("name="):]
+			}
+		} else if !v.inferKind() {
+			return nil, fmt.Errorf("z3: field %s.%s: type %s requires a z3 tag", typ, f.Name, f.Type)
+		}
+		if !v.compatible() {
+			return nil, fmt.Errorf("z3: field %s.%s: type %s cannot hold %s", typ, f.Name, f.Type, v.sortName())
+		}
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		switch v.kind {
+		case KindBool:
+			v.val = ctx.BoolConst(name)
+		case KindInt:
+			v.val = ctx.IntConst(name)
+		case KindReal:
+			v.val = ctx.RealConst(name)
+		case KindBV:
+			v.val = ctx.BVConst(name, v.bits)
+		}
+		sv.fields = append(sv.fields, v)
+	}
+	for i := range sv.fields {
+		sv.byName[sv.fields[i].field.Name] = &sv.fields[i]
+	}
+	return sv, nil
+}
+
+func (v *structVar) parseKind(kind string) error {
+	switch {
+	case kind == "bool":
+		v.kind = KindBool
+	case kind == "int":
+		v.kind = KindInt
+	case kind == "real":
+		v.kind = KindReal
+	case strings.HasPrefix(kind, "bv:"):
+		bits, err := strconv.Atoi(kind[len("bv:"):])
+		if err != nil || bits <= 0 {
+			return fmt.Errorf("bad bit-vector width in %q", kind)
+		}
+		v.kind, v.bits = KindBV, bits
+	default:
+		return fmt.Errorf("unknown sort %q", kind)
+	}
+	return nil
+}
+
+func (v *structVar) inferKind() bool {
+	t := v.field.Type
+	switch t.Kind() {
+	case reflect.Bool:
+		v.kind = KindBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.kind, v.bits = KindBV, t.Bits()
+	default:
+		switch t {
+		case bigIntType:
+			v.kind = KindInt
+		case bigRatType:
+			v.kind = KindReal
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// compatible returns whether v's field type can represent v's sort.
+// Ints are allowed in any integer field, and Fill checks the range of
+// each value.
+func (v *structVar) compatible() bool {
+	t := v.field.Type
+	switch v.kind {
+	case KindBool:
+		return t.Kind() == reflect.Bool
+	case KindInt:
+		return t == bigIntType || isInteger(t)
+	case KindReal:
+		return t == bigRatType || t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64
+	case KindBV:
+		return t == bigIntType || isInteger(t) && v.bits <= t.Bits()
+	}
+	return false
+}
+
+func isInteger(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+func isSigned(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func (v *structVar) sortName() string {
+	if v.kind == KindBV {
+		return fmt.Sprintf("bv:%d", v.bits)
+	}
+	return strings.ToLower(strings.TrimPrefix(v.kind.String(), "Kind"))
+}
+
+// Value returns the constant bound to the named field. It panics if
+// the field is not bound.
+func (sv *StructVars) Value(field string) Value {
+	v, ok := sv.byName[field]
+	if !ok {
+		panic("z3: no bound field " + field)
+	}
+	return v.val
+}
+
+// Fields returns the names of the bound fields, in declaration order.
+func (sv *StructVars) Fields() []string {
+	names := make([]string, len(sv.fields))
+	for i, v := range sv.fields {
+		names[i] = v.field.Name
+	}
+	return names
+}
+
+// Fill sets each bound field of the struct to the value of its
+// constant in m, using model completion for constants that m does not
+// constrain.
+//
+// Bit-vectors are interpreted as signed if the field has a signed
+// integer type and as unsigned otherwise. Fill returns an error if a
+// value does not fit in its field, such as an Int too large for an
+// int64 field, but still sets the fields it can.
+func (sv *StructVars) Fill(m *Model) error {
+	var firstErr error
+	s := sv.ptr.Elem()
+	for _, v := range sv.fields {
+		if err := v.fill(s.FieldByIndex(v.field.Index), m.Eval(v.val, true)); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("z3: field %s: %v", v.field.Name, err)
+		}
+	}
+	return firstErr
+}
+
+func (v *structVar) fill(f reflect.Value, val Value) error {
+	t := f.Type()
+	if v.kind == KindBool {
+		b, ok := val.(Bool).AsBool()
+		if !ok {
+			return fmt.Errorf("%s is not a literal", val)
+		}
+		f.SetBool(b)
+		return nil
+	}
+	if v.kind == KindReal {
+		rat, ok := val.(Real).AsBigRat()
+		if !ok {
+			return fmt.Errorf("%s is not a rational literal", val)
+		}
+		if t == bigRatType {
+			f.Set(reflect.ValueOf(rat))
+		} else {
+			x, _ := rat.Float64()
+			f.SetFloat(x)
+		}
+		return nil
+	}
+
+	var x *big.Int
+	var ok bool
+	switch {
+	case v.kind == KindInt:
+		x, ok = val.(Int).AsBigInt()
+	case isSigned(t):
+		x, ok = val.(BV).AsBigSigned()
+	default:
+		x, ok = val.(BV).AsBigUnsigned()
+	}
+	if !ok {
+		return fmt.Errorf("%s is not a literal", val)
+	}
+	switch {
+	case t == bigIntType:
+		f.Set(reflect.ValueOf(x))
+	case isSigned(t):
+		if !x.IsInt64() || f.OverflowInt(x.Int64()) {
+			return fmt.Errorf("%s overflows %s", x, t)
+		}
+		f.SetInt(x.Int64())
+	default:
+		if x.Sign() < 0 || !x.IsUint64() || f.OverflowUint(x.Uint64()) {
+			return fmt.Errorf("%s overflows %s", x, t)
+		}
+		f.SetUint(x.Uint64())
+	}
+	return nil
+}
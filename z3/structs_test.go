@@ -0,0 +1,114 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testPacket struct {
+	Len   uint16
+	Kind  uint8 `z3:"bv:4"`
+	Delta int8
+	Valid bool
+	Count int64    `z3:"int,name=n"`
+	Big   *big.Int `z3:"int"`
+	Ratio *big.Rat
+	Scale float64 `z3:"real"`
+	Debug string  `z3:"-"`
+	priv  int
+}
+
+func TestStructConsts(t *testing.T) {
+	ctx := NewContext(nil)
+	var p testPacket
+	vars, err := ctx.StructConsts("p", &p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"Len", "Kind", "Delta", "Valid", "Count", "Big", "Ratio", "Scale"}
+	if got := vars.Fields(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Fields() = %v, want %v", got, want)
+	}
+	if got := vars.Value("Count").String(); got != "p.n" {
+		t.Errorf("Count is bound to %s, want p.n", got)
+	}
+	if got := vars.Value("Kind").Sort().BVSize(); got != 4 {
+		t.Errorf("Kind has width %d, want 4", got)
+	}
+
+	bv := func(x int64, bits int) BV { return ctx.FromInt(x, ctx.BVSort(bits)).(BV) }
+	s := NewSolver(ctx)
+	s.Assert(vars.Value("Len").(BV).Eq(bv(1500, 16)))
+	s.Assert(vars.Value("Kind").(BV).Eq(bv(15, 4)))
+	s.Assert(vars.Value("Delta").(BV).Eq(bv(-3, 8)))
+	s.Assert(vars.Value("Valid").(Bool))
+	s.Assert(vars.Value("Count").(Int).Eq(ctx.FromInt(-42, ctx.IntSort()).(Int)))
+	big40, _ := new(big.Int).SetString("1"+strings.Repeat("0", 40), 10)
+	s.Assert(vars.Value("Big").(Int).Eq(ctx.FromBigInt(big40, ctx.IntSort()).(Int)))
+	s.Assert(vars.Value("Ratio").(Real).Eq(ctx.FromBigRat(big.NewRat(1, 3))))
+	s.Assert(vars.Value("Scale").(Real).Eq(ctx.FromBigRat(big.NewRat(5, 2))))
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("want sat, got %v, %v", sat, err)
+	}
+	p.Debug = "keep"
+	if err := vars.Fill(s.Model()); err != nil {
+		t.Fatal(err)
+	}
+	if p.Len != 1500 || p.Kind != 15 || p.Delta != -3 || !p.Valid || p.Count != -42 ||
+		p.Big.Cmp(big40) != 0 || p.Ratio.Cmp(big.NewRat(1, 3)) != 0 || p.Scale != 2.5 || p.Debug != "keep" {
+		t.Errorf("Fill set %+v", p)
+	}
+
+	// Values that don't fit are reported.
+	var small struct {
+		N int8 `z3:"int"`
+	}
+	vars, err = ctx.StructConsts("", &small)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s = NewSolver(ctx)
+	s.Assert(vars.Value("N").(Int).Eq(ctx.FromInt(300, ctx.IntSort()).(Int)))
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("want sat, got %v, %v", sat, err)
+	}
+	if err := vars.Fill(s.Model()); err == nil || !strings.Contains(err.Error(), "overflows") {
+		t.Errorf("want overflow error, got %v", err)
+	}
+}
+
+func TestStructConstsErrors(t *testing.T) {
+	ctx := NewContext(nil)
+	for _, test := range []struct {
+		ptr  interface{}
+		want string
+	}{
+		{testPacket{}, "want non-nil pointer to struct"},
+		{&struct{ S string }{}, "requires a z3 tag"},
+		{&struct {
+			X uint8 `z3:"bv:16"`
+		}{}, "cannot hold bv:16"},
+		{&struct {
+			X int `z3:"bv:x"`
+		}{}, "bad bit-vector width"},
+		{&struct {
+			X bool `z3:"float"`
+		}{}, "unknown sort"},
+		{&struct {
+			X bool `z3:"bool,size=2"`
+		}{}, "bad tag option"},
+		{&struct {
+			X int `z3:"real"`
+		}{}, "cannot hold real"},
+	} {
+		if _, err := ctx.StructConsts("", test.ptr); err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("StructConsts(%T): want error containing %q, got %v", test.ptr, test.want, err)
+		}
+	}
+}
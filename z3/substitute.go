@@ -0,0 +1,95 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "runtime"
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+*/
+import "C"
+
+// Substitute returns x with every occurrence of from[i] replaced by
+// to[i]. from and to must have the same length, and from[i] and
+// to[i] must have the same sort.
+//
+// The substitution is simultaneous, so the replacements themselves
+// are not substituted.
+func (ctx *Context) Substitute(x Value, from, to []Value) Value {
+	if len(from) != len(to) {
+		panic("from and to must have the same length")
+	}
+	cfrom, cto := valuesToC(from), valuesToC(to)
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_substitute(ctx.c, x.impl().c, C.uint(len(cfrom)), cptr(cfrom), cptr(cto))
+	})
+	runtime.KeepAlive(x)
+	runtime.KeepAlive(from)
+	runtime.KeepAlive(to)
+	return val.lift(KindUnknown)
+}
+
+// SubstituteVars returns x with every free bound variable with de
+// Bruijn index i replaced by to[i]. See Context.BoundVar.
+func (ctx *Context) SubstituteVars(x Value, to []Value) Value {
+	cto := valuesToC(to)
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_substitute_vars(ctx.c, x.impl().c, C.uint(len(cto)), cptr(cto))
+	})
+	runtime.KeepAlive(x)
+	runtime.KeepAlive(to)
+	return val.lift(KindUnknown)
+}
+
+// SubstituteFuns returns x with every application of from[i]
+// replaced by to[i], where the arguments of the application are
+// substituted for the bound variables of to[i]. That is, to[i] is
+// the body of the replacement function, with bound variable 0 (see
+// Context.BoundVar) referring to its first argument, and so on. The
+// range of from[i] must be the sort of to[i].
+func (ctx *Context) SubstituteFuns(x Value, from []FuncDecl, to []Value) Value {
+	if len(from) != len(to) {
+		panic("from and to must have the same length")
+	}
+	cfrom := make([]C.Z3_func_decl, len(from))
+	for i, f := range from {
+		cfrom[i] = f.c
+	}
+	cto := valuesToC(to)
+	val := wrapValue(ctx, func() C.Z3_ast {
+		var pfrom *C.Z3_func_decl
+		if len(cfrom) > 0 {
+			pfrom = &cfrom[0]
+		}
+		return C.Z3_substitute_funs(ctx.c, x.impl().c, C.uint(len(cfrom)), pfrom, cptr(cto))
+	})
+	runtime.KeepAlive(x)
+	runtime.KeepAlive(from)
+	runtime.KeepAlive(to)
+	return val.lift(KindUnknown)
+}
+
+// BoundVar returns the bound variable with de Bruijn index index.
+//
+// Bound variables are placeholders that refer to an enclosing
+// quantifier or lambda, or to a value supplied by SubstituteVars or
+// SubstituteFuns. Index 0 refers to the innermost binding.
+func (ctx *Context) BoundVar(index int, sort Sort) Value {
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_bound(ctx.c, C.uint(index), sort.c)
+	})
+	runtime.KeepAlive(sort)
+	return val.lift(sort.Kind())
+}
+
+// valuesToC returns the C ASTs of vals.
+func valuesToC(vals []Value) []C.Z3_ast {
+	cargs := make([]C.Z3_ast, len(vals))
+	for i, v := range vals {
+		cargs[i] = v.impl().c
+	}
+	return cargs
+}
@@ -0,0 +1,48 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestSubstitute(t *testing.T) {
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	x, y, z := ctx.IntConst("x"), ctx.IntConst("y"), ctx.IntConst("z")
+	one := ctx.FromInt(1, ints).(Int)
+
+	// Substitution is simultaneous.
+	e := x.Add(y)
+	got := ctx.Substitute(e, []Value{x, y}, []Value{y, z.Add(one)})
+	want := y.Add(z.Add(one))
+	if !got.AsAST().Equal(want.AsAST()) {
+		t.Errorf("Substitute(%v) = %v, want %v", e, got, want)
+	}
+
+	v0, v1 := ctx.BoundVar(0, ints).(Int), ctx.BoundVar(1, ints).(Int)
+	tmpl := v0.Mul(v1).Sub(v0)
+	got = ctx.SubstituteVars(tmpl, []Value{x, y})
+	want = x.Mul(y).Sub(x)
+	if !got.AsAST().Equal(want.AsAST()) {
+		t.Errorf("SubstituteVars(%v) = %v, want %v", tmpl, got, want)
+	}
+
+	wantPanic(t, "same length", func() { ctx.Substitute(e, []Value{x}, nil) })
+}
+
+func TestSubstituteFuns(t *testing.T) {
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	x := ctx.IntConst("x")
+	f := ctx.FuncDecl("f", []Sort{ints, ints}, ints)
+	e := f.Apply(x, x.Add(x)).(Int)
+
+	// Replace f(a, b) with b - a.
+	body := ctx.BoundVar(1, ints).(Int).Sub(ctx.BoundVar(0, ints).(Int))
+	got := ctx.SubstituteFuns(e, []FuncDecl{f}, []Value{body})
+	want := x.Add(x).Sub(x)
+	if !got.AsAST().Equal(want.AsAST()) {
+		t.Errorf("SubstituteFuns(%v) = %v, want %v", e, got, want)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"runtime"
+)
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+
+// z3goBVFold folds n > 0 bit-vectors with bvadd, or bvmul if mul is
+// set, and returns the result with one reference held for the caller.
+static Z3_ast z3goBVFold(Z3_context c, unsigned n, Z3_ast *vals, int mul) {
+	Z3_ast acc = vals[0], next;
+	unsigned i;
+
+	Z3_inc_ref(c, acc);
+	for (i = 1; i < n; i++) {
+		if (mul)
+			next = Z3_mk_bvmul(c, acc, vals[i]);
+		else
+			next = Z3_mk_bvadd(c, acc, vals[i]);
+		Z3_inc_ref(c, next);
+		Z3_dec_ref(c, acc);
+		acc = next;
+	}
+	return acc;
+}
+*/
+import "C"
+
+// SumInt returns the sum of vals as a single term. If vals is empty,
+// it returns 0.
+func (ctx *Context) SumInt(vals []Int) Int {
+	if len(vals) == 0 {
+		return ctx.FromInt(0, ctx.IntSort()).(Int)
+	}
+	return ctx.AddN(intValues(vals)...).(Int)
+}
+
+// ProductInt returns the product of vals as a single term. If vals is
+// empty, it returns 1.
+func (ctx *Context) ProductInt(vals []Int) Int {
+	if len(vals) == 0 {
+		return ctx.FromInt(1, ctx.IntSort()).(Int)
+	}
+	return ctx.MulN(intValues(vals)...).(Int)
+}
+
+func intValues(vals []Int) []Value {
+	xs := make([]Value, len(vals))
+	for i, v := range vals {
+		xs[i] = v
+	}
+	return xs
+}
+
+// SumReal returns the sum of vals as a single term. If vals is empty,
+// it returns 0.
+func (ctx *Context) SumReal(vals []Real) Real {
+	if len(vals) == 0 {
+		return ctx.FromInt(0, ctx.RealSort()).(Real)
+	}
+	return ctx.AddN(realValues(vals)...).(Real)
+}
+
+// ProductReal returns the product of vals as a single term. If vals
+// is empty, it returns 1.
+func (ctx *Context) ProductReal(vals []Real) Real {
+	if len(vals) == 0 {
+		return ctx.FromInt(1, ctx.RealSort()).(Real)
+	}
+	return ctx.MulN(realValues(vals)...).(Real)
+}
+
+func realValues(vals []Real) []Value {
+	xs := make([]Value, len(vals))
+	for i, v := range vals {
+		xs[i] = v
+	}
+	return xs
+}
+
+// SumBV returns the sum of vals, modulo 2^n.
+//
+// vals must be non-empty and all have the same width, which is the
+// width of the result. SumBV panics otherwise.
+func (ctx *Context) SumBV(vals []BV) BV {
+	return ctx.foldBV("SumBV", vals, false)
+}
+
+// ProductBV returns the product of vals, modulo 2^n.
+//
+// vals must be non-empty and all have the same width, which is the
+// width of the result. ProductBV panics otherwise.
+func (ctx *Context) ProductBV(vals []BV) BV {
+	return ctx.foldBV("ProductBV", vals, true)
+}
+
+func (ctx *Context) foldBV(op string, vals []BV, mul bool) BV {
+	if len(vals) == 0 {
+		panic("z3: " + op + " of no values")
+	}
+	cargs := make([]C.Z3_ast, len(vals))
+	for i, v := range vals {
+		cargs[i] = v.c
+	}
+	var val value
+	ctx.do(func() {
+		width := C.Z3_get_bv_sort_size(ctx.c, C.Z3_get_sort(ctx.c, cargs[0]))
+		for i, c := range cargs[1:] {
+			if w := C.Z3_get_bv_sort_size(ctx.c, C.Z3_get_sort(ctx.c, c)); w != width {
+				panic(fmt.Sprintf("z3: %s of mismatched widths: value 0 has width %d, value %d has width %d", op, width, i+1, w))
+			}
+		}
+		cmul := C.int(0)
+		if mul {
+			cmul = 1
+		}
+		c := C.z3goBVFold(ctx.c, C.uint(len(cargs)), &cargs[0], cmul)
+		val = value{(*valueImpl)(wrapAST(ctx, c).astImpl), noEq{}}
+		C.Z3_dec_ref(ctx.c, c)
+	})
+	runtime.KeepAlive(vals)
+	return BV(val)
+}
@@ -0,0 +1,65 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestSum(t *testing.T) {
+	ctx := NewContext(nil)
+	ints := []Int{
+		ctx.FromInt(2, ctx.IntSort()).(Int),
+		ctx.FromInt(3, ctx.IntSort()).(Int),
+		ctx.FromInt(7, ctx.IntSort()).(Int),
+	}
+	reals := []Real{ctx.FromInt(1, ctx.RealSort()).(Real), ctx.FromInt(4, ctx.RealSort()).(Real)}
+	bvs := []BV{
+		ctx.FromInt(200, ctx.BVSort(8)).(BV),
+		ctx.FromInt(100, ctx.BVSort(8)).(BV),
+		ctx.FromInt(3, ctx.BVSort(8)).(BV),
+	}
+
+	for _, test := range []struct {
+		name string
+		v    Value
+		want int64
+	}{
+		{"SumInt", ctx.SumInt(ints), 12},
+		{"ProductInt", ctx.ProductInt(ints), 42},
+		{"SumInt()", ctx.SumInt(nil), 0},
+		{"ProductInt()", ctx.ProductInt(nil), 1},
+		{"SumReal", ctx.SumReal(reals), 5},
+		{"ProductReal", ctx.ProductReal(reals), 4},
+		{"SumReal()", ctx.SumReal(nil), 0},
+		{"SumBV", ctx.SumBV(bvs), 47},
+		{"ProductBV", ctx.ProductBV(bvs), 96},
+		{"SumBV(x)", ctx.SumBV(bvs[:1]), 200},
+	} {
+		var got int64
+		var ok bool
+		switch v := ctx.Simplify(test.v, nil).(type) {
+		case Int:
+			got, _, ok = v.AsInt64()
+		case Real:
+			var denom int64
+			got, denom, _, ok = v.AsSmallRat()
+			ok = ok && denom == 1
+		case BV:
+			got, _, ok = v.AsInt64()
+			got &= 0xff
+		}
+		if !ok || got != test.want {
+			t.Errorf("%s = %s, want %d", test.name, test.v, test.want)
+		}
+	}
+
+	if n := ctx.SumInt([]Int{ctx.IntConst("x"), ctx.IntConst("y"), ctx.IntConst("z")}).NumArgs(); n != 3 {
+		t.Errorf("SumInt of 3 values has %d arguments, want 3", n)
+	}
+
+	wantPanic(t, "no values", func() { ctx.SumBV(nil) })
+	wantPanic(t, "mismatched widths", func() {
+		ctx.ProductBV([]BV{bvs[0], ctx.BVConst("w", 16)})
+	})
+}
@@ -15,7 +15,7 @@ import "C"
 func (l Uninterpreted) Eq(r Uninterpreted) Bool {
 	ctx := l.ctx
 	val := wrapValue(ctx, func() C.Z3_ast {
-		return C.Z3_mk_eq(ctx.c, l.c, r.c)
+		return C.Z3_mk_eq(ctx.c, l.cref(), r.cref())
 	})
 	runtime.KeepAlive(l)
 	runtime.KeepAlive(r)
@@ -26,3 +26,17 @@ func (l Uninterpreted) Eq(r Uninterpreted) Bool {
 func (l Uninterpreted) NE(r Uninterpreted) Bool {
 	return l.ctx.Distinct(l, r)
 }
+
+// If returns a Value equal to l if cond is true, otherwise r.
+//
+// This is a typed form of Bool.IfThenElse.
+func (l Uninterpreted) If(cond Bool, r Uninterpreted) Uninterpreted {
+	ctx := l.ctx
+	val := wrapValue(ctx, func() C.Z3_ast {
+		return C.Z3_mk_ite(ctx.c, cond.cref(), l.cref(), r.cref())
+	})
+	runtime.KeepAlive(l)
+	runtime.KeepAlive(cond)
+	runtime.KeepAlive(r)
+	return Uninterpreted(val)
+}
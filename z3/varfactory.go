@@ -0,0 +1,87 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"strconv"
+	"sync"
+)
+
+// A VarFactory creates constants with unique, readable names.
+//
+// Constants with the same name and sort are the same constant, so
+// building an encoding from independently named temporaries risks
+// aliasing values that are meant to be distinct. A VarFactory avoids
+// this by appending a counter to each name, which it tracks
+// separately for each sort. Each name has the form
+// "prefix.name!n", or "name!n" if the prefix is empty. Constants of
+// different sorts may share a name, but are still distinct.
+//
+// Unlike FreshConst, the names a VarFactory generates are
+// deterministic, so they're stable across runs and can be looked up
+// later, for example in a model or a saved SMT-LIB file.
+//
+// Names are only unique among the constants created by one
+// VarFactory. Users should choose distinct prefixes for different
+// VarFactories in the same Context.
+//
+// A VarFactory is safe for concurrent use.
+type VarFactory struct {
+	ctx    *Context
+	prefix string
+
+	mu     sync.Mutex
+	counts map[varKey]int
+}
+
+type varKey struct {
+	name, sort string
+}
+
+// NewVarFactory returns a VarFactory that creates constants in ctx
+// whose names begin with prefix.
+func (ctx *Context) NewVarFactory(prefix string) *VarFactory {
+	return &VarFactory{ctx: ctx, prefix: prefix, counts: make(map[varKey]int)}
+}
+
+// Const returns a new constant of the given sort whose name is
+// derived from name.
+func (vf *VarFactory) Const(name string, sort Sort) Value {
+	return vf.ctx.Const(vf.next(name, sort), sort)
+}
+
+// Bool returns a new Bool constant whose name is derived from name.
+func (vf *VarFactory) Bool(name string) Bool {
+	return vf.Const(name, vf.ctx.BoolSort()).(Bool)
+}
+
+// Int returns a new Int constant whose name is derived from name.
+func (vf *VarFactory) Int(name string) Int {
+	return vf.Const(name, vf.ctx.IntSort()).(Int)
+}
+
+// Real returns a new Real constant whose name is derived from name.
+func (vf *VarFactory) Real(name string) Real {
+	return vf.Const(name, vf.ctx.RealSort()).(Real)
+}
+
+// BV returns a new bit-vector constant with the given width whose
+// name is derived from name.
+func (vf *VarFactory) BV(name string, bits int) BV {
+	return vf.Const(name, vf.ctx.BVSort(bits)).(BV)
+}
+
+// next returns the next unused name derived from name for sort.
+func (vf *VarFactory) next(name string, sort Sort) string {
+	if vf.prefix != "" {
+		name = vf.prefix + "." + name
+	}
+	key := varKey{name, sort.String()}
+	vf.mu.Lock()
+	n := vf.counts[key]
+	vf.counts[key] = n + 1
+	vf.mu.Unlock()
+	return name + "!" + strconv.Itoa(n)
+}
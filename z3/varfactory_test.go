@@ -0,0 +1,43 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "testing"
+
+func TestVarFactory(t *testing.T) {
+	ctx := NewContext(nil)
+	vf := ctx.NewVarFactory("enc")
+
+	a, b := vf.BV("tmp", 32), vf.BV("tmp", 32)
+	if a.String() != "enc.tmp!0" || b.String() != "enc.tmp!1" {
+		t.Errorf("got names %s, %s, want enc.tmp!0, enc.tmp!1", a, b)
+	}
+	// Counters are per sort.
+	if got := vf.BV("tmp", 8).String(); got != "enc.tmp!0" {
+		t.Errorf("got 8-bit name %s, want enc.tmp!0", got)
+	}
+	i := vf.Int("tmp")
+	if got := i.String(); got != "enc.tmp!0" {
+		t.Errorf("got Int name %s, want enc.tmp!0", got)
+	}
+	if i.AsAST().Equal(vf.Real("tmp").AsAST()) {
+		t.Errorf("Int and Real %s are the same constant", i)
+	}
+	if got := ctx.NewVarFactory("").Bool("b").String(); got != "b!0" {
+		t.Errorf("got unprefixed name %s, want b!0", got)
+	}
+
+	// Constants with the same base name are distinct.
+	s := NewSolver(ctx)
+	s.Assert(a.NE(b))
+	if sat, err := s.Check(); !sat || err != nil {
+		t.Fatalf("want sat, got %v, %v", sat, err)
+	}
+
+	x, y := vf.Real("r"), vf.Real("r")
+	if x.AsAST().Equal(y.AsAST()) {
+		t.Errorf("two Reals from VarFactory are the same constant %s", x)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+/*
+#cgo LDFLAGS: -lz3
+#include <z3.h>
+*/
+import "C"
+
+// Version returns the version of the Z3 library in use. This is the
+// library loaded at run time, which may differ from the one this
+// package was compiled against.
+//
+// This package requires Z3 4.12 or later. Older libraries lack C
+// functions it calls, such as Z3_solver_register_on_clause,
+// Z3_solver_congruence_root, Z3_substitute_funs, Z3_mk_char_from_bv,
+// and Z3_enable_concurrent_dec_ref, so the package fails to build or
+// link against them.
+func Version() (major, minor, build, rev int) {
+	var cmajor, cminor, cbuild, crev C.uint
+	C.Z3_get_version(&cmajor, &cminor, &cbuild, &crev)
+	return int(cmajor), int(cminor), int(cbuild), int(crev)
+}
+
+// FullVersion returns a string describing the version of the Z3
+// library in use, including any build information, such as
+// "4.8.12.0".
+func FullVersion() string {
+	return C.GoString(C.Z3_get_full_version())
+}
@@ -0,0 +1,22 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestVersion(t *testing.T) {
+	major, minor, build, _ := Version()
+	if major < 4 {
+		t.Errorf("Version() = %d.%d.%d, want at least 4", major, minor, build)
+	}
+	want := fmt.Sprintf("%d.%d.%d", major, minor, build)
+	if full := FullVersion(); !strings.Contains(full, want) {
+		t.Errorf("FullVersion() = %q, want it to contain %q", full, want)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import "math/big"
+
+// Z3 expressions are DAGs that typically share many subexpressions,
+// so a naive recursive traversal can take time exponential in the
+// size of the DAG. Walk and Transform visit each distinct
+// subexpression once, identifying them by AST ID.
+
+// Walk traverses x in depth-first pre-order, calling pre once for
+// each distinct subexpression of x (including x itself). If pre
+// returns false, Walk does not descend into the arguments of that
+// subexpression.
+//
+// Walk only descends into the arguments of applications. Quantifiers
+// and bound variables are treated as leaves.
+func Walk(x Value, pre func(Value) bool) {
+	seen := make(map[uint64]bool)
+	var walk func(x Value)
+	walk = func(x Value) {
+		id := x.AsAST().ID()
+		if seen[id] {
+			return
+		}
+		seen[id] = true
+		if !pre(x) {
+			return
+		}
+		for i, n := 0, x.NumArgs(); i < n; i++ {
+			walk(x.Arg(i))
+		}
+	}
+	walk(x)
+}
+
+// Transform rewrites x bottom-up. For each distinct subexpression v
+// of x, Transform first transforms v's arguments and, if any of them
+// changed, rebuilds v with the new arguments using UpdateArgs. It
+// then replaces v with f(v).
+//
+// The result of transforming each subexpression is cached, so f is
+// called at most once for each distinct subexpression. f may return
+// its argument to leave it unchanged.
+//
+// Like Walk, Transform treats quantifiers and bound variables as
+// leaves.
+func Transform(x Value, f func(Value) Value) Value {
+	cache := make(map[uint64]Value)
+	var transform func(x Value) Value
+	transform = func(x Value) Value {
+		id := x.AsAST().ID()
+		if y, ok := cache[id]; ok {
+			return y
+		}
+		n := x.NumArgs()
+		args := make([]Value, n)
+		changed := false
+		for i := range args {
+			arg := x.Arg(i)
+			args[i] = transform(arg)
+			if !args[i].AsAST().Equal(arg.AsAST()) {
+				changed = true
+			}
+		}
+		y := x
+		if changed {
+			y = x.UpdateArgs(args...)
+		}
+		y = f(y)
+		cache[id] = y
+		return y
+	}
+	return transform(x)
+}
+
+// ExprStats summarizes the size and shape of an expression. See
+// Measure.
+type ExprStats struct {
+	// DAGSize is the number of distinct subexpressions.
+	DAGSize int
+
+	// TreeSize is the number of nodes in the expression if shared
+	// subexpressions were expanded into a tree. This may be
+	// exponential in DAGSize.
+	TreeSize *big.Int
+
+	// Depth is the length of the longest path from the root to a
+	// leaf, counting both ends. A constant has depth 1.
+	Depth int
+
+	// Decls maps from declaration name (such as "bvadd" or the
+	// name of an uninterpreted function) to the number of
+	// distinct applications of declarations with that name.
+	// Numerals are counted under "numeral".
+	Decls map[string]int
+}
+
+// Measure computes statistics about the size of x. Like Walk, it
+// treats quantifiers and bound variables as leaves.
+func Measure(x Value) ExprStats {
+	type node struct {
+		size  *big.Int
+		depth int
+	}
+	stats := ExprStats{Decls: make(map[string]int)}
+	memo := make(map[uint64]node)
+	var measure func(x Value) node
+	measure = func(x Value) node {
+		id := x.AsAST().ID()
+		if n, ok := memo[id]; ok {
+			return n
+		}
+		stats.DAGSize++
+		n := node{big.NewInt(1), 1}
+		switch {
+		case x.AsAST().Kind() == ASTKindNumeral:
+			stats.Decls["numeral"]++
+		case x.IsApp():
+			stats.Decls[x.Decl().Name()]++
+			for i, nargs := 0, x.NumArgs(); i < nargs; i++ {
+				arg := measure(x.Arg(i))
+				n.size.Add(n.size, arg.size)
+				if arg.depth+1 > n.depth {
+					n.depth = arg.depth + 1
+				}
+			}
+		}
+		memo[id] = n
+		return n
+	}
+	root := measure(x)
+	stats.TreeSize, stats.Depth = root.size, root.depth
+	return stats
+}
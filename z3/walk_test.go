@@ -0,0 +1,117 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWalk(t *testing.T) {
+	ctx := NewContext(nil)
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+
+	// Build a DAG with 2^100 paths but only 101 distinct nodes.
+	e := x
+	for i := 0; i < 100; i++ {
+		e = e.Add(e)
+	}
+
+	visits := 0
+	Walk(e, func(Value) bool {
+		visits++
+		return true
+	})
+	if visits != 101 {
+		t.Errorf("Walk visited %d nodes, want 101", visits)
+	}
+
+	// Don't descend into x+y.
+	sum := x.Add(y)
+	var seen []string
+	Walk(sum.Mul(y), func(v Value) bool {
+		seen = append(seen, v.String())
+		return !v.AsAST().Equal(sum.AsAST())
+	})
+	if got, want := strings.Join(seen, " "), "(* (+ x y) y) (+ x y) y"; got != want {
+		t.Errorf("pruned Walk visited %s, want %s", got, want)
+	}
+}
+
+func TestTransform(t *testing.T) {
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	x, y := ctx.IntConst("x"), ctx.IntConst("y")
+
+	e, want := x, y
+	for i := 0; i < 100; i++ {
+		e, want = e.Add(e), want.Add(want)
+	}
+
+	calls := 0
+	got := Transform(e, func(v Value) Value {
+		calls++
+		if v.AsAST().Equal(x.AsAST()) {
+			return y
+		}
+		return v
+	})
+	if !got.AsAST().Equal(want.AsAST()) {
+		t.Errorf("Transform produced wrong result")
+	}
+	if calls != 101 {
+		t.Errorf("Transform called f %d times, want 101", calls)
+	}
+
+	// Rewrite a*2 to a+a, bottom-up.
+	two := ctx.FromInt(2, ints).(Int)
+	double := x.Mul(two).Mul(two)
+	got = Transform(double, func(v Value) Value {
+		if v.NumArgs() == 2 && v.Decl().AsAST().Equal(x.Mul(y).Decl().AsAST()) && v.Arg(1).AsAST().Equal(two.AsAST()) {
+			a := v.Arg(0).(Int)
+			return a.Add(a)
+		}
+		return v
+	})
+	want = x.Add(x).Add(x.Add(x))
+	if !got.AsAST().Equal(want.AsAST()) {
+		t.Errorf("Transform(%v) = %v, want %v", double, got, want)
+	}
+}
+
+func TestMeasure(t *testing.T) {
+	ctx := NewContext(nil)
+	ints := ctx.IntSort()
+	x := ctx.IntConst("x")
+	f := ctx.FuncDecl("f", []Sort{ints}, ints)
+
+	// (f (+ e e)) nested 10 times around x*2.
+	e := x.Mul(ctx.FromInt(2, ints).(Int))
+	for i := 0; i < 10; i++ {
+		e = f.Apply(e.Add(e)).(Int)
+	}
+
+	st := Measure(e)
+	if st.DAGSize != 23 {
+		t.Errorf("DAGSize = %d, want 23", st.DAGSize)
+	}
+	// Each level is 2 + 2*(size of level below), starting at 3.
+	want := big.NewInt(3)
+	for i := 0; i < 10; i++ {
+		want.Mul(want, big.NewInt(2)).Add(want, big.NewInt(2))
+	}
+	if st.TreeSize.Cmp(want) != 0 {
+		t.Errorf("TreeSize = %v, want %v", st.TreeSize, want)
+	}
+	if st.Depth != 22 {
+		t.Errorf("Depth = %d, want 22", st.Depth)
+	}
+	wantDecls := map[string]int{"f": 10, "+": 10, "*": 1, "x": 1, "numeral": 1}
+	if !reflect.DeepEqual(st.Decls, wantDecls) {
+		t.Errorf("Decls = %v, want %v", st.Decls, wantDecls)
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package z3bench measures the performance of Z3 encodings and
+// queries across solver configurations.
+//
+// A benchmark is a set of Cases, each of which builds a problem, run
+// under a set of Configs. Run measures the time to build and to
+// check each Case under each Config and collects the solver's
+// statistics. Compare reports the change between two sets of
+// results, such as before and after a change to an encoding or to
+// the z3 package itself.
+package z3bench
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aclements/go-z3/z3"
+)
+
+// A Case is a problem to benchmark.
+type Case struct {
+	// Name identifies the Case in results.
+	Name string
+
+	// Build constructs the problem in ctx and returns a Solver
+	// that is ready to check. Build is timed separately from the
+	// check, so encoding performance can be measured too.
+	Build func(ctx *z3.Context) *z3.Solver
+}
+
+// A Config is a solver configuration to benchmark Cases under.
+type Config struct {
+	// Name identifies the Config in results.
+	Name string
+
+	// Context configures the Context of each run. If nil, the
+	// default configuration is used.
+	Context *z3.Config
+
+	// Params sets solver parameters, such as "smt.arith.solver".
+	// Values must be bool, uint, float64, or string.
+	Params map[string]interface{}
+}
+
+// A Result is the measurement of one Case under one Config.
+type Result struct {
+	Case, Config string
+
+	// Build and Check are the median time to build and to check
+	// the Case across all runs.
+	Build, Check time.Duration
+
+	// Sat and Err are the result of checking the Case in the last
+	// run.
+	Sat bool
+	Err error
+
+	// Stats are the solver's statistics from the last run.
+	Stats z3.Statistics
+}
+
+// Run runs each Case under each Config runs times, each time in a
+// new Context, and returns a Result for each pair.
+func Run(cases []Case, configs []Config, runs int) []Result {
+	if runs < 1 {
+		runs = 1
+	}
+	var results []Result
+	for _, c := range cases {
+		for _, cfg := range configs {
+			res := Result{Case: c.Name, Config: cfg.Name}
+			var builds, checks []time.Duration
+			for i := 0; i < runs; i++ {
+				ctx := z3.NewContext(cfg.Context)
+				start := time.Now()
+				s := c.Build(ctx)
+				setParams(s, cfg.Params)
+				mid := time.Now()
+				res.Sat, res.Err = s.Check()
+				end := time.Now()
+				res.Stats = s.Statistics()
+				ctx.Close()
+				builds = append(builds, mid.Sub(start))
+				checks = append(checks, end.Sub(mid))
+			}
+			res.Build, res.Check = median(builds), median(checks)
+			results = append(results, res)
+		}
+	}
+	return results
+}
+
+func setParams(s *z3.Solver, params map[string]interface{}) {
+	if len(params) == 0 {
+		return
+	}
+	cfg := s.Config()
+	for name, val := range params {
+		switch val := val.(type) {
+		case bool:
+			cfg.SetBool(name, val)
+		case uint:
+			cfg.SetUint(name, val)
+		case float64:
+			cfg.SetFloat(name, val)
+		case string:
+			cfg.SetString(name, val)
+		default:
+			panic(fmt.Sprintf("z3bench: parameter %s has unsupported type %T", name, val))
+		}
+	}
+}
+
+func median(ds []time.Duration) time.Duration {
+	ds = append([]time.Duration(nil), ds...)
+	sort.Slice(ds, func(i, j int) bool { return ds[i] < ds[j] })
+	return ds[len(ds)/2]
+}
+
+// Report writes a table of results to w.
+func Report(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "case\tconfig\tbuild\tcheck\tresult\n")
+	for _, r := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%v\t%v\t%s\n", r.Case, r.Config, r.Build, r.Check, r.outcome())
+	}
+	return tw.Flush()
+}
+
+func (r Result) outcome() string {
+	switch {
+	case r.Err != nil:
+		return "unknown (" + r.Err.Error() + ")"
+	case r.Sat:
+		return "sat"
+	}
+	return "unsat"
+}
+
+// Compare writes to w a table comparing the check times of new
+// results to old results for each Case and Config that appears in
+// both. It also flags pairs whose outcome changed.
+func Compare(w io.Writer, old, new []Result) error {
+	type key struct{ c, cfg string }
+	oldByKey := make(map[key]Result)
+	for _, r := range old {
+		oldByKey[key{r.Case, r.Config}] = r
+	}
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "case\tconfig\told check\tnew check\tdelta\t\n")
+	for _, r := range new {
+		o, ok := oldByKey[key{r.Case, r.Config}]
+		if !ok {
+			continue
+		}
+		delta := "~"
+		if o.Check > 0 {
+			delta = fmt.Sprintf("%+.1f%%", 100*(float64(r.Check)/float64(o.Check)-1))
+		}
+		note := ""
+		if o.outcome() != r.outcome() {
+			note = fmt.Sprintf("(was %s, now %s)", o.outcome(), r.outcome())
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%v\t%v\t%s\t%s\n", r.Case, r.Config, o.Check, r.Check, delta, note)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,61 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3bench
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aclements/go-z3/z3"
+)
+
+func TestRun(t *testing.T) {
+	cases := []Case{{
+		Name: "square",
+		Build: func(ctx *z3.Context) *z3.Solver {
+			x := ctx.IntConst("x")
+			s := z3.NewSolver(ctx)
+			s.Assert(x.Mul(x).Eq(ctx.FromInt(49, ctx.IntSort()).(z3.Int)))
+			return s
+		},
+	}}
+	configs := []Config{
+		{Name: "default"},
+		{Name: "seeded", Params: map[string]interface{}{"random_seed": uint(3)}},
+	}
+	results := Run(cases, configs, 3)
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	for _, r := range results {
+		if !r.Sat || r.Err != nil {
+			t.Errorf("%s/%s: got %v, %v, want sat", r.Case, r.Config, r.Sat, r.Err)
+		}
+		if len(r.Stats) == 0 {
+			t.Errorf("%s/%s: no statistics", r.Case, r.Config)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := Report(&buf, results); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "seeded") || !strings.Contains(buf.String(), "sat") {
+		t.Errorf("unexpected report:\n%s", buf.String())
+	}
+
+	old := append([]Result(nil), results...)
+	old[0].Check = 2 * time.Second
+	results[0].Check = time.Second
+	buf.Reset()
+	if err := Compare(&buf, old, results); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "-50.0%") {
+		t.Errorf("comparison does not show a 50%% improvement:\n%s", buf.String())
+	}
+}
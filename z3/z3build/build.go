@@ -0,0 +1,206 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package z3build provides a fluent layer for building Z3
+// constraints.
+//
+// Operations on z3.Values panic if their operands have the wrong
+// sorts. This is appropriate for encodings whose sorts are fixed by
+// the program, but makes it awkward to build constraints from
+// dynamic input. Terms in this package instead record errors in
+// their Builder and continue, so a sequence of constraints can be
+// written straight through and checked once at the end:
+//
+//	b := z3build.New(solver)
+//	x, y := b.BV("x", 32), b.BV("y", 32)
+//	b.Assert(x.Add(b.Lit(1)).ULT(y))
+//	if err := b.Err(); err != nil {
+//		...
+//	}
+//
+// Untyped literals created by Builder.Lit take on the sort of the
+// other operand, as untyped constants do in Go.
+package z3build
+
+import (
+	"fmt"
+
+	"github.com/aclements/go-z3/z3"
+)
+
+// A Builder builds Terms and asserts them to a Solver, collecting
+// errors as it goes.
+//
+// A Builder is not safe for concurrent use.
+type Builder struct {
+	ctx  *z3.Context
+	s    *z3.Solver
+	errs ErrorList
+}
+
+// New returns a Builder that asserts to s.
+func New(s *z3.Solver) *Builder {
+	return &Builder{ctx: s.Context(), s: s}
+}
+
+// Context returns the Context of b's Solver.
+func (b *Builder) Context() *z3.Context {
+	return b.ctx
+}
+
+// Err returns the errors collected by b as an ErrorList, or nil if
+// there have been no errors.
+func (b *Builder) Err() error {
+	if len(b.errs) == 0 {
+		return nil
+	}
+	return b.errs
+}
+
+// Assert asserts t to b's Solver. If t is invalid, Assert does
+// nothing, since the error that made it invalid has already been
+// collected. If t is not a Bool, Assert records an error.
+func (b *Builder) Assert(t Term) {
+	if !b.owns("Assert", t) || !t.valid() {
+		return
+	}
+	v, ok := t.v.(z3.Bool)
+	if !ok {
+		b.fail("Assert", fmt.Errorf("%s is not a Bool", t.sortString()))
+		return
+	}
+	b.try("Assert", func() z3.Value {
+		b.s.Assert(v)
+		return v
+	})
+}
+
+// Check checks the satisfiability of b's Solver, like z3.Solver.Check.
+// If b has collected any errors, Check returns them without checking.
+func (b *Builder) Check() (sat bool, err error) {
+	if err := b.Err(); err != nil {
+		return false, err
+	}
+	return b.s.Check()
+}
+
+// An Error is an error from a single operation on a Builder or Term.
+type Error struct {
+	// Op is the name of the failed operation, such as "Add".
+	Op string
+
+	// Err is the underlying error. This is a *z3.Error if the
+	// error was reported by Z3.
+	Err error
+}
+
+func (e *Error) Error() string {
+	return "z3build: " + e.Op + ": " + e.Err.Error()
+}
+
+// An ErrorList is a list of errors collected by a Builder, in the
+// order they occurred.
+type ErrorList []*Error
+
+func (l ErrorList) Error() string {
+	switch len(l) {
+	case 0:
+		return "no errors"
+	case 1:
+		return l[0].Error()
+	}
+	return fmt.Sprintf("%s (and %d more errors)", l[0], len(l)-1)
+}
+
+func (b *Builder) fail(op string, err error) Term {
+	b.errs = append(b.errs, &Error{op, err})
+	return Term{b: b}
+}
+
+// try calls f and returns its result as a Term, recording any Z3
+// error raised by f.
+func (b *Builder) try(op string, f func() z3.Value) Term {
+	var v z3.Value
+	if err := z3.Try(func() { v = f() }); err != nil {
+		return b.fail(op, err)
+	}
+	return Term{b: b, v: v}
+}
+
+// owns checks that every Term in ts was created by b. Terms from
+// different Builders may belong to different Contexts and errors on
+// them would be recorded in the wrong Builder, so mixing them is an
+// error.
+func (b *Builder) owns(op string, ts ...Term) bool {
+	for _, t := range ts {
+		if t.b != b {
+			b.fail(op, fmt.Errorf("operand %s belongs to a different Builder", t))
+			return false
+		}
+	}
+	return true
+}
+
+// Bool returns a Term for the Bool constant named name.
+func (b *Builder) Bool(name string) Term {
+	return b.try("Bool", func() z3.Value { return b.ctx.BoolConst(name) })
+}
+
+// Int returns a Term for the Int constant named name.
+func (b *Builder) Int(name string) Term {
+	return b.try("Int", func() z3.Value { return b.ctx.IntConst(name) })
+}
+
+// Real returns a Term for the Real constant named name.
+func (b *Builder) Real(name string) Term {
+	return b.try("Real", func() z3.Value { return b.ctx.RealConst(name) })
+}
+
+// BV returns a Term for the bit-vector constant named name with the
+// given width.
+func (b *Builder) BV(name string, bits int) Term {
+	if bits <= 0 {
+		return b.fail("BV", fmt.Errorf("bad width %d", bits))
+	}
+	return b.try("BV", func() z3.Value { return b.ctx.BVConst(name, bits) })
+}
+
+// Lit returns an untyped integer literal. When combined with another
+// Term, it becomes a literal of that Term's sort, which must be Int,
+// Real, or a bit-vector sort.
+func (b *Builder) Lit(val int64) Term {
+	return Term{b: b, lit: val, isLit: true}
+}
+
+// BoolLit returns a Term for the Bool literal val.
+func (b *Builder) BoolLit(val bool) Term {
+	return Term{b: b, v: b.ctx.FromBool(val)}
+}
+
+// Value returns a Term for v, which must belong to b's Context.
+func (b *Builder) Value(v z3.Value) Term {
+	if v.Context() != b.ctx {
+		return b.fail("Value", fmt.Errorf("%s belongs to a different Context", v))
+	}
+	return Term{b: b, v: v}
+}
+
+// If returns a Term equal to cons if cond is true and otherwise alt.
+func (b *Builder) If(cond, cons, alt Term) Term {
+	if !b.owns("If", cond, cons, alt) {
+		return Term{b: b}
+	}
+	if !cond.valid() || !cons.valid() || !alt.valid() {
+		return Term{b: b}
+	}
+	c, ok := cond.v.(z3.Bool)
+	if !ok {
+		return b.fail("If", fmt.Errorf("condition is %s, not Bool", cond.sortString()))
+	}
+	cons, alt, ok = b.unify("If", cons, alt)
+	if !ok {
+		return Term{b: b}
+	}
+	return b.try("If", func() z3.Value { return c.IfThenElse(cons.v, alt.v) })
+}
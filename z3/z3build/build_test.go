@@ -0,0 +1,110 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3build
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aclements/go-z3/z3"
+)
+
+func TestBuilder(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	s := z3.NewSolver(ctx)
+	b := New(s)
+
+	x, y := b.BV("x", 32), b.BV("y", 32)
+	b.Assert(x.Add(b.Lit(1)).ULT(y))
+	b.Assert(y.Eq(b.Lit(10)))
+	n := b.Int("n")
+	b.Assert(b.Lit(3).Mul(n).GT(b.Lit(4)).And(n.LT(b.Lit(3))))
+	b.Assert(b.If(b.BoolLit(true), n, b.Lit(0)).Eq(b.Value(ctx.FromInt(2, ctx.IntSort()))))
+	if err := b.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if sat, err := b.Check(); !sat || err != nil {
+		t.Fatalf("want sat, got %v, %v", sat, err)
+	}
+	m := s.Model()
+	if got, _, _ := m.Eval(n.Value(), true).(z3.Int).AsInt64(); got != 2 {
+		t.Errorf("n = %d, want 2", got)
+	}
+	if got, _, _ := m.Eval(x.Value(), true).(z3.BV).AsUint64(); got+1 >= 10 {
+		t.Errorf("x = %d, want x+1 < 10", got)
+	}
+}
+
+func TestBuilderErrors(t *testing.T) {
+	ctx := z3.NewContext(nil)
+	b := New(z3.NewSolver(ctx))
+
+	x, y := b.BV("x", 32), b.BV("y", 16)
+	n, p := b.Int("n"), b.Bool("p")
+
+	// Each mistake is collected once, and building continues.
+	bad := x.Add(y)          // width mismatch reported by Z3
+	b.Assert(bad.ULT(x))     // no new error
+	b.Assert(n.Add(p))       // kind mismatch
+	b.Assert(x.LT(x))        // LT is not defined on bit-vectors
+	b.Assert(p.Or(b.Lit(1))) // literal cannot be a Bool
+	b.Assert(n.Add(b.Lit(1)))
+	b.Assert(b.Lit(1).Eq(b.Lit(1)))
+	b.Assert(p.Not().Implies(p))
+	b.Value(z3.NewContext(nil).IntConst("z"))
+
+	err := b.Err()
+	list, ok := err.(ErrorList)
+	if !ok {
+		t.Fatalf("Err() = %v (%T), want ErrorList", err, err)
+	}
+	wantOps := []string{"Add", "Add", "LT", "Or", "Assert", "Eq", "Value"}
+	if len(list) != len(wantOps) {
+		t.Fatalf("got %d errors, want %d: %v", len(list), len(wantOps), []*Error(list))
+	}
+	for i, e := range list {
+		if e.Op != wantOps[i] {
+			t.Errorf("error %d is from %s, want %s: %v", i, e.Op, wantOps[i], e)
+		}
+	}
+	if _, ok := list[0].Err.(*z3.Error); !ok {
+		t.Errorf("width mismatch error is %T, want *z3.Error", list[0].Err)
+	}
+	if !strings.Contains(err.Error(), "and 6 more errors") {
+		t.Errorf("unexpected error string %q", err)
+	}
+	if _, err := b.Check(); err == nil {
+		t.Errorf("Check succeeded despite errors")
+	}
+}
+
+func TestBuilderMixed(t *testing.T) {
+	b1 := New(z3.NewSolver(z3.NewContext(nil)))
+	b2 := New(z3.NewSolver(z3.NewContext(nil)))
+	x1, x2 := b1.Int("x"), b2.Int("x")
+
+	b1.Assert(x1.Add(x2).Eq(x1))
+	b1.Assert(b1.If(b2.BoolLit(true), x1, x1).Eq(x1))
+	b2.Assert(x1.Eq(x1))
+
+	for i, test := range []struct {
+		b   *Builder
+		ops []string
+	}{
+		{b1, []string{"Add", "If"}},
+		{b2, []string{"Assert"}},
+	} {
+		list, _ := test.b.Err().(ErrorList)
+		if len(list) != len(test.ops) {
+			t.Errorf("builder %d: got errors %v, want %d", i+1, []*Error(list), len(test.ops))
+			continue
+		}
+		for j, e := range list {
+			if e.Op != test.ops[j] || !strings.Contains(e.Error(), "different Builder") {
+				t.Errorf("builder %d: error %d is %v, want %s with different Builder", i+1, j, e, test.ops[j])
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3build
+
+import "github.com/aclements/go-z3/z3"
+
+// Operation tables for Term methods, indexed by the kind of the
+// operands' sort. The operands have already been unified, so both
+// have the same kind.
+
+var addOps = binop{
+	z3.KindInt:  func(l, r z3.Value) z3.Value { return l.(z3.Int).Add(r.(z3.Int)) },
+	z3.KindReal: func(l, r z3.Value) z3.Value { return l.(z3.Real).Add(r.(z3.Real)) },
+	z3.KindBV:   func(l, r z3.Value) z3.Value { return l.(z3.BV).Add(r.(z3.BV)) },
+}
+
+var subOps = binop{
+	z3.KindInt:  func(l, r z3.Value) z3.Value { return l.(z3.Int).Sub(r.(z3.Int)) },
+	z3.KindReal: func(l, r z3.Value) z3.Value { return l.(z3.Real).Sub(r.(z3.Real)) },
+	z3.KindBV:   func(l, r z3.Value) z3.Value { return l.(z3.BV).Sub(r.(z3.BV)) },
+}
+
+var mulOps = binop{
+	z3.KindInt:  func(l, r z3.Value) z3.Value { return l.(z3.Int).Mul(r.(z3.Int)) },
+	z3.KindReal: func(l, r z3.Value) z3.Value { return l.(z3.Real).Mul(r.(z3.Real)) },
+	z3.KindBV:   func(l, r z3.Value) z3.Value { return l.(z3.BV).Mul(r.(z3.BV)) },
+}
+
+var negOps = unop{
+	z3.KindInt:  func(l z3.Value) z3.Value { return l.(z3.Int).Neg() },
+	z3.KindReal: func(l z3.Value) z3.Value { return l.(z3.Real).Neg() },
+	z3.KindBV:   func(l z3.Value) z3.Value { return l.(z3.BV).Neg() },
+}
+
+var eqOps = binop{
+	z3.KindBool: func(l, r z3.Value) z3.Value { return l.(z3.Bool).Eq(r.(z3.Bool)) },
+	z3.KindInt:  func(l, r z3.Value) z3.Value { return l.(z3.Int).Eq(r.(z3.Int)) },
+	z3.KindReal: func(l, r z3.Value) z3.Value { return l.(z3.Real).Eq(r.(z3.Real)) },
+	z3.KindBV:   func(l, r z3.Value) z3.Value { return l.(z3.BV).Eq(r.(z3.BV)) },
+}
+
+var neOps = binop{
+	z3.KindBool: func(l, r z3.Value) z3.Value { return l.(z3.Bool).NE(r.(z3.Bool)) },
+	z3.KindInt:  func(l, r z3.Value) z3.Value { return l.(z3.Int).NE(r.(z3.Int)) },
+	z3.KindReal: func(l, r z3.Value) z3.Value { return l.(z3.Real).NE(r.(z3.Real)) },
+	z3.KindBV:   func(l, r z3.Value) z3.Value { return l.(z3.BV).NE(r.(z3.BV)) },
+}
+
+var ltOps = binop{
+	z3.KindInt:  func(l, r z3.Value) z3.Value { return l.(z3.Int).LT(r.(z3.Int)) },
+	z3.KindReal: func(l, r z3.Value) z3.Value { return l.(z3.Real).LT(r.(z3.Real)) },
+}
+
+var leOps = binop{
+	z3.KindInt:  func(l, r z3.Value) z3.Value { return l.(z3.Int).LE(r.(z3.Int)) },
+	z3.KindReal: func(l, r z3.Value) z3.Value { return l.(z3.Real).LE(r.(z3.Real)) },
+}
+
+var gtOps = binop{
+	z3.KindInt:  func(l, r z3.Value) z3.Value { return l.(z3.Int).GT(r.(z3.Int)) },
+	z3.KindReal: func(l, r z3.Value) z3.Value { return l.(z3.Real).GT(r.(z3.Real)) },
+}
+
+var geOps = binop{
+	z3.KindInt:  func(l, r z3.Value) z3.Value { return l.(z3.Int).GE(r.(z3.Int)) },
+	z3.KindReal: func(l, r z3.Value) z3.Value { return l.(z3.Real).GE(r.(z3.Real)) },
+}
+
+var ultOps = binop{z3.KindBV: func(l, r z3.Value) z3.Value { return l.(z3.BV).ULT(r.(z3.BV)) }}
+var uleOps = binop{z3.KindBV: func(l, r z3.Value) z3.Value { return l.(z3.BV).ULE(r.(z3.BV)) }}
+var ugtOps = binop{z3.KindBV: func(l, r z3.Value) z3.Value { return l.(z3.BV).UGT(r.(z3.BV)) }}
+var ugeOps = binop{z3.KindBV: func(l, r z3.Value) z3.Value { return l.(z3.BV).UGE(r.(z3.BV)) }}
+var sltOps = binop{z3.KindBV: func(l, r z3.Value) z3.Value { return l.(z3.BV).SLT(r.(z3.BV)) }}
+var sleOps = binop{z3.KindBV: func(l, r z3.Value) z3.Value { return l.(z3.BV).SLE(r.(z3.BV)) }}
+var sgtOps = binop{z3.KindBV: func(l, r z3.Value) z3.Value { return l.(z3.BV).SGT(r.(z3.BV)) }}
+var sgeOps = binop{z3.KindBV: func(l, r z3.Value) z3.Value { return l.(z3.BV).SGE(r.(z3.BV)) }}
+
+var andOps = binop{z3.KindBool: func(l, r z3.Value) z3.Value { return l.(z3.Bool).And(r.(z3.Bool)) }}
+var orOps = binop{z3.KindBool: func(l, r z3.Value) z3.Value { return l.(z3.Bool).Or(r.(z3.Bool)) }}
+var impliesOps = binop{z3.KindBool: func(l, r z3.Value) z3.Value { return l.(z3.Bool).Implies(r.(z3.Bool)) }}
+var notOps = unop{z3.KindBool: func(l z3.Value) z3.Value { return l.(z3.Bool).Not() }}
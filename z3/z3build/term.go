@@ -0,0 +1,191 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3build
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/aclements/go-z3/z3"
+)
+
+// A Term is a symbolic value being built by a Builder.
+//
+// A Term is either a z3.Value, an untyped literal, or invalid. An
+// operation that fails records an error in the Builder and returns
+// an invalid Term. Operations on invalid Terms return invalid Terms
+// without recording further errors, so each mistake is reported
+// once.
+type Term struct {
+	b     *Builder
+	v     z3.Value
+	lit   int64
+	isLit bool
+}
+
+func (t Term) valid() bool {
+	return t.v != nil || t.isLit
+}
+
+// Value returns t's value, or nil if t is invalid or an untyped
+// literal.
+func (t Term) Value() z3.Value {
+	return t.v
+}
+
+// String returns a string representation of t.
+func (t Term) String() string {
+	switch {
+	case t.v != nil:
+		return t.v.String()
+	case t.isLit:
+		return strconv.FormatInt(t.lit, 10)
+	}
+	return "<invalid>"
+}
+
+func (t Term) sortString() string {
+	if t.v == nil {
+		return "untyped literal"
+	}
+	return t.v.Sort().String()
+}
+
+// unify converts untyped literals in l and r to the sort of the other
+// operand and checks that l and r belong to b and have the same kind
+// of sort.
+func (b *Builder) unify(op string, l, r Term) (Term, Term, bool) {
+	if !b.owns(op, l, r) {
+		return l, r, false
+	}
+	if !l.valid() || !r.valid() {
+		return l, r, false
+	}
+	switch {
+	case l.isLit && r.isLit:
+		b.fail(op, fmt.Errorf("cannot infer sort of untyped literals %d and %d", l.lit, r.lit))
+		return l, r, false
+	case l.isLit:
+		l = b.convert(op, l.lit, r.v.Sort())
+	case r.isLit:
+		r = b.convert(op, r.lit, l.v.Sort())
+	}
+	if !l.valid() || !r.valid() {
+		return l, r, false
+	}
+	if lk, rk := l.v.Sort().Kind(), r.v.Sort().Kind(); lk != rk {
+		b.fail(op, fmt.Errorf("mismatched sorts %s and %s", l.sortString(), r.sortString()))
+		return l, r, false
+	}
+	return l, r, true
+}
+
+// convert returns a literal of the given sort with value val.
+func (b *Builder) convert(op string, val int64, sort z3.Sort) Term {
+	switch sort.Kind() {
+	case z3.KindInt, z3.KindReal, z3.KindBV:
+		return b.try(op, func() z3.Value { return b.ctx.FromInt(val, sort) })
+	}
+	return b.fail(op, fmt.Errorf("cannot use untyped literal %d as %s", val, sort))
+}
+
+type binop map[z3.Kind]func(l, r z3.Value) z3.Value
+
+type unop map[z3.Kind]func(l z3.Value) z3.Value
+
+func (t Term) binary(op string, r Term, ops binop) Term {
+	b := t.b
+	l, r, ok := b.unify(op, t, r)
+	if !ok {
+		return Term{b: b}
+	}
+	f, ok := ops[l.v.Sort().Kind()]
+	if !ok {
+		return b.fail(op, fmt.Errorf("not defined on %s", l.sortString()))
+	}
+	return b.try(op, func() z3.Value { return f(l.v, r.v) })
+}
+
+func (t Term) unary(op string, ops unop) Term {
+	b := t.b
+	switch {
+	case !t.valid():
+		return Term{b: b}
+	case t.isLit:
+		return b.fail(op, fmt.Errorf("cannot infer sort of untyped literal %d", t.lit))
+	}
+	f, ok := ops[t.v.Sort().Kind()]
+	if !ok {
+		return b.fail(op, fmt.Errorf("not defined on %s", t.sortString()))
+	}
+	return b.try(op, func() z3.Value { return f(t.v) })
+}
+
+// Add returns t + r. t and r must be Int, Real, or bit-vectors.
+func (t Term) Add(r Term) Term { return t.binary("Add", r, addOps) }
+
+// Sub returns t - r. t and r must be Int, Real, or bit-vectors.
+func (t Term) Sub(r Term) Term { return t.binary("Sub", r, subOps) }
+
+// Mul returns t * r. t and r must be Int, Real, or bit-vectors.
+func (t Term) Mul(r Term) Term { return t.binary("Mul", r, mulOps) }
+
+// Neg returns -t. t must be Int, Real, or a bit-vector.
+func (t Term) Neg() Term { return t.unary("Neg", negOps) }
+
+// Eq returns a Bool Term that is true if t and r are equal.
+func (t Term) Eq(r Term) Term { return t.binary("Eq", r, eqOps) }
+
+// NE returns a Bool Term that is true if t and r are not equal.
+func (t Term) NE(r Term) Term { return t.binary("NE", r, neOps) }
+
+// LT returns t < r. t and r must be Int or Real.
+func (t Term) LT(r Term) Term { return t.binary("LT", r, ltOps) }
+
+// LE returns t <= r. t and r must be Int or Real.
+func (t Term) LE(r Term) Term { return t.binary("LE", r, leOps) }
+
+// GT returns t > r. t and r must be Int or Real.
+func (t Term) GT(r Term) Term { return t.binary("GT", r, gtOps) }
+
+// GE returns t >= r. t and r must be Int or Real.
+func (t Term) GE(r Term) Term { return t.binary("GE", r, geOps) }
+
+// ULT returns t < r, where t and r are unsigned bit-vectors.
+func (t Term) ULT(r Term) Term { return t.binary("ULT", r, ultOps) }
+
+// ULE returns t <= r, where t and r are unsigned bit-vectors.
+func (t Term) ULE(r Term) Term { return t.binary("ULE", r, uleOps) }
+
+// UGT returns t > r, where t and r are unsigned bit-vectors.
+func (t Term) UGT(r Term) Term { return t.binary("UGT", r, ugtOps) }
+
+// UGE returns t >= r, where t and r are unsigned bit-vectors.
+func (t Term) UGE(r Term) Term { return t.binary("UGE", r, ugeOps) }
+
+// SLT returns t < r, where t and r are signed bit-vectors.
+func (t Term) SLT(r Term) Term { return t.binary("SLT", r, sltOps) }
+
+// SLE returns t <= r, where t and r are signed bit-vectors.
+func (t Term) SLE(r Term) Term { return t.binary("SLE", r, sleOps) }
+
+// SGT returns t > r, where t and r are signed bit-vectors.
+func (t Term) SGT(r Term) Term { return t.binary("SGT", r, sgtOps) }
+
+// SGE returns t >= r, where t and r are signed bit-vectors.
+func (t Term) SGE(r Term) Term { return t.binary("SGE", r, sgeOps) }
+
+// And returns t && r. t and r must be Bools.
+func (t Term) And(r Term) Term { return t.binary("And", r, andOps) }
+
+// Or returns t || r. t and r must be Bools.
+func (t Term) Or(r Term) Term { return t.binary("Or", r, orOps) }
+
+// Implies returns a Bool Term that is true if t implies r. t and r
+// must be Bools.
+func (t Term) Implies(r Term) Term { return t.binary("Implies", r, impliesOps) }
+
+// Not returns !t. t must be a Bool.
+func (t Term) Not() Term { return t.unary("Not", notOps) }
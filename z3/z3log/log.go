@@ -2,9 +2,10 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-// Package z3log exposes Z3's interaction log.
+// Package z3log exposes Z3's interaction log and internal tracing.
 //
-// The interaction log is a low-level trace of all Z3 API calls.
+// The interaction log is a low-level trace of all Z3 API calls, which
+// Z3 can replay to reproduce a problem outside of the Go program.
 package z3log
 
 import "unsafe"
@@ -22,7 +23,7 @@ import "C"
 func Open(filename string) bool {
 	cfilename := C.CString(filename)
 	defer C.free(unsafe.Pointer(cfilename))
-	return C.Z3_open_log(cfilename) != 0
+	return bool(C.Z3_open_log(cfilename))
 }
 
 // Append emits text to the Z3 interaction log.
@@ -36,3 +37,21 @@ func Append(text string) {
 func Close() {
 	C.Z3_close_log()
 }
+
+// EnableTrace enables Z3's internal tracing for tag. Traces are
+// written to the file ".z3-trace" in the current directory.
+//
+// Tracing is only available if the Z3 library was built in debug or
+// trace mode; otherwise EnableTrace does nothing.
+func EnableTrace(tag string) {
+	ctag := C.CString(tag)
+	defer C.free(unsafe.Pointer(ctag))
+	C.Z3_enable_trace(ctag)
+}
+
+// DisableTrace disables Z3's internal tracing for tag.
+func DisableTrace(tag string) {
+	ctag := C.CString(tag)
+	defer C.free(unsafe.Pointer(ctag))
+	C.Z3_disable_trace(ctag)
+}
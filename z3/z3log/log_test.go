@@ -0,0 +1,41 @@
+// Copyright 2017 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package z3log
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "z3log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	name := filepath.Join(dir, "z3.log")
+	if !Open(name) {
+		t.Fatalf("Open(%q) failed", name)
+	}
+	Append("hello from Go")
+	Close()
+
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "hello from Go") {
+		t.Errorf("log does not contain appended text:\n%s", data)
+	}
+
+	// Tracing is a no-op in release builds of Z3, but must not
+	// fail.
+	EnableTrace("z3log_test")
+	DisableTrace("z3log_test")
+}